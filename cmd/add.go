@@ -1,15 +1,25 @@
 package cmd
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
 	"maajise/internal/beads"
+	"maajise/internal/config"
+	"maajise/internal/fsutil"
 	"maajise/internal/git"
+	"maajise/internal/managed"
+	"maajise/internal/manifest"
+	"maajise/internal/source"
+	"maajise/internal/txn"
 	"maajise/internal/ui"
+	"maajise/internal/validate"
 	"maajise/templates"
 )
 
@@ -19,6 +29,16 @@ type AddCommand struct {
 	template string
 	verbose  bool
 	dryRun   bool
+	diffOnly bool
+	branch   string
+	vars     templates.TemplateVars
+	features []string
+	manifest *manifest.Manifest
+
+	// For add project
+	varFlags  varList
+	skipHooks bool
+	tx        *txn.Tx // records what add project writes, for rollback
 }
 
 // Tooling items that can be added
@@ -34,10 +54,19 @@ func NewAddCommand() *AddCommand {
 	}
 
 	ac.fs.BoolVar(&ac.force, "force", false, "Overwrite existing files")
-	ac.fs.StringVar(&ac.template, "template", "", "Template for file content (auto-detects if not specified)")
+	ac.fs.StringVar(&ac.template, "template", "", "Template for file content (default: from .maajise.lock, else auto-detect)")
 	ac.fs.BoolVar(&ac.verbose, "v", false, "Verbose output")
 	ac.fs.BoolVar(&ac.verbose, "verbose", false, "Verbose output")
-	ac.fs.BoolVar(&ac.dryRun, "dry-run", false, "Preview without making changes")
+	ac.fs.BoolVar(&ac.dryRun, "dry-run", false, "Preview without making changes (shows a diff)")
+	ac.fs.BoolVar(&ac.diffOnly, "diff-only", false, "Dry run that only summarizes changed line counts")
+	ac.fs.Var(&ac.varFlags, "var", "Template variable for 'add project' as key=value (repeatable)")
+	ac.fs.BoolVar(&ac.skipHooks, "skip-hooks", false, "Skip the template's hooks for 'add project'")
+
+	branch := config.DefaultMainBranch
+	if fc, err := config.LoadFileConfig(); err == nil && fc != nil && fc.Defaults.MainBranch != "" {
+		branch = fc.Defaults.MainBranch
+	}
+	ac.fs.StringVar(&ac.branch, "branch", branch, "Initial branch for 'add git'")
 
 	return ac
 }
@@ -54,30 +83,75 @@ func (ac *AddCommand) LongDescription() string {
 	return `Add files or tooling components to an existing project.
 
 Add Git repositories, Beads issue tracking, .gitignore files, .ubsignore files, or
-README.md files to an existing project. If the template is not specified, it will be
-auto-detected based on the project structure.`
+README.md files to an existing project. If the template is not specified, it is read from
+.maajise.lock, or auto-detected based on the project structure when there is no manifest.
+Added files are recorded in .maajise.lock.
+
+'maajise add license <spdx>' writes LICENSE for the given SPDX id (MIT, Apache-2.0,
+BSD-2-Clause, BSD-3-Clause, GPL-3.0-only, GPL-3.0-or-later, ISC, MPL-2.0, Unlicense) and records
+it as the project's license; run 'maajise update' to apply it to package.json, Cargo.toml, etc.
+
+'maajise add feature <name>...' layers features on top of the project's template, e.g. a
+Dockerfile or CI workflow tailored to its language, and adds their lines to .gitignore. Added
+features are recorded in .maajise.lock so later 'add' and 'update' runs include their files.
+
+'maajise add project <name> --template=<template>' creates a project in apps/<name> of a
+workspace (see 'maajise init --workspace'), with its own .maajise.lock, and runs the template's
+hooks there. The project shares the workspace's Git repository, Beads database and license; its
+.gitignore and .ubsignore rules are added to the workspace's, scoped to apps/<name>. Go,
+TypeScript and Rust projects are added to the workspace's go.work, package.json and
+pnpm-workspace.yaml, or Cargo.toml. --template defaults to base.
+
+If .gitignore or .ubsignore already contains maajise-managed blocks ("# >>> maajise:<name>"
+... "# <<< maajise:<name>"), only the content between the markers is rewritten and your own
+lines outside them are kept; --force is not needed for that.`
 }
 
 func (ac *AddCommand) Usage() string {
-	return "maajise add <item> [flags]"
+	return "maajise add [flags] <item>...\n  maajise add [flags] feature <name>...\n  maajise add [flags] license [spdx]\n  maajise add [flags] project <name>"
 }
 
 func (ac *AddCommand) Examples() string {
 	return `  # Add Git initialization
   maajise add git
 
+  # Add Git with a specific initial branch
+  maajise add --branch=trunk git
+
   # Add Beads issue tracking
   maajise add beads
 
   # Add .ubsignore file
   maajise add ubs
 
+  # Add a Dockerfile and CI workflow tailored to the project's template
+  maajise add feature docker ci
+
+  # Add a LICENSE file, switching the project to Apache-2.0
+  maajise add license Apache-2.0
+      Without an id, uses the license recorded in .maajise.lock
+
+  # Add projects to a workspace created with 'maajise init platform --workspace'
+  maajise add project api --template=go
+  maajise add project web --template=typescript
+      Creates apps/api and apps/web, and lists them in go.work and package.json
+
+  # Add a workspace project without running its hooks, answering a template question
+  maajise add project billing --template=java --var build=maven --skip-hooks
+
   # Add .gitignore with specific template
   maajise add .gitignore --template=typescript
 
   # Preview changes without applying
   maajise add --dry-run git
 
+  # Review what --force would change in an existing file
+  maajise add --dry-run --force .gitignore
+      Shows a colored unified diff against the file on disk
+
+  # Summarize changed line counts only
+  maajise add --diff-only .gitignore readme
+
   # Force overwrite existing files
   maajise add .gitignore --force
       Overwrites .gitignore even if it exists
@@ -88,11 +162,14 @@ func (ac *AddCommand) Examples() string {
 }
 
 func (ac *AddCommand) Run(args []string) error {
-	if err := ac.fs.Parse(args); err != nil {
+	if err := ParseFlags(ac.fs, args); err != nil {
 		return err
 	}
 
 	items := ac.fs.Args()
+	if ac.diffOnly {
+		ac.dryRun = true
+	}
 	if len(items) == 0 {
 		return ac.showHelp()
 	}
@@ -102,14 +179,30 @@ func (ac *AddCommand) Run(args []string) error {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	projectName := filepath.Base(cwd)
+	// Prefer the manifest over marker-file detection
+	ac.manifest = loadManifest(cwd)
+	ac.vars = projectVars(cwd, ac.manifest)
+	projectName := ac.vars.ProjectName
 
-	// Detect template if not specified
-	if ac.template == "" {
-		ac.template = ac.detectTemplate(cwd)
-		if ac.verbose {
-			ui.Info(fmt.Sprintf("Detected template: %s", ac.template))
-		}
+	// add project takes the sub-project's template, not the workspace's
+	if strings.ToLower(items[0]) == "project" {
+		return ac.addProject(cwd, items[1:])
+	}
+
+	var source string
+	ac.template, source = projectTemplate(cwd, ac.template, ac.manifest)
+	if ac.verbose {
+		ui.Info(fmt.Sprintf("Template: %s (%s)", ac.template, source))
+	}
+	if ac.manifest != nil {
+		ac.features = ac.manifest.Features
+	}
+
+	switch strings.ToLower(items[0]) {
+	case "feature":
+		return ac.addFeatures(cwd, projectName, items[1:])
+	case "license":
+		return ac.addLicense(cwd, projectName, items[1:])
 	}
 
 	for _, item := range items {
@@ -134,6 +227,15 @@ func (ac *AddCommand) showHelp() error {
 	fmt.Println("  .gitignore   Add .gitignore from template")
 	fmt.Println("  .ubsignore   Add .ubsignore from template")
 	fmt.Println("  readme       Add README.md from template")
+	fmt.Println("  license      Add LICENSE (maajise add license <spdx>)")
+	fmt.Println()
+	fmt.Println("Workspaces:")
+	fmt.Println("  project      Add a project in apps/ (maajise add project <name> --template=<template>)")
+	fmt.Println()
+	fmt.Println("Features (maajise add feature <name>...):")
+	for _, f := range templates.AllFeatures() {
+		fmt.Printf("  %-12s  %s\n", f.Name(), f.Description())
+	}
 	fmt.Println()
 	return nil
 }
@@ -143,6 +245,12 @@ func (ac *AddCommand) addItem(dir, projectName, item string) error {
 	itemLower := strings.ToLower(item)
 
 	// Check if it's a tooling item
+	// A workspace project shares the root's repository and issue database
+	if ac.manifest != nil && ac.manifest.Workspace != "" && (itemLower == "git" || itemLower == "beads") {
+		ui.Warn(fmt.Sprintf("Skipped %s (set up at the workspace root, %s)", item, ac.manifest.Workspace))
+		return nil
+	}
+
 	switch itemLower {
 	case "git":
 		return ac.addGit(dir)
@@ -154,6 +262,8 @@ func (ac *AddCommand) addItem(dir, projectName, item string) error {
 		return ac.addFile(dir, projectName, ".gitignore")
 	case "readme", "readme.md":
 		return ac.addFile(dir, projectName, "README.md")
+	case "license", "licence":
+		return ac.addFile(dir, projectName, templates.LicenseFile)
 	default:
 		// Try as template file
 		return ac.addFile(dir, projectName, item)
@@ -167,16 +277,20 @@ func (ac *AddCommand) addGit(dir string) error {
 		return nil
 	}
 
+	if err := validate.ValidateBranchName(ac.branch); err != nil {
+		return ui.UsageError("add", fmt.Sprintf("invalid branch %q: %v", ac.branch, err))
+	}
+
 	if ac.dryRun {
-		ui.Info("[dry-run] Would initialize Git repository")
+		ui.Info(fmt.Sprintf("[dry-run] Would initialize Git repository (branch: %s)", ac.branch))
 		return nil
 	}
 
-	if err := git.Init(dir, ac.verbose); err != nil {
+	if err := git.Init(dir, ac.branch, ac.verbose); err != nil {
 		return err
 	}
 
-	ui.Success("Initialized Git repository")
+	ui.Success(fmt.Sprintf("Initialized Git repository on branch %s", ac.branch))
 	return nil
 }
 
@@ -200,55 +314,526 @@ func (ac *AddCommand) addBeads(dir string) error {
 	return nil
 }
 
-func (ac *AddCommand) addFile(dir, projectName, filename string) error {
-	tmpl, ok := templates.Get(ac.template)
-	if !ok {
-		return ui.UsageError("add", fmt.Sprintf("unknown template: %s (available: base, typescript, python, rust, php, go, swift)", ac.template))
+// addFeatures adds the files of the named features and records the features
+// in the manifest
+func (ac *AddCommand) addFeatures(dir, projectName string, names []string) error {
+	if len(names) == 0 {
+		return ui.UsageError("add", fmt.Sprintf("feature name required (available: %s)", strings.Join(templates.FeatureNames(), ", ")))
+	}
+	names, err := templates.ParseFeatures(strings.Join(names, ","))
+	if err != nil {
+		return ui.UsageError("add", err.Error())
 	}
 
-	files := tmpl.Files(projectName)
-	content, ok := files[filename]
-	if !ok {
-		return ui.UsageError("add", fmt.Sprintf("file %s not found in template %s", filename, ac.template))
+	tmpl, err := lookupTemplate("add", ac.template)
+	if err != nil {
+		return err
 	}
+	base := templates.BaseOf(tmpl)
 
-	path := filepath.Join(dir, filename)
+	// Record the features first so addFile renders their files
+	for _, name := range names {
+		if !slices.Contains(ac.features, name) {
+			ac.features = append(ac.features, name)
+		}
+	}
 
-	if ac.fileExists(path) && !ac.force {
-		ui.Warn(fmt.Sprintf("Skipped %s (exists, use --force to overwrite)", filename))
+	var ignores []templates.Feature
+	for _, name := range names {
+		f, _ := templates.GetFeature(name)
+		files := f.Files(base, ac.vars)
+		filenames := make([]string, 0, len(files))
+		for filename := range files {
+			filenames = append(filenames, filename)
+		}
+		sort.Strings(filenames)
+		for _, filename := range filenames {
+			if err := ac.addFile(dir, projectName, filename); err != nil {
+				ui.Error(fmt.Sprintf("Failed to add %s: %v", filename, err))
+			}
+		}
+		if len(f.Gitignore(base)) > 0 {
+			ignores = append(ignores, f)
+		}
+	}
+	if len(ignores) > 0 {
+		if err := ac.addFeatureIgnores(dir, projectName, base, ignores); err != nil {
+			ui.Error(fmt.Sprintf("Failed to add .gitignore: %v", err))
+		}
+	}
+
+	if ac.dryRun {
 		return nil
 	}
+	if ac.manifest == nil {
+		ac.manifest = manifest.New(ac.template, Version, ac.vars)
+	}
+	ac.manifest.Features = ac.features
+	if err := ac.manifest.Save(dir); err != nil {
+		return fmt.Errorf("failed to write %s: %w", manifest.FileName, err)
+	}
+	ui.Success(fmt.Sprintf("Added features: %s", strings.Join(names, ", ")))
+	return nil
+}
+
+// addLicense writes LICENSE for the given SPDX id, or the project's recorded
+// license, and records the license in the manifest
+func (ac *AddCommand) addLicense(dir, projectName string, args []string) error {
+	if len(args) > 1 {
+		return ui.UsageError("add", "add license takes a single SPDX id")
+	}
+	if ac.vars.ProjectName == "" {
+		ac.vars = templates.DefaultVars(projectName)
+	}
+
+	license := ac.vars.License
+	if len(args) == 1 {
+		id, err := parseLicense("add", args[0])
+		if err != nil {
+			return err
+		}
+		license = id
+	}
+	id, ok := templates.LicenseID(license)
+	if !ok {
+		return ui.UsageError("add", fmt.Sprintf("no license text for %q (available: %s)", licenseLabel(license), strings.Join(templates.Licenses(), ", ")))
+	}
+
+	// addFile records the new license in the manifest only if it writes LICENSE
+	changed := id != ac.vars.License
+	ac.vars.License = id
+	if ac.manifest != nil {
+		ac.manifest.Vars.License = id
+	}
+	if err := ac.addFile(dir, projectName, templates.LicenseFile); err != nil {
+		return err
+	}
+
+	if changed && !ac.dryRun {
+		text, err := templates.LicenseText(id, ac.vars)
+		if err != nil {
+			return err
+		}
+		if data, err := os.ReadFile(filepath.Join(dir, templates.LicenseFile)); err == nil && string(data) == text {
+			ui.Info(fmt.Sprintf("Run 'maajise update' to use %s in the other generated files", id))
+		}
+	}
+	return nil
+}
+
+// addProject creates a project in apps/<name> of a workspace with its own
+// manifest, records it in the workspace manifest and refreshes the
+// workspace files that list the projects. Flags may follow the name. Like
+// init, it is transactional: a failure or Ctrl-C removes the project and
+// restores the workspace files.
+func (ac *AddCommand) addProject(dir string, args []string) (err error) {
+	if len(args) == 0 {
+		return ui.UsageError("add", "project name required")
+	}
+	name := args[0]
+	if err := ac.fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if ac.fs.NArg() > 0 {
+		return ui.UsageError("add", "add project takes a single project name")
+	}
+	if ac.diffOnly {
+		ac.dryRun = true
+	}
+
+	if ac.manifest == nil || ac.manifest.Template != templates.WorkspaceName {
+		return ui.UsageError("add", "add project must run at the root of a workspace (create one with 'maajise init --workspace')")
+	}
+	if !projectNamePattern.MatchString(name) {
+		return ui.UsageError("add", "invalid characters in project name (use only letters, numbers, hyphens, and underscores)")
+	}
+	if slices.ContainsFunc(ac.manifest.Projects, func(p templates.Project) bool { return p.Name == name }) {
+		return ui.UsageError("add", fmt.Sprintf("project %s already exists", name))
+	}
+
+	project := templates.Project{Name: name, Template: "base"}
+	if ac.template != "" {
+		project.Template = source.Canonical(ac.template)
+	}
+	projectDir := filepath.Join(dir, filepath.FromSlash(project.Dir()))
+	if fsutil.PathExists(projectDir) {
+		return fmt.Errorf("%s already exists", project.Dir())
+	}
+
+	tmpl, err := lookupTemplate("add", project.Template)
+	if err != nil {
+		return err
+	}
+	if templates.BaseOf(tmpl) == templates.WorkspaceName {
+		return ui.UsageError("add", "workspaces cannot be nested")
+	}
+
+	vars := workspaceVars(ac.manifest, name)
+	if err := applyVarFlags(&vars, ac.varFlags); err != nil {
+		return ui.UsageError("add", err.Error())
+	}
+	if err := prepareVars("add", tmpl, &vars); err != nil {
+		return err
+	}
+	files, err := renderTemplate(tmpl, vars)
+	if err != nil {
+		return err
+	}
 
+	rel, err := filepath.Rel(projectDir, dir)
+	if err != nil {
+		return err
+	}
+	m := manifest.New(project.Template, Version, vars)
+	m.Workspace = filepath.ToSlash(rel)
+	if err := projectFiles("add", projectDir, m, files); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if !ac.dryRun {
+		ac.tx = txn.New()
+		var stopInterrupt func()
+		ctx, stopInterrupt = cancelOnInterrupt()
+		defer stopInterrupt()
+		defer func() {
+			if err != nil {
+				rollback(ac.tx, "project "+name, ac.verbose)
+			}
+		}()
+		if err := ac.track(projectDir); err != nil {
+			return err
+		}
+	}
+
+	filenames := make([]string, 0, len(files))
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		path, err := fsutil.SafeJoin(projectDir, filename)
+		if err != nil {
+			return fmt.Errorf("template %s: %w", tmpl.Name(), err)
+		}
+		if ac.dryRun {
+			ui.Info(fmt.Sprintf("[dry-run] Would create: %s/%s", project.Dir(), filename))
+			continue
+		}
+		if err := writeGenerated(path, files[filename], templates.FileMode(templates.Modes(tmpl, vars), filename, files[filename])); err != nil {
+			return err
+		}
+		if err := recordGenerated(projectDir, m, filename, files[filename]); err != nil {
+			return err
+		}
+		if ac.verbose {
+			ui.Success(fmt.Sprintf("Created %s/%s", project.Dir(), filename))
+		}
+	}
+	if !ac.dryRun {
+		if err := m.Save(projectDir); err != nil {
+			return fmt.Errorf("failed to write %s: %w", manifest.FileName, err)
+		}
+	}
+	if ctx.Err() != nil {
+		return ErrInterrupted
+	}
+
+	// Refresh the workspace files before the hooks, so that e.g. go mod tidy
+	// and cargo already see the project as a workspace member
+	ac.manifest.Projects = append(ac.manifest.Projects, project)
+	if err := ac.refreshWorkspace(dir); err != nil {
+		return err
+	}
 	if ac.dryRun {
-		if ac.fileExists(path) {
-			ui.Info(fmt.Sprintf("[dry-run] Would overwrite: %s", filename))
-		} else {
-			ui.Info(fmt.Sprintf("[dry-run] Would create: %s", filename))
+		return nil
+	}
+	if err := ac.trackManifest(dir); err != nil {
+		return err
+	}
+	if err := ac.manifest.Save(dir); err != nil {
+		return fmt.Errorf("failed to write %s: %w", manifest.FileName, err)
+	}
+	if ctx.Err() != nil {
+		return ErrInterrupted
+	}
+
+	if err := ac.runHooks(ctx, dir, projectDir, tmpl, vars); err != nil {
+		return err
+	}
+	ac.tx.Commit()
+	ui.Success(fmt.Sprintf("Added project %s (%s) in %s", name, project.Template, project.Dir()))
+	return nil
+}
+
+// runHooks runs the project template's hooks in projectDir, unless
+// --skip-hooks. What they create anywhere in the workspace, such as a
+// go.work.sum at its root, is added to the transaction.
+func (ac *AddCommand) runHooks(ctx context.Context, dir, projectDir string, tmpl templates.Template, vars templates.TemplateVars) error {
+	hooks, err := activeHooks(tmpl, vars)
+	if err != nil || len(hooks) == 0 {
+		return err
+	}
+	if ac.skipHooks {
+		ui.Info(fmt.Sprintf("Skipping %d template hooks (--skip-hooks)", len(hooks)))
+		return nil
+	}
+
+	before, err := txn.TakeSnapshot(dir)
+	if err != nil {
+		return err
+	}
+	_, err = runHooks(ctx, projectDir, hooks, ac.verbose)
+	if trackErr := ac.tx.TrackNew(before); err == nil {
+		err = trackErr
+	}
+	return err
+}
+
+// track records path in the add project transaction before it is written
+func (ac *AddCommand) track(path string) error {
+	return trackPath(ac.tx, path)
+}
+
+// trackManifest records the manifest in dir and its baselines, which saving
+// the manifest rewrites and prunes
+func (ac *AddCommand) trackManifest(dir string) error {
+	if err := ac.track(manifest.Path(dir)); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, filepath.FromSlash(manifest.BaselineDir)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, entry := range entries {
+		if err := ac.track(filepath.Join(dir, filepath.FromSlash(manifest.BaselineDir), entry.Name())); err != nil {
+			return err
 		}
+	}
+	return nil
+}
+
+// refreshWorkspace regenerates the workspace files that list its projects,
+// merging them with local changes the way update does
+func (ac *AddCommand) refreshWorkspace(dir string) error {
+	tmpl, err := composeTemplate("add", ac.manifest.Template, ac.manifest.Features)
+	if err != nil {
+		return err
+	}
+	vars := ac.vars
+	if err := prepareVars("add", tmpl, &vars); err != nil {
+		return err
+	}
+	files, err := renderTemplate(tmpl, vars)
+	if err != nil {
+		return err
+	}
+	if err := projectFiles("add", dir, ac.manifest, files); err != nil {
+		return err
+	}
+
+	uc := &UpdateCommand{force: ac.force, diffOnly: ac.diffOnly}
+	modes := templates.Modes(tmpl, vars)
+	for _, filename := range templates.WorkspaceFiles {
+		content, ok := files[filename]
+		if !ok {
+			continue
+		}
+		plan, err := uc.planFile(dir, ac.manifest, filename, content)
+		if err != nil {
+			return err
+		}
+		if ac.dryRun {
+			uc.reportDryRun(filename, plan)
+			continue
+		}
+
+		switch plan.action {
+		case actionSkip:
+			ui.Warn(fmt.Sprintf("Skipped %s (exists, no baseline to merge with; use --force to overwrite)", filename))
+			continue
+		case actionUnchanged:
+		default:
+			if err := ac.track(filepath.Join(dir, filename)); err != nil {
+				return err
+			}
+			if err := writeGenerated(filepath.Join(dir, filename), plan.content, templates.FileMode(modes, filename, plan.content)); err != nil {
+				return fmt.Errorf("failed to write %s: %w", filename, err)
+			}
+		}
+		if err := ac.track(manifest.BaselinePath(dir, manifest.Hash(content))); err != nil {
+			return err
+		}
+		if err := recordGenerated(dir, ac.manifest, filename, content); err != nil {
+			return err
+		}
+
+		switch plan.action {
+		case actionCreate:
+			ui.Success(fmt.Sprintf("Created %s", filename))
+		case actionConflict:
+			ui.Error(fmt.Sprintf("Conflict in %s (%d hunks, resolve the markers)", filename, plan.conflicts))
+		case actionUnchanged:
+		default:
+			ui.Success(fmt.Sprintf("Updated %s", filename))
+		}
+	}
+	return nil
+}
+
+// addFeatureIgnores adds the .gitignore lines of features. A .gitignore
+// without managed blocks is the user's own, so the feature blocks are
+// appended to it instead of replacing it.
+func (ac *AddCommand) addFeatureIgnores(dir, projectName, base string, features []templates.Feature) error {
+	path, err := fsutil.SafeJoin(dir, ".gitignore")
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || managed.Has(string(data)) || ac.force {
+		return ac.addFile(dir, projectName, ".gitignore")
+	}
+
+	current := string(data)
+	target := current
+	for _, f := range features {
+		block := managed.Wrap(templates.FeatureBlock(f.Name()), strings.Join(f.Gitignore(base), "\n"))
+		if !strings.HasSuffix(target, "\n") {
+			target += "\n"
+		}
+		if !strings.HasSuffix(target, "\n\n") {
+			target += "\n"
+		}
+		target += block
+	}
+
+	if ac.dryRun {
+		ac.previewFile(".gitignore", current, target, true, true)
 		return nil
 	}
+	if err := os.WriteFile(path, []byte(target), 0644); err != nil {
+		return err
+	}
+	ui.Success("Added feature blocks to .gitignore")
+	return nil
+}
+
+func (ac *AddCommand) addFile(dir, projectName, filename string) error {
+	tmpl, err := composeTemplate("add", ac.template, ac.features)
+	if err != nil {
+		return err
+	}
+
+	// Render with the recorded variables, or defaults when called without Run
+	vars := ac.vars
+	if vars.ProjectName == "" {
+		vars = templates.DefaultVars(projectName)
+	}
+	if err := prepareVars("add", tmpl, &vars); err != nil {
+		return err
+	}
+	files, err := renderTemplate(tmpl, vars)
+	if err != nil {
+		return err
+	}
+	if err := projectFiles("add", dir, ac.manifest, files); err != nil {
+		return err
+	}
+
+	content, ok := files[filename]
+	if !ok && ac.manifest != nil && ac.manifest.Workspace != "" && slices.Contains(templates.WorkspaceOwned, filename) {
+		return ui.UsageError("add", fmt.Sprintf("%s is generated by the workspace root (%s)", filename, ac.manifest.Workspace))
+	}
+	if !ok {
+		return ui.UsageError("add", fmt.Sprintf("file %s not found in template %s", filename, ac.template))
+	}
+
+	path, err := fsutil.SafeJoin(dir, filename)
+	if err != nil {
+		return fmt.Errorf("template %s: %w", tmpl.Name(), err)
+	}
+	existed := ac.fileExists(path)
 
-	// Create parent directories if needed
-	parentDir := filepath.Dir(path)
-	if parentDir != "." && parentDir != "" {
-		if err := os.MkdirAll(parentDir, 0755); err != nil {
+	current := ""
+	if existed {
+		data, err := os.ReadFile(path)
+		if err != nil {
 			return err
 		}
+		current = string(data)
+	}
+
+	// Files with managed blocks only get the blocks refreshed, so no --force is needed
+	target := content
+	refresh := false
+	if existed {
+		if spliced, ok, err := managed.Splice(current, content); err != nil {
+			ui.Warn(fmt.Sprintf("Ignoring managed blocks in %s: %v", filename, err))
+		} else if ok {
+			target, refresh = spliced, true
+		}
+	}
+
+	if ac.dryRun {
+		ac.previewFile(filename, current, target, existed, refresh)
+		return nil
 	}
 
-	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+	if existed && !ac.force && !refresh {
+		ui.Warn(fmt.Sprintf("Skipped %s (exists, use --force to overwrite)", filename))
+		return nil
+	}
+
+	if err := writeGenerated(path, target, templates.FileMode(templates.Modes(tmpl, vars), filename, target)); err != nil {
 		return err
 	}
 
-	if ac.fileExists(path) && ac.force {
+	if ac.manifest == nil {
+		ac.manifest = manifest.New(ac.template, Version, vars)
+	}
+	if err := recordGenerated(dir, ac.manifest, filename, content); err != nil {
+		return err
+	}
+	if err := ac.manifest.Save(dir); err != nil {
+		return fmt.Errorf("failed to write %s: %w", manifest.FileName, err)
+	}
+
+	switch {
+	case refresh:
+		ui.Success(fmt.Sprintf("Refreshed managed blocks in %s", filename))
+	case existed:
 		ui.Success(fmt.Sprintf("Updated %s", filename))
-	} else {
+	default:
 		ui.Success(fmt.Sprintf("Created %s", filename))
 	}
 
 	return nil
 }
 
+// previewFile reports what addFile would do, with a diff against the file on disk.
+// Existing files are diffed even without --force so the overwrite can be reviewed.
+func (ac *AddCommand) previewFile(filename, current, target string, existed, refresh bool) {
+	if !ac.diffOnly {
+		switch {
+		case !existed:
+			ui.Info(fmt.Sprintf("[dry-run] Would create: %s", filename))
+		case current == target:
+			ui.Info(fmt.Sprintf("[dry-run] Unchanged: %s", filename))
+		case refresh:
+			ui.Info(fmt.Sprintf("[dry-run] Would refresh managed blocks: %s", filename))
+		case ac.force:
+			ui.Info(fmt.Sprintf("[dry-run] Would overwrite: %s", filename))
+		default:
+			ui.Info(fmt.Sprintf("[dry-run] Would skip (exists, use --force to overwrite): %s", filename))
+		}
+	}
+
+	if current != target || !existed {
+		previewChange(filename, current, target, existed, ac.diffOnly)
+	}
+}
+
 func (ac *AddCommand) fileExists(path string) bool {
 	info, err := os.Stat(path)
 	return err == nil && !info.IsDir()
@@ -259,28 +844,6 @@ func (ac *AddCommand) dirExists(path string) bool {
 	return err == nil && info.IsDir()
 }
 
-func (ac *AddCommand) detectTemplate(dir string) string {
-	checks := []struct {
-		file     string
-		template string
-	}{
-		{"Package.swift", "swift"},
-		{"package.json", "typescript"},
-		{"Cargo.toml", "rust"},
-		{"pyproject.toml", "python"},
-		{"composer.json", "php"},
-		{"go.mod", "go"},
-	}
-
-	for _, check := range checks {
-		if ac.fileExists(filepath.Join(dir, check.file)) {
-			return check.template
-		}
-	}
-
-	return "base"
-}
-
 func init() {
 	Register(NewAddCommand())
 }
@@ -3,9 +3,13 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
-	_ "maajise/templates"
+	"maajise/internal/managed"
+	"maajise/internal/manifest"
+	"maajise/templates"
 )
 
 func TestAddCommand_Name(t *testing.T) {
@@ -112,72 +116,264 @@ func TestAddCommand_AddFile_SkipExisting(t *testing.T) {
 	}
 }
 
-func TestAddCommand_DetectTemplate_PackageJson(t *testing.T) {
+func TestAddCommand_AddFile_RefreshManagedBlocks(t *testing.T) {
 	ac := NewAddCommand()
+	ac.template = "base"
 
-	tmpDir, err := os.MkdirTemp("", "maajise-detect-test-*")
+	tmpDir, err := os.MkdirTemp("", "maajise-add-test-*")
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer os.RemoveAll(tmpDir)
 
-	// Create package.json
-	os.WriteFile(filepath.Join(tmpDir, "package.json"), []byte("{}"), 0644)
+	// Managed blocks are refreshed without --force, user lines are kept
+	existingPath := filepath.Join(tmpDir, ".gitignore")
+	os.WriteFile(existingPath, []byte("my-notes/\n"+managed.Wrap("base", "old/\n")), 0644)
+
+	if err := ac.addFile(tmpDir, "test-project", ".gitignore"); err != nil {
+		t.Fatalf("addFile() error = %v", err)
+	}
 
-	detected := ac.detectTemplate(tmpDir)
-	if detected != "typescript" {
-		t.Errorf("detectTemplate() = %q, want %q", detected, "typescript")
+	content, _ := os.ReadFile(existingPath)
+	got := string(content)
+	if !strings.HasPrefix(got, "my-notes/\n") {
+		t.Errorf("user lines outside the markers were not kept:\n%s", got)
+	}
+	if strings.Contains(got, "old/") || !strings.Contains(got, "node_modules/") {
+		t.Errorf("managed block was not refreshed:\n%s", got)
 	}
 }
 
-func TestAddCommand_DetectTemplate_PackageSwift(t *testing.T) {
+func TestAddCommand_AddFile_SymlinkedParent(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-add-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tmplDir := filepath.Join(tmpDir, "tmpl")
+	os.MkdirAll(tmplDir, 0755)
+	os.WriteFile(filepath.Join(tmplDir, "template.yaml"), []byte("name: docs\nfiles:\n  docs/guide.md: guide\n"), 0644)
+
+	project := filepath.Join(tmpDir, "project")
+	outside := filepath.Join(tmpDir, "outside")
+	os.MkdirAll(project, 0755)
+	os.MkdirAll(outside, 0755)
+	if err := os.Symlink(outside, filepath.Join(project, "docs")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
 	ac := NewAddCommand()
+	ac.template = tmplDir
+	err = ac.addFile(project, "project", "docs/guide.md")
+	if err == nil || !strings.Contains(err.Error(), "symlink") {
+		t.Errorf("addFile() error = %v, want a symlink error", err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "guide.md")); err == nil {
+		t.Error("addFile() wrote through a symlinked directory")
+	}
+}
 
-	tmpDir, err := os.MkdirTemp("", "maajise-detect-test-*")
+func TestAddCommand_AddFeatures(t *testing.T) {
+	ac := NewAddCommand()
+	ac.template = "go"
+
+	tmpDir, err := os.MkdirTemp("", "maajise-add-test-*")
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer os.RemoveAll(tmpDir)
 
-	// Create Package.swift
-	os.WriteFile(filepath.Join(tmpDir, "Package.swift"), []byte("// swift package"), 0644)
+	// A hand-written .gitignore gets the feature block appended
+	gitignorePath := filepath.Join(tmpDir, ".gitignore")
+	os.WriteFile(gitignorePath, []byte("my-notes/\n"), 0644)
+
+	if err := ac.addFeatures(tmpDir, "svc", []string{"docker", "editorconfig"}); err != nil {
+		t.Fatalf("addFeatures() error = %v", err)
+	}
+
+	dockerfile, _ := os.ReadFile(filepath.Join(tmpDir, "Dockerfile"))
+	if !strings.Contains(string(dockerfile), "./cmd/svc") {
+		t.Errorf("Dockerfile = %q, want a Go build of cmd/svc", dockerfile)
+	}
+	if !ac.fileExists(filepath.Join(tmpDir, ".editorconfig")) {
+		t.Error("addFeatures() did not create .editorconfig")
+	}
 
-	detected := ac.detectTemplate(tmpDir)
-	if detected != "swift" {
-		t.Errorf("detectTemplate() = %q, want %q", detected, "swift")
+	gitignore, _ := os.ReadFile(gitignorePath)
+	want := "my-notes/\n\n" + managed.Wrap("feature-docker", "# Docker\ndocker-compose.override.yml")
+	if string(gitignore) != want {
+		t.Errorf(".gitignore = %q, want %q", gitignore, want)
+	}
+
+	m, err := manifest.Load(tmpDir)
+	if err != nil || m == nil {
+		t.Fatalf("manifest.Load() = %v, %v", m, err)
+	}
+	if strings.Join(m.Features, ",") != "docker,editorconfig" {
+		t.Errorf("Features = %v, want [docker editorconfig]", m.Features)
+	}
+	if _, ok := m.Files["Dockerfile"]; !ok {
+		t.Error("Dockerfile not recorded in the manifest")
+	}
+
+	if err := ac.addFeatures(tmpDir, "svc", []string{"kubernetes"}); err == nil {
+		t.Error("addFeatures() should fail for an unknown feature")
 	}
 }
 
-func TestAddCommand_DetectTemplate_CargoToml(t *testing.T) {
+func TestAddCommand_AddLicense(t *testing.T) {
 	ac := NewAddCommand()
+	ac.template = "base"
 
-	tmpDir, err := os.MkdirTemp("", "maajise-detect-test-*")
+	tmpDir, err := os.MkdirTemp("", "maajise-add-test-*")
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer os.RemoveAll(tmpDir)
 
-	// Create Cargo.toml
-	os.WriteFile(filepath.Join(tmpDir, "Cargo.toml"), []byte("[package]"), 0644)
+	ac.vars = templates.DefaultVars("widget")
+	ac.vars.Author = "Jane Doe"
+	if err := ac.addLicense(tmpDir, "widget", []string{"apache-2.0"}); err != nil {
+		t.Fatalf("addLicense() error = %v", err)
+	}
+
+	license, _ := os.ReadFile(filepath.Join(tmpDir, "LICENSE"))
+	if !strings.Contains(string(license), "Apache License") {
+		t.Errorf("LICENSE = %q, want the Apache-2.0 text", license)
+	}
+	m, _ := manifest.Load(tmpDir)
+	if m == nil || m.Vars.License != "Apache-2.0" {
+		t.Errorf("manifest = %+v, want license Apache-2.0 recorded", m)
+	}
 
-	detected := ac.detectTemplate(tmpDir)
-	if detected != "rust" {
-		t.Errorf("detectTemplate() = %q, want %q", detected, "rust")
+	// Switching licenses needs --force to replace the existing LICENSE
+	ac.manifest = m
+	if err := ac.addLicense(tmpDir, "widget", []string{"MIT"}); err != nil {
+		t.Fatalf("addLicense() error = %v", err)
+	}
+	if after, _ := os.ReadFile(filepath.Join(tmpDir, "LICENSE")); string(after) != string(license) {
+		t.Error("addLicense() replaced LICENSE without --force")
+	}
+	if m, _ := manifest.Load(tmpDir); m.Vars.License != "Apache-2.0" {
+		t.Errorf("License = %q, want the manifest unchanged when LICENSE is skipped", m.Vars.License)
+	}
+
+	if err := ac.addLicense(tmpDir, "widget", []string{"WTFPL"}); err == nil {
+		t.Error("addLicense() should fail for an unknown license")
 	}
 }
 
-func TestAddCommand_DetectTemplate_Default(t *testing.T) {
+func TestAddCommand_AddProject(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-add-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// add project only runs at a workspace root
 	ac := NewAddCommand()
+	if err := ac.addProject(tmpDir, []string{"api"}); err == nil {
+		t.Error("addProject() should fail outside a workspace")
+	}
+
+	ac.vars = templates.DefaultVars("platform")
+	ac.template = templates.WorkspaceName
+	for _, filename := range []string{".gitignore", "README.md"} {
+		if err := ac.addFile(tmpDir, "platform", filename); err != nil {
+			t.Fatalf("addFile(%s) error = %v", filename, err)
+		}
+	}
+
+	ac.template = ""
+	ac.skipHooks = true
+	if err := ac.addProject(tmpDir, []string{"api", "--template=go"}); err != nil {
+		t.Fatalf("addProject() error = %v", err)
+	}
+
+	projectDir := filepath.Join(tmpDir, "apps", "api")
+	if !ac.fileExists(filepath.Join(projectDir, "go.mod")) {
+		t.Error("addProject() did not create apps/api/go.mod")
+	}
+	for _, filename := range templates.WorkspaceOwned {
+		if ac.fileExists(filepath.Join(projectDir, filename)) {
+			t.Errorf("addProject() created apps/api/%s, which belongs to the workspace root", filename)
+		}
+	}
+
+	sub, _ := manifest.Load(projectDir)
+	if sub == nil || sub.Template != "go" || sub.Workspace != "../.." || sub.Vars.ProjectName != "api" {
+		t.Errorf("apps/api manifest = %+v, want template go in workspace ../..", sub)
+	}
+	root, _ := manifest.Load(tmpDir)
+	if root == nil || len(root.Projects) != 1 || root.Projects[0].Name != "api" || root.Projects[0].Template != "go" || root.Projects[0].Base != "go" {
+		t.Errorf("workspace manifest = %+v, want project api recorded", root)
+	}
+
+	gitignore, _ := os.ReadFile(filepath.Join(tmpDir, ".gitignore"))
+	if !strings.Contains(string(gitignore), managed.Begin("project-api")) || !strings.Contains(string(gitignore), "apps/api/") {
+		t.Errorf(".gitignore = %q, want a scoped project-api block", gitignore)
+	}
+	gowork, _ := os.ReadFile(filepath.Join(tmpDir, "go.work"))
+	if !strings.Contains(string(gowork), "./apps/api") {
+		t.Errorf("go.work = %q, want apps/api in use", gowork)
+	}
 
-	tmpDir, err := os.MkdirTemp("", "maajise-detect-test-*")
+	if err := ac.addProject(tmpDir, []string{"api"}); err == nil {
+		t.Error("addProject() should fail for an existing project")
+	}
+	if err := ac.addProject(tmpDir, []string{"bad/name"}); err == nil {
+		t.Error("addProject() should fail for an invalid name")
+	}
+}
+
+func TestAddCommand_AddProjectRollback(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks in this test use sh")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "maajise-add-test-*")
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer os.RemoveAll(tmpDir)
 
-	// No markers, should default to base
-	detected := ac.detectTemplate(tmpDir)
-	if detected != "base" {
-		t.Errorf("detectTemplate() = %q, want %q", detected, "base")
+	ac := NewAddCommand()
+	ac.vars = templates.DefaultVars("platform")
+	ac.template = templates.WorkspaceName
+	for _, filename := range []string{".gitignore", "README.md"} {
+		if err := ac.addFile(tmpDir, "platform", filename); err != nil {
+			t.Fatalf("addFile(%s) error = %v", filename, err)
+		}
+	}
+	before := make(map[string]string)
+	for _, filename := range []string{".gitignore", manifest.FileName} {
+		data, _ := os.ReadFile(filepath.Join(tmpDir, filename))
+		before[filename] = string(data)
+	}
+
+	// The hook leaves a file at the workspace root, then fails
+	tmplDir := filepath.Join(tmpDir, "broken-tmpl")
+	os.MkdirAll(tmplDir, 0755)
+	os.WriteFile(filepath.Join(tmplDir, "template.yaml"), []byte(`name: broken
+extends: go
+hooks:
+  - run: "touch ../../go.work.sum && exit 1"
+`), 0644)
+
+	ac.template = tmplDir
+	if err := ac.addProject(tmpDir, []string{"api"}); err == nil {
+		t.Fatal("addProject() should fail when a required hook fails")
+	}
+
+	for _, path := range []string{filepath.Join("apps", "api"), "go.work", "go.work.sum", ".ubsignore"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, path)); err == nil {
+			t.Errorf("rollback left %s behind", path)
+		}
+	}
+	for filename, want := range before {
+		if data, _ := os.ReadFile(filepath.Join(tmpDir, filename)); string(data) != want {
+			t.Errorf("%s = %q after rollback, want %q", filename, data, want)
+		}
 	}
 }
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"maajise/internal/ignore"
+	"maajise/internal/manifest"
+	"maajise/internal/ui"
+	"maajise/templates"
+)
+
+// Template forms written by 'template capture'
+const (
+	formatDir  = "dir"
+	formatYAML = "yaml"
+)
+
+// captureSkip are never captured: version control, issue tracking and
+// maajise's own bookkeeping
+var captureSkip = map[string]bool{
+	".git":            true,
+	".beads":          true,
+	".maajise":        true,
+	manifest.FileName: true,
+}
+
+// minNameLength is the shortest project name capture replaces on its own;
+// shorter names match too much unrelated text
+const minNameLength = 3
+
+// commonNames are project names capture only replaces when given with
+// --project-name, since they also turn up as ordinary words, directories and
+// identifiers
+var commonNames = map[string]bool{
+	"api": true, "app": true, "backend": true, "bin": true, "build": true,
+	"cli": true, "client": true, "cmd": true, "common": true, "config": true,
+	"core": true, "data": true, "demo": true, "dist": true, "docs": true,
+	"example": true, "frontend": true, "internal": true, "lib": true,
+	"main": true, "pkg": true, "project": true, "server": true,
+	"service": true, "shared": true, "site": true, "src": true,
+	"template": true, "test": true, "tests": true, "tool": true,
+	"tools": true, "utils": true, "web": true, "www": true,
+}
+
+// replaceableName reports whether a project name taken from the manifest or
+// directory is distinctive enough to replace with {{.ProjectName}}
+func replaceableName(name string) bool {
+	return utf8.RuneCountInString(name) >= minNameLength && !commonNames[strings.ToLower(name)]
+}
+
+// namePattern matches name as a whole word, so "billing" is replaced in
+// "billing-api" but not in "billingAddress" or "rebilling"
+func namePattern(name string) *regexp.Regexp {
+	pattern := regexp.QuoteMeta(name)
+	if first, _ := utf8.DecodeRuneInString(name); isWordRune(first) {
+		pattern = `\b` + pattern
+	}
+	if last, _ := utf8.DecodeLastRuneInString(name); isWordRune(last) {
+		pattern += `\b`
+	}
+	return regexp.MustCompile(pattern)
+}
+
+// isWordRune reports whether \b treats r as a word character
+func isWordRune(r rune) bool {
+	return r == '_' || (r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+}
+
+// capturedFile is a project file turned into a template file
+type capturedFile struct {
+	path     string // template path, with the project name replaced
+	content  []byte
+	mode     os.FileMode
+	binary   bool
+	rendered bool // content mentions the project name, so it is a template
+}
+
+// captureDir walks dir and returns its files as template files, sorted by
+// path. An empty projectName replaces nothing.
+func captureDir(dir, projectName string) ([]capturedFile, error) {
+	var name *regexp.Regexp
+	if projectName != "" {
+		name = namePattern(projectName)
+	}
+
+	matcher := &ignore.Matcher{}
+	if err := matcher.AddFile("", filepath.Join(dir, ".gitignore")); err != nil {
+		return nil, err
+	}
+
+	var files []capturedFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if captureSkip[rel] || matcher.Ignored(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return matcher.AddFile(rel, filepath.Join(path, ".gitignore"))
+		}
+		if !d.Type().IsRegular() {
+			ui.Warn(fmt.Sprintf("Skipped %s (not a regular file)", rel))
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, captureFile(rel, content, info.Mode().Perm(), name))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture %s: %w", dir, err)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+	return files, nil
+}
+
+// captureFile replaces the project name, matched by name, with
+// {{.ProjectName}} in a file's path and, unless it is binary, its content
+func captureFile(rel string, content []byte, mode os.FileMode, name *regexp.Regexp) capturedFile {
+	f := capturedFile{
+		path:    escapeTemplate(rel),
+		content: content,
+		mode:    mode,
+		binary:  bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content),
+	}
+	if name == nil {
+		return f
+	}
+
+	f.path = name.ReplaceAllLiteralString(f.path, "{{.ProjectName}}")
+	if !f.binary && name.Match(content) {
+		f.content = []byte(name.ReplaceAllLiteralString(escapeTemplate(string(content)), "{{.ProjectName}}"))
+		f.rendered = true
+	}
+	return f
+}
+
+// escapeTemplate makes text/template render s unchanged
+func escapeTemplate(s string) string {
+	return strings.ReplaceAll(s, "{{", `{{"{{"}}`)
+}
+
+// writeDirTemplate writes files as a directory template: rendered files get
+// the .tmpl suffix, the others are copied verbatim with their mode
+func writeDirTemplate(target, name, description string, files []capturedFile) error {
+	for _, f := range files {
+		path := f.path
+		content := f.content
+		if f.rendered || strings.HasSuffix(path, templates.TemplateSuffix) {
+			if f.binary {
+				return fmt.Errorf("can't capture binary file %s: its name ends in %s", f.path, templates.TemplateSuffix)
+			}
+			if !f.rendered {
+				// A verbatim file named like a template must be rendered to itself
+				content = []byte(escapeTemplate(string(content)))
+			}
+			path += templates.TemplateSuffix
+		}
+
+		if err := writeGenerated(filepath.Join(target, templates.FilesDir, filepath.FromSlash(path)), string(content), f.mode); err != nil {
+			return fmt.Errorf("failed to write template file %s: %w", f.path, err)
+		}
+	}
+
+	return writeTemplateManifest(filepath.Join(target, templates.ManifestFile), templates.CustomTemplateFile{
+		Name:        name,
+		Description: description,
+	})
+}
+
+// writeYAMLTemplate writes files as a single YAML template. Files that don't
+// mention the project name are marked raw: so they are written as is; modes
+// other than 0644 are kept with mode:.
+func writeYAMLTemplate(target, name, description string, files []capturedFile) error {
+	ctf := templates.CustomTemplateFile{
+		Name:        name,
+		Description: description,
+		Files:       make(map[string]templates.FileEntry, len(files)),
+	}
+
+	for _, f := range files {
+		if f.binary {
+			return ui.UsageError("template", fmt.Sprintf("%s is a binary file, which the YAML form can't hold (use --format=dir)", f.path))
+		}
+		entry := templates.FileEntry{Content: string(f.content), Raw: !f.rendered}
+		if f.mode != 0644 {
+			entry.Mode = fmt.Sprintf("%04o", f.mode)
+		}
+		ctf.Files[f.path] = entry
+	}
+
+	return writeTemplateManifest(target, ctf)
+}
+
+// writeTemplateManifest writes a template.yaml or YAML template
+func writeTemplateManifest(path string, ctf templates.CustomTemplateFile) error {
+	data, err := yaml.Marshal(&ctf)
+	if err != nil {
+		return fmt.Errorf("failed to encode template: %w", err)
+	}
+	if err := writeGenerated(path, string(data), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package cmd
 
-import "flag"
+import (
+	"flag"
+	"strings"
+)
 
 // Command represents a CLI command
 type Command interface {
@@ -35,7 +38,40 @@ func All() []Command {
 	return cmds
 }
 
-// ParseFlags is a helper for commands that use flags
+// ParseFlags parses args with fs. Unlike fs.Parse it doesn't stop at the
+// first positional argument, so "init my-api --template=go" works like
+// "init --template=go my-api"; everything after "--" stays positional.
 func ParseFlags(fs *flag.FlagSet, args []string) error {
-	return fs.Parse(args)
+	var flags, positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			positional = append(positional, arg)
+			continue
+		}
+		flags = append(flags, arg)
+		// A non-boolean flag without = takes the next argument as its value
+		name := strings.TrimLeft(arg, "-")
+		if strings.Contains(name, "=") || i+1 >= len(args) {
+			continue
+		}
+		if f := fs.Lookup(name); f != nil && !isBoolFlag(f) {
+			i++
+			flags = append(flags, args[i])
+		}
+	}
+	if len(positional) > 0 {
+		flags = append(append(flags, "--"), positional...)
+	}
+	return fs.Parse(flags)
+}
+
+// isBoolFlag reports whether f is set by its name alone, like --verbose
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
 }
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"unicode"
 
 	"maajise/internal/config"
 	"maajise/internal/ui"
+	"maajise/templates"
 )
 
 type DoctorCommand struct {
@@ -46,8 +48,9 @@ func (dc *DoctorCommand) LongDescription() string {
 	return `Check system dependencies and configuration for Maajise.
 
 Verifies that required tools (Git, Beads via beads_rust) are installed and accessible, and optionally
-checks for optional tools (UBS, Go, Swift). Helps diagnose setup issues and verify the system
-is properly configured for using Maajise.`
+checks for the optional tools the templates use (UBS, Go, Swift, Java, Gradle, Maven, CMake,
+a C compiler, .NET, Ruby, Bundler, Elixir, Mix, Zig). Helps diagnose setup issues and verify
+the system is properly configured for using Maajise.`
 }
 
 func (dc *DoctorCommand) Usage() string {
@@ -63,7 +66,7 @@ func (dc *DoctorCommand) Examples() string {
 }
 
 func (dc *DoctorCommand) Run(args []string) error {
-	if err := dc.fs.Parse(args); err != nil {
+	if err := ParseFlags(dc.fs, args); err != nil {
 		return err
 	}
 
@@ -135,6 +138,13 @@ func (dc *DoctorCommand) Run(args []string) error {
 	if fc, err := config.LoadFileConfig(); err == nil && fc.TemplatesDir != "" {
 		ui.Info(fmt.Sprintf("  Custom templates: %s", fc.TemplatesDir))
 	}
+	if loadErrors := templates.LoadErrors(); len(loadErrors) > 0 {
+		ui.Warn(fmt.Sprintf("○ Custom templates: %d failed to load", len(loadErrors)))
+		for _, e := range loadErrors {
+			fmt.Printf("    %v\n", e)
+		}
+		fmt.Println("    Run 'maajise template lint' for details")
+	}
 
 	fmt.Println()
 
@@ -158,6 +168,17 @@ func (dc *DoctorCommand) defaultDependencyChecks() []DependencyCheck {
 		{Name: "ubs", Command: "ubs", Args: []string{"--version"}, Required: false},
 		{Name: "go", Command: "go", Args: []string{"version"}, Required: false},
 		{Name: "swift", Command: "swift", Args: []string{"--version"}, Required: false},
+		{Name: "java", Command: "java", Args: []string{"--version"}, Required: false},
+		{Name: "gradle", Command: "gradle", Args: []string{"--version"}, Required: false},
+		{Name: "mvn", Command: "mvn", Args: []string{"--version"}, Required: false},
+		{Name: "cmake", Command: "cmake", Args: []string{"--version"}, Required: false},
+		{Name: "cc", Command: "cc", Args: []string{"--version"}, Required: false},
+		{Name: "dotnet", Command: "dotnet", Args: []string{"--version"}, Required: false},
+		{Name: "ruby", Command: "ruby", Args: []string{"--version"}, Required: false},
+		{Name: "bundle", Command: "bundle", Args: []string{"--version"}, Required: false},
+		{Name: "elixir", Command: "elixir", Args: []string{"--version"}, Required: false},
+		{Name: "mix", Command: "mix", Args: []string{"--version"}, Required: false},
+		{Name: "zig", Command: "zig", Args: []string{"version"}, Required: false},
 	}
 }
 
@@ -172,16 +193,23 @@ func (dc *DoctorCommand) runCheck(check DependencyCheck) DependencyCheck {
 	}
 
 	check.Found = true
-	check.Version = strings.TrimSpace(string(output))
-
-	// Clean up version strings
-	if strings.Contains(check.Version, "\n") {
-		check.Version = strings.Split(check.Version, "\n")[0]
-	}
+	check.Version = versionLine(string(output))
 
 	return check
 }
 
+// versionLine returns the first line of version output that says something,
+// skipping blank lines and banners such as gradle's line of dashes
+func versionLine(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.IndexFunc(line, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			return line
+		}
+	}
+	return ""
+}
+
 func init() {
 	Register(NewDoctorCommand())
 }
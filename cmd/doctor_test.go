@@ -113,3 +113,95 @@ func TestDoctorCommand_Execute(t *testing.T) {
 	}
 	t.Errorf("Execute() returned unexpected error: %v", err)
 }
+
+func TestDoctorCommand_DefaultDependencyChecks_IncludesJVMTools(t *testing.T) {
+	dc := NewDoctorCommand()
+	found := map[string]bool{}
+	for _, check := range dc.defaultDependencyChecks() {
+		found[check.Name] = true
+		if (check.Name == "java" || check.Name == "gradle" || check.Name == "mvn") && check.Required {
+			t.Errorf("%s check should be optional", check.Name)
+		}
+	}
+
+	for _, name := range []string{"java", "gradle", "mvn"} {
+		if !found[name] {
+			t.Errorf("default dependency checks should include %s", name)
+		}
+	}
+}
+
+func TestDoctorCommand_DefaultDependencyChecks_IncludesNativeTools(t *testing.T) {
+	dc := NewDoctorCommand()
+	commands := map[string]DependencyCheck{}
+	for _, check := range dc.defaultDependencyChecks() {
+		commands[check.Command] = check
+	}
+
+	for _, command := range []string{"cmake", "cc"} {
+		check, ok := commands[command]
+		if !ok {
+			t.Errorf("default dependency checks should run %s", command)
+			continue
+		}
+		if check.Required {
+			t.Errorf("%s check should be optional", command)
+		}
+	}
+}
+
+func TestDoctorCommand_DefaultDependencyChecks_IncludesDotnetOptional(t *testing.T) {
+	dc := NewDoctorCommand()
+	for _, check := range dc.defaultDependencyChecks() {
+		if check.Name == "dotnet" {
+			if check.Required {
+				t.Error("dotnet check should be optional")
+			}
+			if check.Command != "dotnet" {
+				t.Errorf("dotnet check command = %q, want %q", check.Command, "dotnet")
+			}
+			return
+		}
+	}
+
+	t.Error("default dependency checks should include dotnet")
+}
+
+func TestDoctorCommand_DefaultDependencyChecks_IncludesRubyElixirZig(t *testing.T) {
+	dc := NewDoctorCommand()
+	checks := map[string]DependencyCheck{}
+	for _, check := range dc.defaultDependencyChecks() {
+		checks[check.Name] = check
+	}
+
+	for _, name := range []string{"ruby", "bundle", "elixir", "mix", "zig"} {
+		check, ok := checks[name]
+		if !ok {
+			t.Errorf("default dependency checks should include %s", name)
+			continue
+		}
+		if check.Required {
+			t.Errorf("%s check should be optional", name)
+		}
+	}
+	if args := checks["zig"].Args; len(args) != 1 || args[0] != "version" {
+		t.Errorf("zig check args = %v, want [version]", args)
+	}
+}
+
+func TestVersionLine(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{"go version go1.23.0 linux/amd64\n", "go version go1.23.0 linux/amd64"},
+		{"\n------------------------------------------------------------\nGradle 8.10\n------------------------------------------------------------\n", "Gradle 8.10"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := versionLine(tt.output); got != tt.want {
+			t.Errorf("versionLine(%q) = %q, want %q", tt.output, got, tt.want)
+		}
+	}
+}
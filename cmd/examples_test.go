@@ -16,7 +16,7 @@ func TestInitExamplesIncludeAllFlags(t *testing.T) {
 		"--skip-git", "--skip-beads", "--skip-commit",
 		"--skip-remote", "--skip-git-user",
 		"--git-name", "--git-email",
-		"--dry-run", "--interactive", "--verbose",
+		"--dry-run", "--interactive", "--verbose", "--workspace",
 	}
 
 	for _, flag := range requiredFlags {
@@ -26,7 +26,7 @@ func TestInitExamplesIncludeAllFlags(t *testing.T) {
 	}
 
 	// Check that all template options are listed
-	templates := []string{"base", "typescript", "python", "rust", "php", "go", "swift"}
+	templates := []string{"base", "typescript", "python", "rust", "php", "go", "swift", "java", "kotlin", "c", "cpp", "dotnet", "ruby", "elixir", "zig"}
 	for _, tmpl := range templates {
 		if !strings.Contains(examples, tmpl) {
 			t.Errorf("InitCommand examples missing template: %s", tmpl)
@@ -39,7 +39,7 @@ func TestAddExamplesIncludeAllFlags(t *testing.T) {
 	cmd := NewAddCommand()
 	examples := cmd.Examples()
 
-	requiredFlags := []string{"--force", "--template", "--dry-run", "--verbose"}
+	requiredFlags := []string{"--force", "--template", "--dry-run", "--diff-only", "--verbose"}
 
 	for _, flag := range requiredFlags {
 		if !strings.Contains(examples, flag) {
@@ -53,7 +53,7 @@ func TestUpdateExamplesIncludeAllFlags(t *testing.T) {
 	cmd := NewUpdateCommand()
 	examples := cmd.Examples()
 
-	requiredFlags := []string{"--force", "--template", "--dry-run", "--verbose"}
+	requiredFlags := []string{"--force", "--template", "--dry-run", "--diff-only", "--skip-conflicts", "--verbose"}
 
 	for _, flag := range requiredFlags {
 		if !strings.Contains(examples, flag) {
@@ -46,7 +46,7 @@ func (hc *HelpCommand) Examples() string {
 }
 
 func (hc *HelpCommand) Run(args []string) error {
-	if err := hc.fs.Parse(args); err != nil {
+	if err := ParseFlags(hc.fs, args); err != nil {
 		return err
 	}
 
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"maajise/internal/fsutil"
+	"maajise/internal/ui"
+	"maajise/templates"
+)
+
+// hookOutputLines is how much of a failed hook's output is shown without --verbose
+const hookOutputLines = 20
+
+// activeHooks returns the hooks of tmpl whose condition holds, rendered with vars
+func activeHooks(tmpl templates.Template, vars templates.TemplateVars) ([]templates.Hook, error) {
+	var hooks []templates.Hook
+	for _, h := range templates.HooksOf(tmpl) {
+		if h.When != "" {
+			ok, err := templates.EvalCondition(h.When, vars)
+			if err != nil {
+				return nil, fmt.Errorf("hook %s: %w", h.Label(), err)
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		rendered, err := h.Render(vars)
+		if err != nil {
+			return nil, err
+		}
+		hooks = append(hooks, rendered)
+	}
+	return hooks, nil
+}
+
+// runHooks runs hooks in order in repoDir and returns the files they created
+// for the initial commit. A failing optional hook is reported and skipped, so
+// a missing toolchain or network doesn't stop init; a failing required one
+// stops the run. Canceling ctx kills the running hook
+// and stops the run with ErrInterrupted.
+func runHooks(ctx context.Context, repoDir string, hooks []templates.Hook, verbose bool) ([]string, error) {
+	var created []string
+	for _, h := range hooks {
+		if ctx.Err() != nil {
+			return nil, ErrInterrupted
+		}
+		ui.Info(fmt.Sprintf("Running hook: %s", h.Label()))
+		if err := runHook(ctx, repoDir, h, verbose); err != nil {
+			if ctx.Err() != nil {
+				return nil, ErrInterrupted
+			}
+			if h.Optional {
+				ui.Warn(fmt.Sprintf("Optional hook %s failed: %v", h.Label(), err))
+				continue
+			}
+			return nil, fmt.Errorf("hook %s failed: %w", h.Label(), err)
+		}
+		ui.Success(fmt.Sprintf("Hook %s done", h.Label()))
+
+		for _, path := range h.Commit {
+			if fsutil.PathExists(filepath.Join(repoDir, filepath.FromSlash(path))) {
+				created = append(created, path)
+			}
+		}
+	}
+	return created, nil
+}
+
+// runHook runs one hook through the shell. Output streams in verbose mode;
+// otherwise its tail is shown when the hook fails.
+func runHook(ctx context.Context, repoDir string, h templates.Hook, verbose bool) error {
+	dir := repoDir
+	if h.Dir != "" {
+		joined, err := fsutil.SafeJoin(repoDir, h.Dir)
+		if err != nil {
+			return err
+		}
+		dir = joined
+	}
+	if !fsutil.DirExists(dir) {
+		return fmt.Errorf("directory %s does not exist", h.Dir)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.Duration())
+	defer cancel()
+
+	cmd := shellCommand(ctx, h.Run)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), h.Environ()...)
+	killProcessGroup(cmd)
+	// Don't wait forever for children that keep the output open after a timeout
+	cmd.WaitDelay = 5 * time.Second
+
+	var output bytes.Buffer
+	if verbose {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	} else {
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+	}
+
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", h.Duration())
+	}
+	if err != nil && !verbose {
+		printTail(output.String(), hookOutputLines)
+	}
+	return err
+}
+
+// shellCommand runs command with the platform's shell
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// printTail prints the last n lines of output, indented
+func printTail(output string, n int) {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return
+	}
+	if len(lines) > n {
+		fmt.Printf("    ... (%d lines omitted, use --verbose for all output)\n", len(lines)-n)
+		lines = lines[len(lines)-n:]
+	}
+	for _, line := range lines {
+		fmt.Printf("    %s\n", line)
+	}
+}
+
+// describeHook returns the dry-run line for a hook
+func describeHook(h templates.Hook) string {
+	var details []string
+	if h.Name != "" {
+		details = append(details, h.Name)
+	}
+	if h.Dir != "" {
+		details = append(details, "in "+h.Dir)
+	}
+	if h.Timeout != "" {
+		details = append(details, "timeout "+h.Duration().String())
+	}
+	if h.Optional {
+		details = append(details, "optional")
+	}
+	if len(details) == 0 {
+		return h.Run
+	}
+	return fmt.Sprintf("%s (%s)", h.Run, strings.Join(details, ", "))
+}
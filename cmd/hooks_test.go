@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"maajise/templates"
+)
+
+func TestRunHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks in this test use sh")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "maajise-hooks-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	os.MkdirAll(filepath.Join(tmpDir, "web"), 0755)
+
+	hooks := []templates.Hook{
+		{Run: "echo one >> order.txt"},
+		{Run: "exit 3", Optional: true},
+		{Run: `echo "$GREETING" > greeting.txt`, Dir: "web", Env: map[string]string{"GREETING": "hello"}, Commit: []string{"web/greeting.txt", "web/missing.txt"}},
+		{Run: "echo two >> order.txt"},
+	}
+
+	created, err := runHooks(context.Background(), tmpDir, hooks, false)
+	if err != nil {
+		t.Fatalf("runHooks() error = %v", err)
+	}
+
+	order, _ := os.ReadFile(filepath.Join(tmpDir, "order.txt"))
+	if string(order) != "one\ntwo\n" {
+		t.Errorf("order.txt = %q, want hooks run in order", order)
+	}
+	greeting, _ := os.ReadFile(filepath.Join(tmpDir, "web", "greeting.txt"))
+	if string(greeting) != "hello\n" {
+		t.Errorf("greeting.txt = %q, want dir and env applied", greeting)
+	}
+	if len(created) != 1 || created[0] != "web/greeting.txt" {
+		t.Errorf("runHooks() created = %v, want only existing commit files", created)
+	}
+}
+
+func TestRunHooks_Failures(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks in this test use sh")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "maajise-hooks-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	_, err = runHooks(context.Background(), tmpDir, []templates.Hook{{Run: "exit 1"}, {Run: "touch after"}}, false)
+	if err == nil {
+		t.Fatal("runHooks() should fail for a failing required hook")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "after")); err == nil {
+		t.Error("hooks after a failed required hook should not run")
+	}
+
+	_, err = runHooks(context.Background(), tmpDir, []templates.Hook{{Run: "sleep 5", Timeout: "100ms"}}, false)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("runHooks() error = %v, want a timeout", err)
+	}
+
+	_, err = runHooks(context.Background(), tmpDir, []templates.Hook{{Run: "true", Dir: "nowhere"}}, false)
+	if err == nil {
+		t.Error("runHooks() should fail for a missing directory")
+	}
+	// An interrupt kills the running hook, even an optional one, and stops the run
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	start := time.Now()
+	_, err = runHooks(ctx, tmpDir, []templates.Hook{{Run: "sleep 5", Optional: true}, {Run: "touch interrupted"}}, false)
+	if !errors.Is(err, ErrInterrupted) {
+		t.Errorf("runHooks() error = %v, want ErrInterrupted", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("runHooks() took %s, want the hook killed", elapsed)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "interrupted")); err == nil {
+		t.Error("hooks after an interrupt should not run")
+	}
+}
+
+func TestInitCommand_Hooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks in this test use sh")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "maajise-init-hooks-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	os.MkdirAll("hooked", 0755)
+	os.WriteFile(filepath.Join("hooked", "template.yaml"), []byte(`name: hooked
+files:
+  README.md: "# {{.ProjectName}}\n"
+hooks:
+  - name: stamp
+    run: "echo {{shquote .ProjectName}} {{shquote .team}} > stamp.txt"
+`), 0644)
+
+	ic := NewInitCommand()
+	if err := ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--template=./hooked", "--var", "team=O'Brien; touch pwned", "svc"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	stamp, _ := os.ReadFile(filepath.Join("svc", "svc", "stamp.txt"))
+	if string(stamp) != "svc O'Brien; touch pwned\n" {
+		t.Errorf("stamp.txt = %q, want the hook to run with quoted variables", stamp)
+	}
+	if _, err := os.Stat(filepath.Join("svc", "svc", "pwned")); err == nil {
+		t.Error("a variable value ran as a shell command")
+	}
+
+	ic = NewInitCommand()
+	if err := ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--skip-hooks", "--template=./hooked", "--var", "team=x", "quiet"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join("quiet", "quiet", "stamp.txt")); err == nil {
+		t.Error("--skip-hooks still ran the hook")
+	}
+
+	// A failing required hook aborts init and rolls the project back
+	os.MkdirAll("failing", 0755)
+	os.WriteFile(filepath.Join("failing", "template.yaml"), []byte(`name: failing
+files:
+  README.md: "# {{.ProjectName}}\n"
+hooks:
+  - run: exit 1
+`), 0644)
+
+	ic = NewInitCommand()
+	if err := ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--template=./failing", "broken"}); err == nil {
+		t.Fatal("Run() should fail when a required hook fails")
+	}
+	if _, err := os.Stat("broken"); err == nil {
+		t.Error("failed init should remove the project directory")
+	}
+
+	// In place, rollback removes what the hooks created but keeps existing files
+	os.MkdirAll("inplace", 0755)
+	os.WriteFile(filepath.Join("inplace", "notes.txt"), []byte("mine\n"), 0644)
+	os.MkdirAll("messy", 0755)
+	os.WriteFile(filepath.Join("messy", "template.yaml"), []byte(`name: messy
+files:
+  README.md: "# {{.ProjectName}}\n"
+hooks:
+  - run: "mkdir -p node_modules/dep && touch node_modules/dep/index.js lock.json notes.txt"
+  - run: exit 1
+`), 0644)
+
+	os.Chdir("inplace")
+	ic = NewInitCommand()
+	err = ic.Run([]string{"--yes", "--in-place", "--skip-git", "--skip-beads", "--template=../messy"})
+	os.Chdir("..")
+	if err == nil {
+		t.Fatal("Run() should fail when a required hook fails")
+	}
+	for _, name := range []string{"node_modules", "lock.json", "README.md"} {
+		if _, err := os.Stat(filepath.Join("inplace", name)); err == nil {
+			t.Errorf("rollback left %s behind", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join("inplace", "notes.txt")); err != nil {
+		t.Error("rollback should keep files that existed before init")
+	}
+}
+
+func TestInitCommand_Interrupted(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks in this test use sh and signals")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "maajise-init-interrupt-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	// The hook interrupts init, as Ctrl-C would, and waits to be killed
+	os.MkdirAll("slow", 0755)
+	os.WriteFile(filepath.Join("slow", "template.yaml"), []byte(`name: slow
+files:
+  README.md: "# {{.ProjectName}}\n"
+hooks:
+  - run: "touch partial.txt; kill -INT $PPID; exec sleep 10"
+`), 0644)
+
+	ic := NewInitCommand()
+	err = ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--template=./slow", "svc"})
+	if !errors.Is(err, ErrInterrupted) {
+		t.Fatalf("Run() error = %v, want ErrInterrupted", err)
+	}
+	if _, err := os.Stat("svc"); err == nil {
+		t.Error("interrupted init should remove the project directory")
+	}
+}
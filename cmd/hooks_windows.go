@@ -0,0 +1,9 @@
+//go:build windows
+
+package cmd
+
+import "os/exec"
+
+// killProcessGroup is a no-op on Windows, where canceling kills cmd.exe and
+// WaitDelay bounds the wait for its children
+func killProcessGroup(cmd *exec.Cmd) {}
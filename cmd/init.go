@@ -1,30 +1,51 @@
 package cmd
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
+	"syscall"
 
 	"maajise/internal/beads"
 	"maajise/internal/config"
 	"maajise/internal/fsutil"
 	"maajise/internal/git"
+	"maajise/internal/manifest"
+	"maajise/internal/source"
+	"maajise/internal/txn"
 	"maajise/internal/ui"
 	"maajise/internal/validate"
 	"maajise/templates"
 )
 
 type InitCommand struct {
-	fs         *flag.FlagSet
-	config     config.Config
-	template   string
-	fileConfig *config.FileConfig
-	dryRun     bool
-	interactive bool
+	fs           *flag.FlagSet
+	config       config.Config
+	template     string
+	with         string
+	workspace    bool
+	license      string
+	features     []string
+	fileConfig   *config.FileConfig
+	dryRun       bool
+	interactive  bool
+	yes          bool
+	answersPath  string
+	vars         varList
+	resolvedVars *templates.TemplateVars
+	answers      *config.Answers
+	prompt       *prompter
+	manifest     *manifest.Manifest
+	tx           *txn.Tx
+	ctx          context.Context // canceled when init is interrupted
+	hookFiles    []string        // files created by hooks, for the initial commit
 }
 
 func NewInitCommand() *InitCommand {
@@ -41,11 +62,16 @@ func NewInitCommand() *InitCommand {
 	}
 
 	// Define flags (will use merged defaults)
-	ic.fs.BoolVar(&ic.config.InPlace, "in-place", false, "Initialize in current directory")
+	ic.fs.BoolVar(&ic.config.InPlace, "in-place", false, "Initialize in current directory (rollback does not restore existing files hooks modify)")
 	ic.fs.BoolVar(&ic.config.NoOverwrite, "no-overwrite", false, "Don't overwrite existing files")
-	ic.fs.StringVar(&ic.template, "template", ic.config.Template, "Project template (base, typescript, python, rust, php, go, swift)")
+	ic.fs.StringVar(&ic.template, "template", ic.config.Template, "Project template (base, typescript, python, rust, php, go, swift, java, kotlin, c, cpp, dotnet, ruby, elixir, zig), or a directory, tarball or git URL")
+	ic.fs.BoolVar(&ic.workspace, "workspace", false, "Create a monorepo workspace; add projects under apps/ with 'maajise add project'")
+	ic.fs.StringVar(&ic.with, "with", "", "Comma-separated features to add (e.g. docker,ci,editorconfig)")
+	ic.fs.StringVar(&ic.license, "license", "", "License as an SPDX id (e.g. MIT, Apache-2.0), or none (default: license from ~/.maajiserc, else MIT)")
 	ic.fs.BoolVar(&ic.config.SkipGit, "skip-git", false, "Skip Git initialization")
+	ic.fs.StringVar(&ic.config.MainBranch, "branch", ic.config.MainBranch, "Initial Git branch name")
 	ic.fs.BoolVar(&ic.config.SkipBeads, "skip-beads", false, "Skip Beads initialization")
+	ic.fs.BoolVar(&ic.config.SkipHooks, "skip-hooks", false, "Skip the template's post-generate hooks")
 	ic.fs.BoolVar(&ic.config.SkipCommit, "skip-commit", false, "Skip initial commit")
 	ic.fs.BoolVar(&ic.config.SkipRemote, "skip-remote", false, "Skip remote setup")
 	ic.fs.BoolVar(&ic.config.SkipGitUser, "skip-git-user", false, "Skip Git user configuration")
@@ -54,6 +80,11 @@ func NewInitCommand() *InitCommand {
 	ic.fs.BoolVar(&ic.dryRun, "dry-run", false, "Preview what would be created without making changes")
 	ic.fs.BoolVar(&ic.interactive, "interactive", false, "Interactive mode with prompts")
 	ic.fs.BoolVar(&ic.interactive, "i", false, "Interactive mode with prompts")
+	ic.fs.BoolVar(&ic.yes, "yes", false, "Never prompt; fail if a required value is missing")
+	ic.fs.BoolVar(&ic.yes, "y", false, "Never prompt; fail if a required value is missing")
+	ic.fs.BoolVar(&ic.yes, "non-interactive", false, "Never prompt; fail if a required value is missing")
+	ic.fs.Var(&ic.vars, "var", "Template variable as key=value (repeatable, overrides ~/.maajiserc variables)")
+	ic.fs.StringVar(&ic.answersPath, "answers", "", "YAML file answering every prompt (project_name, template, git, git_name, git_email, branch, remote, beads)")
 	ic.fs.BoolVar(&ic.config.Verbose, "v", false, "Verbose output")
 	ic.fs.BoolVar(&ic.config.Verbose, "verbose", false, "Verbose output")
 
@@ -75,8 +106,39 @@ Creates a project directory (or initializes the current directory with --in-plac
 Git for version control, initializes Beads for issue tracking, and creates standard configuration
 files based on the selected template.
 
-Available templates: base, typescript, python, rust, php, go, swift. Use 'maajise templates' to see
-detailed descriptions of each template.`
+Init is transactional: if any step fails or it is interrupted with Ctrl-C before the initial
+commit, everything it created is removed again (directories, files, .git and .beads), and
+existing files it overwrote in --in-place mode are restored. Existing files that hooks modify
+in --in-place mode are not restored.
+
+Init never reads stdin with --yes (or --non-interactive), or when stdin is not a terminal: missing
+required values are an error instead of a prompt. Use --answers to supply every prompt's value
+from a YAML file; command-line flags take precedence over answers. The Git identity falls back
+to ~/.maajiserc, then to the global git config.
+
+Custom templates can declare questions, asked after the built-in prompts. Without prompting they
+are answered with --var name=value, the vars section of --answers, or their default.
+
+After writing the files, init runs the template's hooks (e.g. npm install, go mod tidy) in order,
+before the initial commit. A failing hook aborts init unless it is optional; --skip-hooks skips
+them all and --dry-run lists them. Hook output is shown with --verbose, or when a hook fails.
+
+--with layers features on top of any template: extra files such as a Dockerfile or a CI
+workflow, tailored to the template's language, plus their .gitignore lines. Available features:
+ci, devcontainer, docker, editorconfig, make, task. Add more later with 'maajise add feature'.
+
+Init writes a LICENSE file for the project's license, an SPDX id taken from --license, the license
+variable in ~/.maajiserc, or MIT, with the year and author filled in. Language manifests such as
+package.json and Cargo.toml use the same id. --license=none skips the file.
+
+Available templates: base, typescript, python, rust, php, go, swift, java, kotlin, c, cpp, dotnet,
+ruby, elixir, zig. Use 'maajise templates' to see detailed descriptions of each template.
+--template also accepts a template source: a directory, a tarball or a git repository
+(git+<url>#<ref>) with a template.yaml at its root, fetched into ~/.maajise/cache.
+
+--workspace creates a monorepo root (the workspace template) instead of a single project: one Git
+repository, one Beads database and a root .gitignore and .ubsignore shared by the projects that
+'maajise add project <name> --template=<template>' creates under apps/.`
 }
 
 func (ic *InitCommand) Usage() string {
@@ -101,6 +163,10 @@ func (ic *InitCommand) Examples() string {
   maajise init my-ts-app --template=typescript
       Creates a TypeScript project with tsconfig.json, package.json
 
+  # Use a template from a shared git repository, pinned to a tag
+  maajise init my-svc --template=git+https://github.com/acme/service-template#v1.2.0
+      Fetched into ~/.maajise/cache; also accepts a directory or .tar.gz
+
   # Safe mode - don't overwrite existing files
   maajise init my-project --no-overwrite
       Skips any files that already exist
@@ -109,10 +175,54 @@ func (ic *InitCommand) Examples() string {
   maajise init --interactive
       Prompts for project name and configuration options
 
+  # Non-interactive (CI) - never prompt, fail on missing values
+  maajise init my-project --yes --git-name="CI Bot" --git-email=ci@example.com
+
+  # Answer every prompt from a file
+  maajise init --yes --answers=answers.yaml
+      answers.yaml: project_name, template, git, git_name, git_email, branch, remote, beads, vars
+
+  # Set template variables (override variables from ~/.maajiserc)
+  maajise init my-project --var author="Jane Doe" --var team=platform
+      Available as {{.Author}} and {{.team}} in templates and file paths
+
+  # Answer a template question without prompting
+  maajise init my-svc --template=service --yes --var service=billing
+      Available as {{.Answers.service}}; unanswered questions use their default
+
   # Dry run - preview changes without making them
   maajise init my-project --dry-run
       Shows what would be created without actually creating files
 
+  # Use a different initial branch
+  maajise init my-project --branch=trunk
+      Creates the repository on "trunk" (default: main_branch from ~/.maajiserc, else main)
+
+  # Add features on top of the template
+  maajise init my-api --template=go --with docker,ci,editorconfig
+      Adds a Go-specific Dockerfile, a GitHub Actions workflow and an .editorconfig
+
+  # Choose the license (writes LICENSE and sets it in package.json, Cargo.toml, ...)
+  maajise init my-lib --template=rust --license=Apache-2.0
+      Supported: MIT, Apache-2.0, BSD-2-Clause, BSD-3-Clause, GPL-3.0-only, GPL-3.0-or-later,
+      ISC, MPL-2.0, Unlicense, or none
+
+  # Monorepo with a Go API and a TypeScript web app in apps/
+  maajise init platform --workspace
+  cd platform/platform
+  maajise add project api --template=go
+  maajise add project web --template=typescript
+      Also writes go.work, package.json and pnpm-workspace.yaml listing the projects
+
+  # Java service built with Maven, sources under src/main/java/com/acme/billing/
+  maajise init billing --template=java --var build=maven --var group_id=com.acme.billing
+
+  # C++ tool with headers under include/fastpack/
+  maajise init fastpack --template=cpp
+
+  # C# service, creates BillingApi.sln and src/BillingApi/BillingApi.csproj
+  maajise init billing-api --template=dotnet
+
   # Skip Git initialization
   maajise init my-project --skip-git
       Creates project without Git repository
@@ -129,6 +239,10 @@ func (ic *InitCommand) Examples() string {
   maajise init my-project --skip-git-user
       Skips Git user.name and user.email prompts
 
+  # Skip the template's hooks (npm install, go mod tidy, ...)
+  maajise init my-api --template=go --skip-hooks
+      Writes the files without running any post-generate commands
+
   # Skip initial commit
   maajise init my-project --skip-commit
       Initializes Git but doesn't create initial commit
@@ -142,37 +256,94 @@ func (ic *InitCommand) Examples() string {
       Shows detailed output during initialization
 
   # Available templates
-  Available templates: base, typescript, python, rust, php, go, swift
+  Available templates: base, typescript, python, rust, php, go, swift, java, kotlin, c, cpp, dotnet,
+  ruby, elixir, zig
       base:       Basic project structure (.gitignore, README, .ubsignore)
       typescript: TypeScript project (tsconfig.json, package.json)
       python:     Python project (pyproject.toml, requirements.txt)
       rust:       Rust project (Cargo.toml)
       php:        PHP project (composer.json)
       go:         Go project (go.mod)
-      swift:      Swift project (Package.swift)`
+      swift:      Swift project (Package.swift)
+      java:       Java project (build.gradle.kts or pom.xml)
+      kotlin:     Kotlin/JVM project (build.gradle.kts or pom.xml)
+      c:          C project (CMakeLists.txt)
+      cpp:        C++ project (CMakeLists.txt, .clang-format)
+      dotnet:     C# project (.sln, src/ and tests/ .csproj)
+      ruby:       Ruby gem (Gemfile, gemspec, Rakefile)
+      elixir:     Elixir project (mix.exs)
+      zig:        Zig project (build.zig, build.zig.zon)`
 }
 
 func (ic *InitCommand) Run(args []string) error {
 	// Parse flags
-	if err := ic.fs.Parse(args); err != nil {
+	if err := ParseFlags(ic.fs, args); err != nil {
 		return err
 	}
 
 	remainingArgs := ic.fs.Args()
 
+	if ic.answersPath != "" {
+		answers, err := config.LoadAnswers(ic.answersPath)
+		if err != nil {
+			return err
+		}
+		ic.answers = answers
+		if err := ic.applyAnswers(); err != nil {
+			return err
+		}
+	}
+
+	if ic.workspace {
+		set := make(map[string]bool)
+		ic.fs.Visit(func(f *flag.Flag) {
+			set[f.Name] = true
+		})
+		if set["template"] && source.Canonical(ic.template) != templates.WorkspaceName {
+			return ui.UsageError("init", "--workspace cannot be combined with --template")
+		}
+		ic.template = templates.WorkspaceName
+	}
+
+	features, err := templates.ParseFeatures(ic.with)
+	if err != nil {
+		return ui.UsageError("init", err.Error())
+	}
+	ic.features = features
+
+	// Never wait on a pipe or /dev/null unless --interactive was asked for
+	if ic.yes && ic.interactive {
+		return ui.UsageError("init", "--interactive cannot be combined with --yes/--non-interactive")
+	}
+	if !ic.yes && !ic.interactive && !ui.StdinIsTerminal() {
+		ic.yes = true
+		if ic.config.Verbose {
+			ui.Info("stdin is not a terminal, running non-interactively")
+		}
+	}
+	ic.prompt = newPrompter(os.Stdin, !ic.yes)
+
+	if err := validate.ValidateBranchName(ic.config.MainBranch); err != nil {
+		return ui.UsageError("init", fmt.Sprintf("invalid branch %q: %v", ic.config.MainBranch, err))
+	}
+
+	if len(remainingArgs) == 0 && ic.answers != nil && ic.answers.ProjectName != "" {
+		remainingArgs = []string{ic.answers.ProjectName}
+	}
+
 	// Auto-enter interactive mode if no project name and not in-place
 	if !ic.config.InPlace && len(remainingArgs) == 0 && !ic.interactive {
+		if ic.yes {
+			return ui.UsageError("init", "project name required (pass it as an argument or set project_name in --answers)")
+		}
+
 		// Offer interactive mode
 		fmt.Println("No project name specified.")
-		fmt.Print("Enter interactive mode? (Y/n): ")
-		reader := bufio.NewReader(os.Stdin)
-		response, _ := reader.ReadString('\n')
-		response = strings.TrimSpace(strings.ToLower(response))
-		if response == "" || response == "y" || response == "yes" {
-			ic.interactive = true
-		} else {
+		enter, _ := ic.prompt.confirm("Enter interactive mode?", true)
+		if !enter {
 			return ui.UsageError("init", "project name required (or use --interactive)")
 		}
+		ic.interactive = true
 	}
 
 	// Handle interactive mode
@@ -204,6 +375,17 @@ func (ic *InitCommand) Run(args []string) error {
 	if ic.template == "" {
 		ic.template = "base" // Ultimate fallback
 	}
+	// Record sources so add and update find them from the project directory
+	ic.template = source.Canonical(ic.template)
+
+	if err := ic.checkNonInteractive(); err != nil {
+		return err
+	}
+
+	// Catch unknown variables and broken templates before anything is created
+	if _, _, err := ic.renderFiles(); err != nil {
+		return err
+	}
 
 	// Execute initialization or dry-run
 	if ic.dryRun {
@@ -212,19 +394,172 @@ func (ic *InitCommand) Run(args []string) error {
 	return ic.runInit()
 }
 
+// projectNamePattern matches valid project names, also used for workspace projects
+var projectNamePattern = regexp.MustCompile("^[a-zA-Z0-9_-]+$")
+
 func (ic *InitCommand) validateProjectName(name string) error {
 	if name == "" {
 		return ui.UsageError("init", "project name cannot be empty")
 	}
 
-	match, _ := regexp.MatchString("^[a-zA-Z0-9_-]+$", name)
-	if !match {
+	if !projectNamePattern.MatchString(name) {
 		return ui.UsageError("init", "invalid characters in project name (use only letters, numbers, hyphens, and underscores)")
 	}
 
 	return nil
 }
 
+// missingGitUser explains how to supply the Git identity without prompts
+const missingGitUser = "git user.name and user.email are required in non-interactive mode (use --git-name/--git-email, git_name/git_email in --answers, a global git identity, or --skip-git-user)"
+
+// prompter returns the shared prompter, creating an interactive one if Run didn't
+func (ic *InitCommand) prompter() *prompter {
+	if ic.prompt == nil {
+		ic.prompt = newPrompter(os.Stdin, !ic.yes)
+	}
+	return ic.prompt
+}
+
+// checkNonInteractive fails early, before anything is created, when a value
+// that would otherwise be prompted for is missing
+func (ic *InitCommand) checkNonInteractive() error {
+	if !ic.yes || ic.config.SkipGit || ic.config.SkipGitUser {
+		return nil
+	}
+
+	// Without prompts, fall back to the identity from ~/.maajiserc, then to
+	// the global git config
+	if ic.fileConfig != nil {
+		if ic.config.GitName == "" {
+			ic.config.GitName = ic.fileConfig.Defaults.GitName
+		}
+		if ic.config.GitEmail == "" {
+			ic.config.GitEmail = ic.fileConfig.Defaults.GitEmail
+		}
+	}
+	if ic.config.GitName == "" {
+		ic.config.GitName, _ = git.GetGlobalConfig("user.name")
+	}
+	if ic.config.GitEmail == "" {
+		ic.config.GitEmail, _ = git.GetGlobalConfig("user.email")
+	}
+
+	if ic.config.GitName == "" || ic.config.GitEmail == "" {
+		return ui.UsageError("init", missingGitUser)
+	}
+	return nil
+}
+
+// templateVars builds the template variables once: defaults for the project
+// name, then the template's own variables, ~/.maajiserc variables, the
+// answers file and --var flags, and finally the answers to the template's
+// questions
+func (ic *InitCommand) templateVars() (templates.TemplateVars, error) {
+	if ic.resolvedVars != nil {
+		return *ic.resolvedVars, nil
+	}
+
+	tmpl, err := ic.lookupTemplate()
+	if err != nil {
+		return templates.TemplateVars{}, err
+	}
+
+	vars := templates.DefaultVars(ic.config.ProjectName)
+	for key, value := range templates.VariablesOf(tmpl) {
+		if err := vars.Set(key, value); err != nil {
+			return vars, fmt.Errorf("template %s: %w", ic.template, err)
+		}
+	}
+	applyConfigVars(&vars, ic.fileConfig)
+	if ic.answers != nil {
+		for key, value := range ic.answers.Vars {
+			if err := vars.Set(key, value); err != nil {
+				return vars, fmt.Errorf("invalid variable in %s: %w", ic.answersPath, err)
+			}
+		}
+	}
+	if err := applyVarFlags(&vars, ic.vars); err != nil {
+		return vars, ui.UsageError("init", err.Error())
+	}
+	vars.Branch = ic.config.MainBranch
+	if ic.license != "" {
+		id, err := parseLicense("init", ic.license)
+		if err != nil {
+			return vars, err
+		}
+		vars.License = id
+	} else if id, ok := templates.LicenseID(vars.License); ok {
+		vars.License = id
+	}
+
+	if err := askQuestions("init", ic.prompter(), templates.QuestionsOf(tmpl), &vars); err != nil {
+		return vars, err
+	}
+
+	ic.resolvedVars = &vars
+	return vars, nil
+}
+
+// lookupTemplate returns the selected template with the --with features
+func (ic *InitCommand) lookupTemplate() (templates.Template, error) {
+	return composeTemplate("init", ic.template, ic.features)
+}
+
+// renderFiles renders the selected template with the project's variables
+func (ic *InitCommand) renderFiles() (map[string]string, templates.TemplateVars, error) {
+	vars, err := ic.templateVars()
+	if err != nil {
+		return nil, vars, err
+	}
+
+	tmpl, err := ic.lookupTemplate()
+	if err != nil {
+		return nil, vars, err
+	}
+
+	files, err := renderTemplate(tmpl, vars)
+	return files, vars, err
+}
+
+// applyAnswers fills the configuration from the answers file. Flags given on
+// the command line win over answers.
+func (ic *InitCommand) applyAnswers() error {
+	set := make(map[string]bool)
+	ic.fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	a := ic.answers
+	if a.ProjectName != "" {
+		ic.config.ProjectName = a.ProjectName
+	}
+	if a.Template != "" && !set["template"] {
+		ic.template = a.Template
+	}
+	if a.Git != nil && !set["skip-git"] {
+		ic.config.SkipGit = !*a.Git
+	}
+	if a.GitName != "" && !set["git-name"] {
+		ic.config.GitName = a.GitName
+	}
+	if a.GitEmail != "" && !set["git-email"] {
+		ic.config.GitEmail = a.GitEmail
+	}
+	if a.Branch != "" && !set["branch"] {
+		ic.config.MainBranch = a.Branch
+	}
+	if a.Remote != "" {
+		if err := validate.ValidateGitURL(a.Remote); err != nil {
+			return fmt.Errorf("invalid remote in %s: %w", ic.answersPath, err)
+		}
+		ic.config.DefaultRemote = a.Remote
+	}
+	if a.Beads != nil && !set["skip-beads"] {
+		ic.config.SkipBeads = !*a.Beads
+	}
+	return nil
+}
+
 func (ic *InitCommand) runDryRun() error {
 	ui.Info("[dry-run] Preview of initialization:")
 	fmt.Println()
@@ -243,13 +578,16 @@ func (ic *InitCommand) runDryRun() error {
 
 	// Show Git initialization
 	if !ic.config.SkipGit {
-		ui.Info("[dry-run] Would initialize Git repository")
+		ui.Info(fmt.Sprintf("[dry-run] Would initialize Git repository (branch: %s)", ic.config.MainBranch))
 		if ic.config.GitName != "" && ic.config.GitEmail != "" {
 			fmt.Printf("         user.name: %s\n", ic.config.GitName)
 			fmt.Printf("         user.email: %s\n", ic.config.GitEmail)
-		} else {
+		} else if !ic.config.SkipGitUser {
 			fmt.Println("         (would prompt for user.name and user.email)")
 		}
+		if ic.config.DefaultRemote != "" && !ic.config.SkipRemote {
+			fmt.Printf("         remote: origin → %s\n", ic.config.DefaultRemote)
+		}
 	}
 
 	// Show Beads initialization
@@ -258,17 +596,22 @@ func (ic *InitCommand) runDryRun() error {
 	}
 
 	// Show files from template
-	tmpl, ok := templates.Get(ic.template)
-	if !ok {
-		return ui.UsageError("init", fmt.Sprintf("unknown template: %s (available: base, typescript, python, rust, php, go, swift)", ic.template))
+	files, _, err := ic.renderFiles()
+	if err != nil {
+		return err
 	}
 
-	files := tmpl.Files(ic.config.ProjectName)
-
 	fmt.Println()
-	ui.Info(fmt.Sprintf("[dry-run] Would create files (template: %s):", ic.template))
+	if len(ic.features) > 0 {
+		ui.Info(fmt.Sprintf("[dry-run] Would create files (template: %s, features: %s):", ic.template, strings.Join(ic.features, ", ")))
+	} else {
+		ui.Info(fmt.Sprintf("[dry-run] Would create files (template: %s):", ic.template))
+	}
 	for filename := range files {
-		fullPath := filepath.Join(targetDir, filename)
+		fullPath, err := fsutil.SafeJoin(targetDir, filename)
+		if err != nil {
+			return fmt.Errorf("template %s: %w", ic.template, err)
+		}
 		if fsutil.FileExists(fullPath) {
 			if ic.config.NoOverwrite {
 				fmt.Printf("         skip: %s (exists, --no-overwrite)\n", filename)
@@ -279,6 +622,24 @@ func (ic *InitCommand) runDryRun() error {
 			fmt.Printf("         create: %s\n", filename)
 		}
 	}
+	fmt.Printf("         create: %s\n", manifest.FileName)
+
+	// Show hooks
+	hooks, err := ic.hooks()
+	if err != nil {
+		return err
+	}
+	if len(hooks) > 0 {
+		fmt.Println()
+		if ic.config.SkipHooks {
+			ui.Info(fmt.Sprintf("[dry-run] Would skip %d template hooks (--skip-hooks)", len(hooks)))
+		} else {
+			ui.Info("[dry-run] Would run hooks:")
+			for _, h := range hooks {
+				fmt.Printf("         run: %s\n", describeHook(h))
+			}
+		}
+	}
 
 	// Show commit
 	if !ic.config.SkipGit && !ic.config.SkipCommit {
@@ -293,15 +654,13 @@ func (ic *InitCommand) runDryRun() error {
 }
 
 func (ic *InitCommand) runInteractive() error {
-	reader := bufio.NewReader(os.Stdin)
+	p := ic.prompt
 
 	ui.Info("Maajise Interactive Setup")
 	fmt.Println()
 
-	// Project name
-	fmt.Print("Project name: ")
-	projectName, _ := reader.ReadString('\n')
-	projectName = strings.TrimSpace(projectName)
+	// Project name (answers and flags become the defaults)
+	projectName, _ := p.ask("Project name", ic.config.ProjectName)
 	if projectName == "" {
 		return ui.UsageError("init", "project name required")
 	}
@@ -317,90 +676,102 @@ func (ic *InitCommand) runInteractive() error {
 	for i, tmpl := range allTemplates {
 		fmt.Printf("  %d. %s - %s\n", i+1, tmpl.Name(), tmpl.Description())
 	}
-	fmt.Printf("Select template [1-%d] (default: base): ", len(allTemplates))
-	templateChoice, _ := reader.ReadString('\n')
-	templateChoice = strings.TrimSpace(templateChoice)
-	if templateChoice == "" {
-		ic.template = "base"
-	} else {
-		// Parse number or name
-		var found bool
-		for i, tmpl := range allTemplates {
-			if templateChoice == fmt.Sprintf("%d", i+1) || templateChoice == tmpl.Name() {
-				ic.template = tmpl.Name()
-				found = true
-				break
-			}
+	defaultTemplate := ic.template
+	if defaultTemplate == "" {
+		defaultTemplate = "base"
+	}
+	templateChoice, _ := p.ask(fmt.Sprintf("Select template [1-%d]", len(allTemplates)), defaultTemplate)
+	// Parse number or name
+	var found bool
+	for i, tmpl := range allTemplates {
+		if templateChoice == fmt.Sprintf("%d", i+1) || templateChoice == tmpl.Name() {
+			ic.template = tmpl.Name()
+			found = true
+			break
 		}
-		if !found {
-			ic.template = "base"
-			ui.Warn(fmt.Sprintf("Unknown template %q, using base", templateChoice))
+	}
+	if !found && source.IsSource(templateChoice) {
+		ic.template = source.Canonical(templateChoice)
+		found = true
+	}
+	if !found {
+		ic.template = "base"
+		ui.Warn(fmt.Sprintf("Unknown template %q, using base", templateChoice))
+	}
+
+	// License
+	defaultLicense := ic.license
+	if defaultLicense == "" {
+		defaultLicense = "MIT"
+		if ic.fileConfig != nil && ic.fileConfig.Variables.License != "" {
+			defaultLicense = ic.fileConfig.Variables.License
 		}
 	}
+	license, _ := p.ask("License (SPDX id, or none)", defaultLicense)
+	if _, err := parseLicense("init", license); err != nil {
+		ui.Warn(fmt.Sprintf("Unknown license %q, using %s", license, defaultLicense))
+		license = defaultLicense
+	}
+	ic.license = license
 
 	// Git configuration
 	fmt.Println()
-	fmt.Print("Initialize Git? (Y/n): ")
-	gitChoice, _ := reader.ReadString('\n')
-	gitChoice = strings.TrimSpace(strings.ToLower(gitChoice))
-	ic.config.SkipGit = (gitChoice == "n" || gitChoice == "no")
+	initGit, _ := p.confirm("Initialize Git?", !ic.config.SkipGit)
+	ic.config.SkipGit = !initGit
 
 	if !ic.config.SkipGit {
 		// Git name
-		defaultName := ""
-		if ic.fileConfig != nil && ic.fileConfig.Defaults.GitName != "" {
+		defaultName := ic.config.GitName
+		if defaultName == "" && ic.fileConfig != nil {
 			defaultName = ic.fileConfig.Defaults.GitName
 		}
-		if defaultName != "" {
-			fmt.Printf("Git user.name [%s]: ", defaultName)
-		} else {
-			fmt.Print("Git user.name: ")
-		}
-		gitName, _ := reader.ReadString('\n')
-		gitName = strings.TrimSpace(gitName)
-		if gitName == "" {
-			gitName = defaultName
-		}
-		ic.config.GitName = gitName
+		ic.config.GitName, _ = p.ask("Git user.name", defaultName)
 
 		// Git email
-		defaultEmail := ""
-		if ic.fileConfig != nil && ic.fileConfig.Defaults.GitEmail != "" {
+		defaultEmail := ic.config.GitEmail
+		if defaultEmail == "" && ic.fileConfig != nil {
 			defaultEmail = ic.fileConfig.Defaults.GitEmail
 		}
-		if defaultEmail != "" {
-			fmt.Printf("Git user.email [%s]: ", defaultEmail)
-		} else {
-			fmt.Print("Git user.email: ")
-		}
-		gitEmail, _ := reader.ReadString('\n')
-		gitEmail = strings.TrimSpace(gitEmail)
-		if gitEmail == "" {
-			gitEmail = defaultEmail
-		}
-		ic.config.GitEmail = gitEmail
+		ic.config.GitEmail, _ = p.ask("Git user.email", defaultEmail)
 	}
 
 	// Beads
 	fmt.Println()
-	fmt.Print("Initialize Beads issue tracking? (Y/n): ")
-	beadsChoice, _ := reader.ReadString('\n')
-	beadsChoice = strings.TrimSpace(strings.ToLower(beadsChoice))
-	ic.config.SkipBeads = (beadsChoice == "n" || beadsChoice == "no")
+	initBeads, _ := p.confirm("Initialize Beads issue tracking?", !ic.config.SkipBeads)
+	ic.config.SkipBeads = !initBeads
+
+	// Questions declared by the template
+	tmpl, err := ic.lookupTemplate()
+	if err != nil {
+		return err
+	}
+	questions := templates.QuestionsOf(tmpl)
+	if len(questions) > 0 {
+		fmt.Println()
+	}
+	vars, err := ic.templateVars()
+	if err != nil {
+		return err
+	}
 
 	// Summary
 	fmt.Println()
 	ui.Info("Summary:")
 	fmt.Printf("  Project: %s\n", ic.config.ProjectName)
 	fmt.Printf("  Template: %s\n", ic.template)
+	if len(ic.features) > 0 {
+		fmt.Printf("  Features: %s\n", strings.Join(ic.features, ", "))
+	}
+	fmt.Printf("  License: %s\n", licenseLabel(vars.License))
 	fmt.Printf("  Git: %v\n", !ic.config.SkipGit)
 	fmt.Printf("  Beads: %v\n", !ic.config.SkipBeads)
+	for _, q := range questions {
+		fmt.Printf("  %s: %v\n", q.Name, vars.Answers[q.Name])
+	}
 	fmt.Println()
 
-	fmt.Print("Proceed? (Y/n): ")
-	proceed, _ := reader.ReadString('\n')
-	proceed = strings.TrimSpace(strings.ToLower(proceed))
-	if proceed == "n" || proceed == "no" {
+	proceed, _ := p.confirm("Proceed?", true)
+	if !proceed {
 		ui.Info("Cancelled")
 		return nil
 	}
@@ -410,7 +781,18 @@ func (ic *InitCommand) runInteractive() error {
 	return ic.runInit()
 }
 
-func (ic *InitCommand) runInit() error {
+func (ic *InitCommand) runInit() (err error) {
+	// Track everything init creates so a failure leaves no half-built project behind
+	ic.tx = txn.New()
+	var stopInterrupt func()
+	ic.ctx, stopInterrupt = cancelOnInterrupt()
+	defer stopInterrupt()
+	defer func() {
+		if err != nil {
+			ic.rollback()
+		}
+	}()
+
 	// Create/determine project directory
 	repoPath, err := ic.createStructure()
 	if err != nil {
@@ -421,24 +803,51 @@ func (ic *InitCommand) runInit() error {
 	if err := ic.initGit(repoPath); err != nil {
 		return err
 	}
+	if err := ic.interrupted(); err != nil {
+		return err
+	}
 
 	// Configure Git user
 	if err := ic.configureGitUser(repoPath); err != nil {
 		return err
 	}
+	if err := ic.interrupted(); err != nil {
+		return err
+	}
 
 	// Initialize Beads
 	ic.initBeads(repoPath)
+	if err := ic.interrupted(); err != nil {
+		return err
+	}
 
 	// Create standard files from template
 	if err := ic.createFiles(repoPath); err != nil {
 		return err
 	}
+	if err := ic.interrupted(); err != nil {
+		return err
+	}
+
+	// Run the template's post-generate hooks
+	if err := ic.runHooks(repoPath); err != nil {
+		return err
+	}
+	if err := ic.interrupted(); err != nil {
+		return err
+	}
 
 	// Initial commit
 	if err := ic.createInitialCommit(repoPath); err != nil {
 		return err
 	}
+	if err := ic.interrupted(); err != nil {
+		return err
+	}
+
+	// The project is complete, keep it even if the remote prompt is interrupted
+	ic.tx.Commit()
+	stopInterrupt()
 
 	// Setup remote (optional)
 	ic.setupGitRemote(repoPath)
@@ -449,6 +858,93 @@ func (ic *InitCommand) runInit() error {
 	return nil
 }
 
+// ErrInterrupted is returned by init after Ctrl-C or SIGTERM, once it has
+// rolled back; main exits with status 130 for it
+var ErrInterrupted = errors.New("interrupted")
+
+// interrupted returns ErrInterrupted once init has been interrupted
+func (ic *InitCommand) interrupted() error {
+	if ic.ctx != nil && ic.ctx.Err() != nil {
+		return ErrInterrupted
+	}
+	return nil
+}
+
+// cancelOnInterrupt returns a context that is canceled on Ctrl-C or SIGTERM.
+// The handler only cancels: init notices between steps, kills a running hook
+// and rolls back from the main goroutine. A second signal gets the default
+// behavior and quits at once. The returned function stops listening and is
+// safe to call more than once.
+func cancelOnInterrupt() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-sigs:
+			signal.Stop(sigs)
+			fmt.Println()
+			ui.Warn("Interrupted, rolling back after the current step (press Ctrl-C again to quit now)")
+			cancel()
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			signal.Stop(sigs)
+			close(done)
+			cancel()
+		})
+	}
+}
+
+// rollback undoes everything recorded in the transaction
+func (ic *InitCommand) rollback() {
+	rollback(ic.tx, "initialization", ic.config.Verbose)
+}
+
+// track records path in the init transaction before it is created or overwritten
+func (ic *InitCommand) track(path string) error {
+	return trackPath(ic.tx, path)
+}
+
+// rollback undoes everything recorded in tx, if any; what names the undone
+// operation in the messages
+func rollback(tx *txn.Tx, what string, verbose bool) {
+	if tx == nil {
+		return
+	}
+
+	created := tx.Created()
+	ui.Warn(fmt.Sprintf("Rolling back %s...", what))
+	if err := tx.Rollback(); err != nil {
+		ui.Error(fmt.Sprintf("Rollback incomplete: %v", err))
+		return
+	}
+
+	if verbose {
+		for _, path := range created {
+			ui.Info(fmt.Sprintf("Removed %s", path))
+		}
+	}
+	ui.Info("Rolled back all changes")
+}
+
+// trackPath records path in tx, if any, before it is created or overwritten
+func trackPath(tx *txn.Tx, path string) error {
+	if tx == nil {
+		return nil
+	}
+	if err := tx.Track(path); err != nil {
+		return fmt.Errorf("failed to track %s for rollback: %w", path, err)
+	}
+	return nil
+}
+
 func (ic *InitCommand) createStructure() (string, error) {
 	if ic.config.InPlace {
 		// Use current directory
@@ -470,6 +966,10 @@ func (ic *InitCommand) createStructure() (string, error) {
 		return "", fmt.Errorf("directory '%s' already exists", ic.config.ProjectName)
 	}
 
+	if err := ic.track(ic.config.ProjectName); err != nil {
+		return "", err
+	}
+
 	if err := os.MkdirAll(innerPath, 0755); err != nil {
 		return "", err
 	}
@@ -486,7 +986,11 @@ func (ic *InitCommand) initGit(repoDir string) error {
 		return nil
 	}
 
-	if err := git.Init(repoDir, ic.config.Verbose); err != nil {
+	if err := ic.track(filepath.Join(repoDir, ".git")); err != nil {
+		return err
+	}
+
+	if err := git.Init(repoDir, ic.config.MainBranch, ic.config.Verbose); err != nil {
 		return err
 	}
 
@@ -517,32 +1021,32 @@ func (ic *InitCommand) configureGitUser(repoDir string) error {
 			ui.Info(fmt.Sprintf("Using provided Git config: %s <%s>", userName, userEmail))
 		}
 	} else {
+		if ic.yes {
+			return ui.UsageError("init", missingGitUser)
+		}
+
 		// Interactive prompts
 		ui.Info("Configuring Git user...")
 		fmt.Println()
 
-		reader := bufio.NewReader(os.Stdin)
+		p := ic.prompter()
 
 		// Get user.name
-		fmt.Print("→ Git user.name (full name): ")
 		var err error
-		userName, err = reader.ReadString('\n')
+		userName, err = p.ask("→ Git user.name (full name)", ic.config.GitName)
 		if err != nil {
 			return fmt.Errorf("failed to read user name: %w", err)
 		}
-		userName = strings.TrimSpace(userName)
 
 		if userName == "" {
 			return ui.UsageError("init", "git user.name cannot be empty")
 		}
 
 		// Get user.email
-		fmt.Print("→ Git user.email (email address): ")
-		userEmail, err = reader.ReadString('\n')
+		userEmail, err = p.ask("→ Git user.email (email address)", ic.config.GitEmail)
 		if err != nil {
 			return fmt.Errorf("failed to read user email: %w", err)
 		}
-		userEmail = strings.TrimSpace(userEmail)
 
 		if userEmail == "" {
 			return ui.UsageError("init", "git user.email cannot be empty")
@@ -554,6 +1058,11 @@ func (ic *InitCommand) configureGitUser(repoDir string) error {
 		}
 	}
 
+	// Back up the config of a repository that existed before init
+	if err := ic.track(filepath.Join(repoDir, ".git", "config")); err != nil {
+		return err
+	}
+
 	// Set user.name
 	if err := git.SetConfig(repoDir, "user.name", userName, ic.config.Verbose); err != nil {
 		return err
@@ -577,6 +1086,11 @@ func (ic *InitCommand) initBeads(repoDir string) {
 		return
 	}
 
+	if err := ic.track(filepath.Join(repoDir, ".beads")); err != nil {
+		ui.Warn(fmt.Sprintf("Skipping Beads: %v", err))
+		return
+	}
+
 	if err := beads.Init(repoDir, ic.config.Verbose); err != nil {
 		ui.Warn("Beads init failed (run 'br init' manually)")
 		return
@@ -584,46 +1098,115 @@ func (ic *InitCommand) initBeads(repoDir string) {
 }
 
 func (ic *InitCommand) createFiles(repoDir string) error {
-	tmpl, ok := templates.Get(ic.template)
-	if !ok {
-		return ui.UsageError("init", fmt.Sprintf("unknown template: %s (available: base, typescript, python, rust, php, go, swift)", ic.template))
+	files, vars, err := ic.renderFiles()
+	if err != nil {
+		return err
 	}
+	tmpl, err := ic.lookupTemplate()
+	if err != nil {
+		return err
+	}
+	modes := templates.Modes(tmpl, vars)
 
-	files := tmpl.Files(ic.config.ProjectName)
+	ic.manifest = manifest.New(ic.template, Version, vars)
+	ic.manifest.Features = ic.features
 	for filename, content := range files {
-		path := filepath.Join(repoDir, filename)
-		if err := ic.writeFileIfNotExists(path, content); err != nil {
+		path, err := fsutil.SafeJoin(repoDir, filename)
+		if err != nil {
+			return fmt.Errorf("template %s: %w", ic.template, err)
+		}
+		written, err := ic.writeFileIfNotExists(path, content, templates.FileMode(modes, filename, content))
+		if err != nil {
 			return err
 		}
+		if written {
+			if err := ic.track(manifest.BaselinePath(repoDir, manifest.Hash(content))); err != nil {
+				return err
+			}
+			if err := recordGenerated(repoDir, ic.manifest, filename, content); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := ic.track(manifest.Path(repoDir)); err != nil {
+		return err
+	}
+	if err := ic.manifest.Save(repoDir); err != nil {
+		return fmt.Errorf("failed to write %s: %w", manifest.FileName, err)
+	}
+	if ic.config.Verbose {
+		ui.Success(fmt.Sprintf("Created %s", manifest.FileName))
 	}
 	return nil
 }
 
-func (ic *InitCommand) writeFileIfNotExists(path, content string) error {
+// writeFileIfNotExists writes a template file, reporting whether it was written
+func (ic *InitCommand) writeFileIfNotExists(path, content string, mode os.FileMode) (bool, error) {
 	if fsutil.FileExists(path) {
 		if ic.config.NoOverwrite {
 			ui.Warn(fmt.Sprintf("Skipped %s (exists, --no-overwrite)", filepath.Base(path)))
-			return nil
+			return false, nil
 		}
 		ui.Warn(fmt.Sprintf("Overwriting %s", filepath.Base(path)))
 	}
 
-	// Create parent directories if needed
-	dir := filepath.Dir(path)
-	if dir != "." && dir != "" {
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return fmt.Errorf("failed to create directory %s: %w", dir, err)
-		}
+	if err := ic.track(path); err != nil {
+		return false, err
 	}
 
-	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
-		return err
+	if err := writeGenerated(path, content, mode); err != nil {
+		return false, err
 	}
 
 	ui.Success(fmt.Sprintf("Created %s", path))
-	return nil
+	return true, nil
 }
 
+// runHooks runs the template's post-generate hooks, unless --skip-hooks.
+// Whatever the hooks create (node_modules, lockfiles, build output) is added
+// to the transaction so a failed or interrupted init removes it too.
+func (ic *InitCommand) runHooks(repoDir string) error {
+	hooks, err := ic.hooks()
+	if err != nil || len(hooks) == 0 {
+		return err
+	}
+	if ic.config.SkipHooks {
+		ui.Info(fmt.Sprintf("Skipping %d template hooks (--skip-hooks)", len(hooks)))
+		return nil
+	}
+
+	ctx := ic.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var before *txn.Snapshot
+	if ic.tx != nil {
+		if before, err = txn.TakeSnapshot(repoDir); err != nil {
+			return err
+		}
+	}
+	ic.hookFiles, err = runHooks(ctx, repoDir, hooks, ic.config.Verbose)
+	if before != nil {
+		if trackErr := ic.tx.TrackNew(before); err == nil {
+			err = trackErr
+		}
+	}
+	return err
+}
+
+// hooks returns the hooks init would run for the template
+func (ic *InitCommand) hooks() ([]templates.Hook, error) {
+	vars, err := ic.templateVars()
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := ic.lookupTemplate()
+	if err != nil {
+		return nil, err
+	}
+	return activeHooks(tmpl, vars)
+}
 
 func (ic *InitCommand) createInitialCommit(repoDir string) error {
 	if ic.config.SkipGit || ic.config.SkipCommit {
@@ -647,23 +1230,40 @@ func (ic *InitCommand) createInitialCommit(repoDir string) error {
 	}
 
 	// Get files from template
-	tmpl, _ := templates.Get(ic.template)
-	files := tmpl.Files(ic.config.ProjectName)
-	fileList := make([]string, 0, len(files))
+	files, _, err := ic.renderFiles()
+	if err != nil {
+		return err
+	}
+	fileList := make([]string, 0, len(files)+2)
+	var executables []string
 	for filename := range files {
 		fileList = append(fileList, filename)
+		if info, err := os.Stat(filepath.Join(repoDir, filename)); err == nil && info.Mode()&0111 != 0 {
+			executables = append(executables, filename)
+		}
+	}
+	fileList = append(fileList, ic.hookFiles...)
+	fileList = append(fileList, manifest.FileName, filepath.Dir(manifest.BaselineDir))
+
+	// Staging rewrites the index of a repository that existed before init
+	if err := ic.track(filepath.Join(repoDir, ".git", "index")); err != nil {
+		return err
 	}
 
 	// Add files
 	if err := git.AddFiles(repoDir, fileList, ic.config.Verbose); err != nil {
 		return err
 	}
+	if err := git.SetExecutable(repoDir, executables, ic.config.Verbose); err != nil {
+		return err
+	}
 
 	commitMsg := `Initial commit
 
 - Add .ubsignore for UBS scanner
 - Add .gitignore for version control
 - Add README.md with project structure
+- Add .maajise.lock and .maajise/ recording the project template
 - Initialize Beads issue tracking`
 
 	// Create commit
@@ -691,35 +1291,34 @@ func (ic *InitCommand) setupGitRemote(repoDir string) {
 		return
 	}
 
-	fmt.Println()
-	ui.Info("Git remote setup (optional)")
-	fmt.Println()
-
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Print("? Add git remote? (y/N): ")
-	response, err := reader.ReadString('\n')
-	if err != nil {
-		return
-	}
-	response = strings.TrimSpace(response)
+	// A remote from --answers is added without asking
+	remoteURL := ic.config.DefaultRemote
+	if remoteURL == "" {
+		if ic.yes {
+			if ic.config.Verbose {
+				ui.Info("Skipping remote setup (non-interactive)")
+			}
+			return
+		}
 
-	if strings.ToLower(response) != "y" {
-		ui.Info("Skipped remote setup")
-		return
-	}
+		fmt.Println()
+		ui.Info("Git remote setup (optional)")
+		fmt.Println()
 
-	fmt.Println()
-	ui.Info(fmt.Sprintf("Enter remote URL (e.g., https://github.com/username/%s.git)", ic.config.ProjectName))
-	fmt.Print("→ Remote URL: ")
-	remoteURL, err := reader.ReadString('\n')
-	if err != nil {
-		return
-	}
-	remoteURL = strings.TrimSpace(remoteURL)
+		p := ic.prompter()
+		add, err := p.confirm("? Add git remote?", false)
+		if err != nil || !add {
+			ui.Info("Skipped remote setup")
+			return
+		}
 
-	if remoteURL == "" {
-		ui.Info("Skipped remote setup")
-		return
+		fmt.Println()
+		ui.Info(fmt.Sprintf("Enter remote URL (e.g., https://github.com/username/%s.git)", ic.config.ProjectName))
+		remoteURL, err = p.ask("→ Remote URL", "")
+		if err != nil || remoteURL == "" {
+			ui.Info("Skipped remote setup")
+			return
+		}
 	}
 
 	// Validate the git remote URL
@@ -733,15 +1332,19 @@ func (ic *InitCommand) setupGitRemote(repoDir string) {
 		return
 	}
 
+	// An existing repository in --in-place mode may be on another branch
+	branch, err := git.CurrentBranch(repoDir)
+	if err != nil {
+		branch = ic.config.MainBranch
+	}
+
 	ui.Success(fmt.Sprintf("Added remote: origin → %s", remoteURL))
 	fmt.Println()
 	ui.Info("Push to remote with:")
-	if ic.config.InPlace {
-		fmt.Println("  git push -u origin main")
-	} else {
+	if !ic.config.InPlace {
 		fmt.Printf("  cd %s/%s\n", ic.config.ProjectName, ic.config.ProjectName)
-		fmt.Println("  git push -u origin main")
 	}
+	fmt.Printf("  git push -u origin %s\n", branch)
 	fmt.Println()
 }
 
@@ -753,13 +1356,17 @@ func (ic *InitCommand) showSummary(repoPath string) {
 		fmt.Sprintf("Location: %s", repoPath),
 	)
 
+	create := "Create your project files"
+	if ic.template == templates.WorkspaceName {
+		create = "Add projects with 'maajise add project <name> --template=<template>'"
+	}
 	if !ic.config.InPlace {
 		ui.Info("Next steps:")
 		fmt.Printf("  1. cd %s/%s\n", ic.config.ProjectName, ic.config.ProjectName)
-		fmt.Println("  2. Create your project files")
+		fmt.Println("  2. " + create)
 	} else {
 		ui.Info("Next steps:")
-		fmt.Println("  1. Create your project files")
+		fmt.Println("  1. " + create)
 	}
 
 	fmt.Println("  2. Run 'ubs .' to scan for issues")
@@ -1,10 +1,17 @@
 package cmd
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"maajise/internal/config"
+	"maajise/internal/fsutil"
+	"maajise/internal/git"
+	"maajise/internal/manifest"
+	"maajise/templates"
 )
 
 // contains is a helper function for substring checking in tests
@@ -171,3 +178,674 @@ func TestInitCommand_InterfaceImplementation(t *testing.T) {
 	_ = ic.Examples()
 	// Execute is tested separately due to side effects
 }
+
+func TestInitCommand_RollbackOnFailure(t *testing.T) {
+	if err := git.CheckAvailable(); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "maajise-init-rollback-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	// The invalid email is rejected after the directory and .git were created
+	ic := NewInitCommand()
+	err = ic.Run([]string{"--skip-beads", "--skip-remote", "--git-name=Test", "--git-email=invalid", "my-project"})
+	if err == nil {
+		t.Fatal("Run() should fail for an invalid email")
+	}
+
+	if fsutil.PathExists(filepath.Join(tmpDir, "my-project")) {
+		t.Error("failed init should remove the project directory")
+	}
+
+	// A retry must not fail with "directory already exists"
+	ic = NewInitCommand()
+	err = ic.Run([]string{"--skip-beads", "--skip-remote", "--skip-git", "my-project"})
+	if err != nil {
+		t.Errorf("Run() after rollback error = %v", err)
+	}
+}
+
+func TestInitCommand_RollbackRestoresInPlaceFiles(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-init-rollback-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	// A file in place of the .maajise directory makes saving baselines fail
+	os.WriteFile("README.md", []byte("mine\n"), 0644)
+	os.WriteFile(".maajise", []byte("in the way\n"), 0644)
+
+	ic := NewInitCommand()
+	err = ic.Run([]string{"--in-place", "--skip-git", "--skip-beads"})
+	if err == nil {
+		t.Fatal("Run() should fail when baselines cannot be written")
+	}
+
+	content, _ := os.ReadFile("README.md")
+	if string(content) != "mine\n" {
+		t.Errorf("README.md = %q after rollback, want original content", content)
+	}
+	if fsutil.PathExists(".gitignore") || fsutil.PathExists(manifest.FileName) {
+		t.Error("rollback should remove files created by init")
+	}
+	if !fsutil.FileExists(".maajise") {
+		t.Error("rollback should keep files that existed before init")
+	}
+}
+
+func TestInitCommand_InvalidBranch(t *testing.T) {
+	ic := NewInitCommand()
+
+	err := ic.Run([]string{"--branch=bad..name", "my-project"})
+	if err == nil {
+		t.Fatal("Expected error for invalid branch name")
+	}
+	if !contains(err.Error(), "invalid branch") {
+		t.Errorf("Expected 'invalid branch' in error, got: %v", err)
+	}
+}
+
+func TestInitCommand_Branch(t *testing.T) {
+	if err := git.CheckAvailable(); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "maajise-init-branch-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	ic := NewInitCommand()
+	ic.config.MainBranch = "trunk"
+	ic.config.SkipGitUser = true
+	if err := ic.initGit(tmpDir); err != nil {
+		t.Fatalf("initGit() error = %v", err)
+	}
+
+	branch, err := git.CurrentBranch(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if branch != "trunk" {
+		t.Errorf("initial branch = %q, want %q", branch, "trunk")
+	}
+}
+
+func TestInitCommand_TrailingFlags(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-init-flags-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	// Flags after the project name apply too, as in the documented examples
+	ic := NewInitCommand()
+	err = ic.Run([]string{"my-api", "--template=go", "--with", "docker,ci", "--skip-hooks", "--yes", "--skip-git", "--skip-beads"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	repo := filepath.Join("my-api", "my-api")
+	for _, filename := range []string{"go.mod", "Dockerfile", filepath.Join(".github", "workflows", "ci.yml")} {
+		if !fsutil.FileExists(filepath.Join(repo, filename)) {
+			t.Errorf("%s not created, want trailing --template and --with applied", filename)
+		}
+	}
+	if !ic.config.SkipHooks || !ic.yes {
+		t.Error("trailing boolean flags were ignored")
+	}
+}
+
+func TestInitCommand_NonInteractive_MissingName(t *testing.T) {
+	ic := NewInitCommand()
+
+	err := ic.Run([]string{"--yes"})
+	if err == nil {
+		t.Fatal("Expected error when no project name provided with --yes")
+	}
+	if !contains(err.Error(), "project name required") {
+		t.Errorf("Expected 'project name required' in error, got: %v", err)
+	}
+}
+
+func TestInitCommand_NonInteractive_DevNull(t *testing.T) {
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+
+	// /dev/null is a character device but not a terminal; init must not
+	// prompt on it
+	oldStdin := os.Stdin
+	os.Stdin = devNull
+	defer func() { os.Stdin = oldStdin }()
+
+	ic := NewInitCommand()
+	err = ic.Run([]string{})
+	if err == nil {
+		t.Fatal("Expected error when no project name provided without a terminal")
+	}
+	if !contains(err.Error(), "project name required (pass it") {
+		t.Errorf("Expected the non-interactive 'project name required' error, got: %v", err)
+	}
+}
+
+func TestInitCommand_NonInteractive_MissingGitUser(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-init-yes-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	// Ignore any global git identity
+	global := filepath.Join(tmpDir, "gitconfig")
+	os.WriteFile(global, nil, 0644)
+	t.Setenv("GIT_CONFIG_GLOBAL", global)
+
+	ic := NewInitCommand()
+	ic.fileConfig = nil // ignore any ~/.maajiserc identity
+	err = ic.Run([]string{"--non-interactive", "--skip-beads", "my-project"})
+	if err == nil {
+		t.Fatal("Expected error when git identity is missing without prompts")
+	}
+	if !contains(err.Error(), "--git-name") {
+		t.Errorf("Expected a hint about --git-name, got: %v", err)
+	}
+	if fsutil.PathExists("my-project") {
+		t.Error("nothing should be created when a required value is missing")
+	}
+
+	// The global git identity is enough
+	if err := git.CheckAvailable(); err != nil {
+		t.Skip("git not available")
+	}
+	os.WriteFile(global, []byte("[user]\n\tname = Global User\n\temail = global@example.com\n"), 0644)
+	ic = NewInitCommand()
+	ic.fileConfig = nil
+	if err := ic.Run([]string{"--non-interactive", "--skip-beads", "--skip-remote", "my-project"}); err != nil {
+		t.Fatalf("Run() with a global git identity error = %v", err)
+	}
+	name, _ := git.GetConfig(filepath.Join("my-project", "my-project"), "user.name")
+	if name != "Global User" {
+		t.Errorf("user.name = %q, want the global identity", name)
+	}
+}
+
+func TestInitCommand_Answers(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-init-answers-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	answers := filepath.Join(tmpDir, "answers.yaml")
+	os.WriteFile(answers, []byte("project_name: from-answers\ntemplate: go\ngit: false\nbeads: false\n"), 0644)
+
+	ic := NewInitCommand()
+	if err := ic.Run([]string{"--yes", "--answers", answers}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	repo := filepath.Join("from-answers", "from-answers")
+	if !fsutil.FileExists(filepath.Join(repo, "go.mod")) {
+		t.Error("template from answers file was not used")
+	}
+	if fsutil.PathExists(filepath.Join(repo, ".git")) {
+		t.Error("git: false in answers should skip Git")
+	}
+}
+
+func TestInitCommand_AnswersFlagPrecedence(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-init-answers-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	answers := filepath.Join(tmpDir, "answers.yaml")
+	os.WriteFile(answers, []byte("template: go\nbranch: develop\n"), 0644)
+
+	ic := NewInitCommand()
+	ic.fs.Parse([]string{"--template=rust", "--answers", answers})
+	ic.answers, _ = config.LoadAnswers(answers)
+	if err := ic.applyAnswers(); err != nil {
+		t.Fatal(err)
+	}
+
+	if ic.template != "rust" {
+		t.Errorf("template = %q, want flag value %q", ic.template, "rust")
+	}
+	if ic.config.MainBranch != "develop" {
+		t.Errorf("MainBranch = %q, want answers value %q", ic.config.MainBranch, "develop")
+	}
+}
+
+func TestInitCommand_Vars(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-init-vars-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	os.WriteFile("vars-test.yaml", []byte(`name: vars-test
+files:
+  "cmd/{{.ProjectName}}/main.go": "// owned by {{.team}}\n"
+`), 0644)
+	if err := templates.LoadCustomTemplates(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// An unknown variable fails before anything is created
+	ic := NewInitCommand()
+	err = ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--template=vars-test", "tool"})
+	if err == nil || !contains(err.Error(), "team") {
+		t.Fatalf("Run() error = %v, want missing variable error", err)
+	}
+	if fsutil.PathExists("tool") {
+		t.Error("nothing should be created when rendering fails")
+	}
+
+	ic = NewInitCommand()
+	err = ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--template=vars-test", "--var", "team=platform", "tool"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join("tool", "tool", "cmd", "tool", "main.go"))
+	if err != nil {
+		t.Fatalf("rendered path not created: %v", err)
+	}
+	if string(content) != "// owned by platform\n" {
+		t.Errorf("main.go = %q", content)
+	}
+
+	// The variables are recorded for add and update
+	m, _ := manifest.Load(filepath.Join("tool", "tool"))
+	if m == nil || m.Vars.Extra["team"] != "platform" {
+		t.Errorf("manifest vars = %+v, want team recorded", m)
+	}
+}
+
+func TestInitCommand_InvalidVar(t *testing.T) {
+	ic := NewInitCommand()
+	if err := ic.fs.Parse([]string{"--var", "missing-equals"}); err == nil {
+		t.Error("Expected error for --var without key=value")
+	}
+}
+
+func TestInitCommand_Questions(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-init-questions-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	os.WriteFile("questions-test.yaml", []byte(`name: questions-test
+questions:
+  - name: service
+    validate: "^[a-z]+$"
+  - name: docker
+    type: bool
+    default: "yes"
+files:
+  README.md: "# {{.Answers.service}}{{if .Answers.docker}} (docker){{end}}\n"
+`), 0644)
+	if err := templates.LoadCustomTemplates(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// A question without a default must be answered in --yes mode
+	ic := NewInitCommand()
+	err = ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--template=questions-test", "svc"})
+	if err == nil || !contains(err.Error(), "service") {
+		t.Fatalf("Run() error = %v, want unanswered question error", err)
+	}
+
+	// Invalid answers are rejected
+	ic = NewInitCommand()
+	err = ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--template=questions-test", "--var", "service=Not Valid", "svc"})
+	if err == nil {
+		t.Fatal("Run() should reject an answer that fails validation")
+	}
+
+	ic = NewInitCommand()
+	err = ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--template=questions-test", "--var", "service=billing", "svc"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join("svc", "svc", "README.md"))
+	if string(content) != "# billing (docker)\n" {
+		t.Errorf("README.md = %q", content)
+	}
+
+	// The answers are recorded so update renders the same files
+	m, _ := manifest.Load(filepath.Join("svc", "svc"))
+	if m == nil || m.Vars.Answers["service"] != "billing" || m.Vars.Answers["docker"] != true {
+		t.Errorf("manifest answers = %+v", m)
+	}
+}
+
+func TestInitCommand_TemplateSource(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-init-source-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	os.MkdirAll("shared", 0755)
+	os.WriteFile(filepath.Join("shared", "template.yaml"), []byte(`name: shared
+files:
+  README.md: "# {{.ProjectName}} (shared)\n"
+`), 0644)
+
+	ic := NewInitCommand()
+	err = ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--template=./shared", "svc"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join("svc", "svc", "README.md"))
+	if string(content) != "# svc (shared)\n" {
+		t.Errorf("README.md = %q", content)
+	}
+
+	// Recorded as an absolute path so update works from the project directory
+	m, _ := manifest.Load(filepath.Join("svc", "svc"))
+	if m == nil || m.Template != filepath.Join(tmpDir, "shared") {
+		t.Errorf("manifest template = %+v, want %s", m, filepath.Join(tmpDir, "shared"))
+	}
+
+	ic = NewInitCommand()
+	err = ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--template=./missing", "other"})
+	if err == nil {
+		t.Error("Run() should fail for a missing template directory")
+	}
+}
+
+func TestInitCommand_DirectoryTemplate(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-init-tree-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	os.MkdirAll(filepath.Join("tree", "files", "scripts"), 0755)
+	os.WriteFile(filepath.Join("tree", "template.yaml"), []byte("name: tree\nvariables:\n  port: \"8080\"\n"), 0644)
+	os.WriteFile(filepath.Join("tree", "files", "config.yaml.tmpl"), []byte("port: {{.port}}\n"), 0644)
+	os.WriteFile(filepath.Join("tree", "files", "scripts", "run.sh"), []byte("#!/bin/sh\n"), 0755)
+
+	ic := NewInitCommand()
+	err = ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--template=./tree", "--var", "port=9090", "svc"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	repoDir := filepath.Join("svc", "svc")
+	if content, _ := os.ReadFile(filepath.Join(repoDir, "config.yaml")); string(content) != "port: 9090\n" {
+		t.Errorf("config.yaml = %q, want --var to override the template variable", content)
+	}
+	info, err := os.Stat(filepath.Join(repoDir, "scripts", "run.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("run.sh mode = %v, want 0755", info.Mode().Perm())
+	}
+}
+
+func TestInitCommand_UnsafeTemplatePaths(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-init-unsafe-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	os.MkdirAll("evil", 0755)
+	os.WriteFile(filepath.Join("evil", "template.yaml"), []byte(`name: evil
+files:
+  README.md: "# {{.ProjectName}}\n"
+  ../../escaped.txt: "gotcha\n"
+`), 0644)
+
+	ic := NewInitCommand()
+	err = ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--template=./evil", "svc"})
+	if err == nil || !strings.Contains(err.Error(), "unsafe path") {
+		t.Fatalf("Run() error = %v, want an unsafe path error", err)
+	}
+	if _, err := os.Stat("escaped.txt"); err == nil {
+		t.Error("template wrote outside the project")
+	}
+}
+
+func TestInitCommand_FileModes(t *testing.T) {
+	if err := git.CheckAvailable(); err != nil {
+		t.Skip("git not available")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "maajise-init-modes-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	os.MkdirAll("modes", 0755)
+	os.WriteFile(filepath.Join("modes", "template.yaml"), []byte(`name: modes
+files:
+  README.md: "# {{.ProjectName}}\n"
+  scripts/build.sh: "#!/bin/sh\necho build\n"
+  bin/setup:
+    mode: "0750"
+    content: "echo setup\n"
+`), 0644)
+
+	ic := NewInitCommand()
+	err = ic.Run([]string{"--yes", "--skip-beads", "--skip-remote", "--git-name=Test", "--git-email=test@example.com", "--template=./modes", "svc"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	repoDir := filepath.Join("svc", "svc")
+	want := map[string]os.FileMode{"README.md": 0644, "scripts/build.sh": 0755, "bin/setup": 0750}
+	for name, mode := range want {
+		info, err := os.Stat(filepath.Join(repoDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != mode {
+			t.Errorf("%s mode = %04o, want %04o", name, info.Mode().Perm(), mode)
+		}
+	}
+
+	// The commit keeps the executable bits
+	out, err := exec.Command("git", "-C", repoDir, "ls-tree", "-r", "HEAD").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		executable := fields[0] == "100755"
+		if name := fields[3]; executable != (want[name]&0100 != 0) {
+			t.Errorf("%s committed with mode %s", name, fields[0])
+		}
+	}
+}
+
+func TestInitCommand_WithFeatures(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-init-features-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	ic := NewInitCommand()
+	err = ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--skip-hooks", "--template=python", "--with", "docker, ci", "api"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	repoDir := filepath.Join("api", "api")
+	dockerfile, _ := os.ReadFile(filepath.Join(repoDir, "Dockerfile"))
+	if !strings.Contains(string(dockerfile), "FROM python:") {
+		t.Errorf("Dockerfile = %q, want a Python image", dockerfile)
+	}
+	for _, path := range []string{".dockerignore", ".github/workflows/ci.yml", "pyproject.toml"} {
+		if !fsutil.FileExists(filepath.Join(repoDir, filepath.FromSlash(path))) {
+			t.Errorf("missing file: %s", path)
+		}
+	}
+
+	m, _ := manifest.Load(repoDir)
+	if m == nil || strings.Join(m.Features, ",") != "docker,ci" {
+		t.Errorf("manifest = %+v, want features docker,ci recorded", m)
+	}
+
+	// The CI workflow runs on the project's main branch, which is recorded
+	ic = NewInitCommand()
+	err = ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--skip-hooks", "--template=go", "--branch=trunk", "--with", "ci", "svc"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	workflow, _ := os.ReadFile(filepath.Join("svc", "svc", ".github", "workflows", "ci.yml"))
+	if !strings.Contains(string(workflow), "branches: [trunk]") {
+		t.Errorf("ci.yml = %q, want it to run on trunk", workflow)
+	}
+	if m, _ := manifest.Load(filepath.Join("svc", "svc")); m == nil || m.Vars.Branch != "trunk" {
+		t.Errorf("manifest = %+v, want branch trunk recorded", m)
+	}
+
+	ic = NewInitCommand()
+	if err := ic.Run([]string{"--yes", "--skip-git", "--with", "kubernetes", "other"}); err == nil {
+		t.Error("Run() should fail for an unknown feature")
+	}
+}
+
+func TestInitCommand_Workspace(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-init-workspace-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	ic := NewInitCommand()
+	if err := ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--workspace", "platform"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	repoDir := filepath.Join("platform", "platform")
+	if !fsutil.FileExists(filepath.Join(repoDir, "apps", ".gitkeep")) {
+		t.Error("missing file: apps/.gitkeep")
+	}
+	m, _ := manifest.Load(repoDir)
+	if m == nil || m.Template != templates.WorkspaceName {
+		t.Errorf("manifest = %+v, want template workspace", m)
+	}
+
+	ic = NewInitCommand()
+	if err := ic.Run([]string{"--yes", "--skip-git", "--workspace", "--template=go", "other"}); err == nil {
+		t.Error("Run() should fail for --workspace with another --template")
+	}
+}
+
+func TestInitCommand_License(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-init-license-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	ic := NewInitCommand()
+	err = ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--skip-hooks", "--template=rust", "--license=bsd-3", "--var", "author=Jane Doe", "lib"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	repoDir := filepath.Join("lib", "lib")
+	license, _ := os.ReadFile(filepath.Join(repoDir, "LICENSE"))
+	if !strings.HasPrefix(string(license), "BSD 3-Clause License\n") || !strings.Contains(string(license), ", Jane Doe\n") {
+		t.Errorf("LICENSE = %q, want the BSD-3-Clause text for Jane Doe", license)
+	}
+	cargo, _ := os.ReadFile(filepath.Join(repoDir, "Cargo.toml"))
+	if !strings.Contains(string(cargo), `license = "BSD-3-Clause"`) {
+		t.Errorf("Cargo.toml = %q, want the same SPDX id", cargo)
+	}
+
+	ic = NewInitCommand()
+	if err := ic.Run([]string{"--yes", "--skip-git", "--skip-beads", "--license=none", "unlicensed"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if fsutil.FileExists(filepath.Join("unlicensed", "unlicensed", "LICENSE")) {
+		t.Error("--license=none still wrote LICENSE")
+	}
+
+	ic = NewInitCommand()
+	if err := ic.Run([]string{"--yes", "--skip-git", "--license=WTFPL", "other"}); err == nil {
+		t.Error("Run() should fail for an unknown license")
+	}
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"maajise/internal/config"
+	"maajise/internal/detect"
+	"maajise/internal/manifest"
+	"maajise/internal/source"
+	"maajise/internal/ui"
+	"maajise/templates"
+)
+
+// loadManifest reads the project manifest, warning (not failing) if it is unreadable
+func loadManifest(dir string) *manifest.Manifest {
+	m, err := manifest.Load(dir)
+	if err != nil {
+		ui.Warn(err.Error())
+		return nil
+	}
+	return m
+}
+
+// projectTemplate picks the template for an existing project. An explicit
+// --template wins, then the manifest, then marker-file detection.
+// The second return value describes where the template came from.
+func projectTemplate(dir, explicit string, m *manifest.Manifest) (string, string) {
+	if explicit != "" {
+		return source.Canonical(explicit), "--template"
+	}
+	if m != nil && m.Template != "" {
+		return m.Template, manifest.FileName
+	}
+	return detect.Template(dir), "detected"
+}
+
+// projectVars returns the template variables recorded for a project, falling
+// back to defaults derived from the directory name and ~/.maajiserc
+func projectVars(dir string, m *manifest.Manifest) templates.TemplateVars {
+	if m != nil && m.Vars.ProjectName != "" {
+		return m.Vars
+	}
+
+	vars := templates.DefaultVars(filepath.Base(dir))
+	if fc, err := config.LoadFileConfig(); err == nil {
+		applyConfigVars(&vars, fc)
+		if fc != nil {
+			vars.Branch = fc.Defaults.MainBranch
+		}
+	}
+	return vars
+}
+
+// recordGenerated records a written template file in the manifest and stores
+// its content as the baseline for future three-way merges
+func recordGenerated(dir string, m *manifest.Manifest, path, content string) error {
+	m.Record(path, content)
+	if err := manifest.SaveBaseline(dir, content); err != nil {
+		return fmt.Errorf("failed to save baseline for %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeGenerated writes a template file, creating its parent directories. A
+// zero mode means the default 0644; the mode is also applied to an existing
+// file, which os.WriteFile leaves alone.
+func writeGenerated(path, content string, mode os.FileMode) error {
+	if mode == 0 {
+		mode = 0644
+	}
+
+	dir := filepath.Dir(path)
+	if dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(content), mode); err != nil {
+		return err
+	}
+	return os.Chmod(path, mode)
+}
+
+// sourceTemplates caches the templates loaded from sources during this run,
+// so a source is fetched once even though commands look it up repeatedly
+var sourceTemplates = make(map[string]templates.Template)
+
+// lookupTemplate finds a template by name, or fetches it when name is a
+// template source (directory, tarball or git repository), and checks that a
+// custom template's extends chain resolves
+func lookupTemplate(cmdName, name string) (templates.Template, error) {
+	if source.IsSource(name) {
+		return lookupSource(cmdName, name)
+	}
+
+	tmpl, ok := templates.Get(name)
+	if !ok {
+		names := templates.List()
+		sort.Strings(names)
+		return nil, ui.UsageError(cmdName, fmt.Sprintf("unknown template: %s (available: %s)", name, strings.Join(names, ", ")))
+	}
+	if err := templates.Resolve(tmpl); err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// lookupSource fetches a template source into ~/.maajise/cache and loads the
+// template.yaml manifest at its root
+func lookupSource(cmdName, ref string) (templates.Template, error) {
+	if tmpl, ok := sourceTemplates[ref]; ok {
+		return tmpl, nil
+	}
+
+	src, err := source.Parse(ref)
+	if err != nil {
+		return nil, ui.UsageError(cmdName, err.Error())
+	}
+
+	dir, err := source.Fetch(src, source.DefaultCacheDir())
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", ref, err)
+	}
+
+	tmpl, err := templates.LoadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", ref, err)
+	}
+	if err := templates.Resolve(tmpl); err != nil {
+		return nil, fmt.Errorf("template %s: %w", ref, err)
+	}
+
+	sourceTemplates[ref] = tmpl
+	return tmpl, nil
+}
+
+// composeTemplate looks up a template and layers the named features on top
+func composeTemplate(cmdName, name string, features []string) (templates.Template, error) {
+	tmpl, err := lookupTemplate(cmdName, name)
+	if err != nil {
+		return nil, err
+	}
+	return templates.Compose(tmpl, features)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	"maajise/internal/diff"
+	"maajise/internal/ui"
+)
+
+// previewChange shows what a dry run would change in a file: a colored unified
+// diff, or with summaryOnly a single line of changed line counts. It returns the
+// number of lines added and removed.
+func previewChange(filename, current, proposed string, existed, summaryOnly bool) (int, int) {
+	added, removed := diff.Stat(current, proposed)
+
+	if summaryOnly {
+		status := "M"
+		if !existed {
+			status = "A"
+		}
+		fmt.Printf("  %s %-40s %s+%d%s %s-%d%s\n", status, filename, ui.Green, added, ui.Reset, ui.Red, removed, ui.Reset)
+		return added, removed
+	}
+
+	oldName := "a/" + filename
+	if !existed {
+		oldName = diff.DevNull
+	}
+	if d := diff.Unified(oldName, "b/"+filename, current, proposed, diff.DefaultContext); d != "" {
+		ui.Diff(d)
+	}
+	return added, removed
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// errNoPrompt is returned when a prompt is needed in non-interactive mode
+var errNoPrompt = errors.New("cannot prompt in non-interactive mode")
+
+// prompter reads answers to prompts. All prompts of a command share one
+// reader so buffered input piped to stdin isn't lost between questions.
+type prompter struct {
+	reader      *bufio.Reader
+	interactive bool
+}
+
+func newPrompter(r io.Reader, interactive bool) *prompter {
+	return &prompter{reader: bufio.NewReader(r), interactive: interactive}
+}
+
+// ask prints question and returns the trimmed answer, or def if the answer is
+// empty. In non-interactive mode it returns errNoPrompt without reading.
+func (p *prompter) ask(question, def string) (string, error) {
+	if !p.interactive {
+		return "", errNoPrompt
+	}
+
+	if def != "" {
+		fmt.Printf("%s [%s]: ", question, def)
+	} else {
+		fmt.Printf("%s: ", question)
+	}
+
+	answer, err := p.reader.ReadString('\n')
+	answer = strings.TrimSpace(answer)
+	if err != nil && (err != io.EOF || answer == "") {
+		return def, err
+	}
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// confirm asks a yes/no question, returning def for an empty answer
+func (p *prompter) confirm(question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	if !p.interactive {
+		return def, errNoPrompt
+	}
+
+	fmt.Printf("%s (%s): ", question, hint)
+	answer, err := p.reader.ReadString('\n')
+	answer = strings.TrimSpace(strings.ToLower(answer))
+	if err != nil && (err != io.EOF || answer == "") {
+		return def, err
+	}
+
+	if def {
+		return answer != "n" && answer != "no", nil
+	}
+	return answer == "y" || answer == "yes", nil
+}
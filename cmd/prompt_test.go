@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrompter_Ask(t *testing.T) {
+	p := newPrompter(strings.NewReader("first\n\nlast"), true)
+
+	if got, _ := p.ask("Name", "def"); got != "first" {
+		t.Errorf("ask() = %q, want %q", got, "first")
+	}
+	if got, _ := p.ask("Name", "def"); got != "def" {
+		t.Errorf("ask() with empty answer = %q, want default %q", got, "def")
+	}
+	// The last line has no newline but is still an answer
+	if got, err := p.ask("Name", ""); got != "last" || err != nil {
+		t.Errorf("ask() = %q, %v, want %q, nil", got, err, "last")
+	}
+	if _, err := p.ask("Name", ""); err == nil {
+		t.Error("ask() at end of input should return an error")
+	}
+}
+
+func TestPrompter_Confirm(t *testing.T) {
+	p := newPrompter(strings.NewReader("\nno\nyes\nmaybe\n"), true)
+
+	want := []bool{true, false, true, true}
+	for i, w := range want {
+		if got, _ := p.confirm("Continue?", true); got != w {
+			t.Errorf("confirm() answer %d = %v, want %v", i, got, w)
+		}
+	}
+}
+
+func TestPrompter_NonInteractive(t *testing.T) {
+	p := newPrompter(strings.NewReader("ignored\n"), false)
+
+	if _, err := p.ask("Name", "def"); err != errNoPrompt {
+		t.Errorf("ask() error = %v, want errNoPrompt", err)
+	}
+	if got, err := p.confirm("Continue?", true); err != errNoPrompt || !got {
+		t.Errorf("confirm() = %v, %v, want default and errNoPrompt", got, err)
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"maajise/internal/ui"
+	"maajise/templates"
+)
+
+// askQuestions fills vars.Answers for a template's questions. Questions that
+// already have an answer (e.g. recorded in .maajise.lock) are skipped. A value
+// given as a variable (--var, the answers file or ~/.maajiserc) answers the
+// question of the same name; otherwise it is prompted for, or its default is
+// used when p is nil or not interactive.
+func askQuestions(cmdName string, p *prompter, questions []templates.Question, vars *templates.TemplateVars) error {
+	if vars.Answers == nil {
+		vars.Answers = make(map[string]any)
+	}
+
+	for _, q := range questions {
+		if _, done := vars.Answers[q.Name]; done {
+			continue
+		}
+
+		applies, err := q.Applies(*vars)
+		if err != nil {
+			return err
+		}
+		if !applies {
+			// Later conditions can still refer to it
+			vars.Answers[q.Name] = zeroAnswer(q)
+			continue
+		}
+
+		if given, ok := vars.Extra[q.Name]; ok {
+			value, err := q.Parse(given)
+			if err != nil {
+				return ui.UsageError(cmdName, fmt.Sprintf("answer for %s: %v", q.Name, err))
+			}
+			delete(vars.Extra, q.Name)
+			vars.Answers[q.Name] = value
+			continue
+		}
+
+		if p != nil && p.interactive {
+			value, err := promptQuestion(p, q)
+			if err != nil {
+				return fmt.Errorf("failed to read answer for %s: %w", q.Name, err)
+			}
+			vars.Answers[q.Name] = value
+			continue
+		}
+
+		value, ok := q.DefaultValue()
+		if !ok {
+			return ui.UsageError(cmdName, fmt.Sprintf("template question %q has no default (answer it with --var %s=<value>)", q.Name, q.Name))
+		}
+		vars.Answers[q.Name] = value
+	}
+
+	return nil
+}
+
+// promptQuestion asks a question until the answer is valid
+func promptQuestion(p *prompter, q templates.Question) (any, error) {
+	if q.IsBool() {
+		def, _ := q.DefaultValue()
+		return p.confirm(q.Label(), def == true)
+	}
+
+	label := q.Label()
+	if len(q.Choices) > 0 {
+		label = fmt.Sprintf("%s (%s)", label, strings.Join(q.Choices, "|"))
+	}
+
+	for {
+		answer, err := p.ask(label, q.Default)
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" {
+			ui.Warn("An answer is required")
+			continue
+		}
+		value, err := q.Parse(answer)
+		if err == nil {
+			return value, nil
+		}
+		ui.Warn(err.Error())
+	}
+}
+
+// zeroAnswer is the value of a question that was skipped by its condition
+func zeroAnswer(q templates.Question) any {
+	if q.IsBool() {
+		return false
+	}
+	return ""
+}
@@ -5,9 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
-	"maajise/internal/detect"
 	"maajise/internal/fsutil"
+	"maajise/internal/manifest"
 	"maajise/internal/ui"
 )
 
@@ -33,7 +34,9 @@ func (sc *StatusCommand) LongDescription() string {
 	return `Display quick status information about the current project.
 
 Shows the project name, path, Git initialization status, Beads (br) initialization status,
-detected template type, and presence of key configuration files.`
+template type, and presence of key configuration files. The template is read from
+.maajise.lock when present (otherwise detected), and generated files that were edited since
+they were written are listed.`
 }
 
 func (sc *StatusCommand) Usage() string {
@@ -50,11 +53,11 @@ func (sc *StatusCommand) Examples() string {
 
     ✓ Git:     initialized
     ✓ Beads:   initialized (br)
-    Template:  typescript`
+    Template:  typescript (.maajise.lock)`
 }
 
 func (sc *StatusCommand) Run(args []string) error {
-	if err := sc.fs.Parse(args); err != nil {
+	if err := ParseFlags(sc.fs, args); err != nil {
 		return err
 	}
 
@@ -85,9 +88,16 @@ func (sc *StatusCommand) Run(args []string) error {
 		ui.Warn("Beads:   not initialized (br)")
 	}
 
-	// Template detection
-	template := detect.Template(cwd)
-	fmt.Printf("Template: %s\n", template)
+	// Template (manifest first, then detection)
+	m := loadManifest(cwd)
+	template, source := projectTemplate(cwd, "", m)
+	fmt.Printf("Template: %s (%s)\n", template, source)
+	if m != nil && len(m.Features) > 0 {
+		fmt.Printf("Features: %s\n", strings.Join(m.Features, ", "))
+	}
+	if m != nil && m.Version != "" {
+		fmt.Printf("Generated by: maajise %s\n", m.Version)
+	}
 
 	// Key files
 	fmt.Println()
@@ -101,6 +111,28 @@ func (sc *StatusCommand) Run(args []string) error {
 		}
 	}
 
+	// Files recorded in the manifest that were changed since generation
+	if m != nil {
+		modified := []string{}
+		for _, path := range m.Paths() {
+			if m.Modified(cwd, path) {
+				modified = append(modified, path)
+			}
+		}
+		fmt.Println()
+		if len(modified) == 0 {
+			fmt.Printf("Generated files: %d (unchanged)\n", len(m.Files))
+		} else {
+			fmt.Printf("Generated files: %d (%d modified)\n", len(m.Files), len(modified))
+			for _, path := range modified {
+				fmt.Printf("  ~ %s\n", path)
+			}
+		}
+	} else {
+		fmt.Println()
+		fmt.Printf("No %s (template detected from marker files)\n", manifest.FileName)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"maajise/internal/manifest"
+	"maajise/internal/source"
+	"maajise/internal/ui"
+	"maajise/templates"
+)
+
+// TemplateCommand manages custom templates. Each subcommand has its own flags.
+type TemplateCommand struct {
+	captureFS   *flag.FlagSet
+	lintFS      *flag.FlagSet
+	name        string
+	description string
+	format      string
+	projectName string
+	output      string
+	force       bool
+}
+
+func NewTemplateCommand() *TemplateCommand {
+	tc := &TemplateCommand{
+		captureFS: flag.NewFlagSet("template capture", flag.ContinueOnError),
+		lintFS:    flag.NewFlagSet("template lint", flag.ContinueOnError),
+	}
+
+	tc.captureFS.StringVar(&tc.name, "name", "", "Name of the captured template (required)")
+	tc.captureFS.StringVar(&tc.description, "description", "", "Description of the captured template")
+	tc.captureFS.StringVar(&tc.format, "format", formatDir, "Template form to write: dir (template.yaml + files/) or yaml")
+	tc.captureFS.StringVar(&tc.projectName, "project-name", "", "Project name to replace with {{.ProjectName}} (default: from .maajise.lock, else the directory name)")
+	tc.captureFS.StringVar(&tc.output, "output", "", "Directory to write the template to (default: ~/.maajise/templates)")
+	tc.captureFS.BoolVar(&tc.force, "force", false, "Replace an existing template of the same name")
+
+	return tc
+}
+
+func (tc *TemplateCommand) Name() string {
+	return "template"
+}
+
+func (tc *TemplateCommand) Description() string {
+	return "Manage custom templates (capture, lint)"
+}
+
+func (tc *TemplateCommand) LongDescription() string {
+	return `Manage custom templates.
+
+'template capture <dir>' turns an existing project into a custom template. It walks the
+directory, skipping .git, .beads, maajise's own files and everything ignored by .gitignore
+files, and replaces the project name with {{.ProjectName}} in file contents and paths. The
+name is only replaced as a whole word, and a name taken from the directory or manifest that
+is shorter than 3 characters or common (app, api, web, ...) is not replaced unless given
+with --project-name. The template is written to ~/.maajise/templates, where init, add and
+update find it by name.
+
+The directory form (template.yaml + files/) is the default: files that don't mention the
+project name are copied verbatim with their modes, binary files included. The YAML form
+(--format=yaml) writes a single file, keeps modes with mode: and can't hold binary files.
+
+'template lint [path...]' checks custom templates before they are used: YAML errors and
+unknown keys (with line numbers), missing names, names that replace a built-in template,
+template syntax errors, absolute or ".." paths, and variables or answers the template
+doesn't declare. A path can be a YAML template, a directory template or a directory of
+templates; the default is ~/.maajise/templates. It fails if any error is found.`
+}
+
+func (tc *TemplateCommand) Usage() string {
+	return "maajise template capture <dir> --name <name> [flags]\n  maajise template lint [path...]"
+}
+
+func (tc *TemplateCommand) Examples() string {
+	return `  # Capture the current project as the "service" template
+  maajise template capture . --name service
+
+  # Capture as a single YAML file with a description
+  maajise template capture ~/src/api --name api --format=yaml --description="Our API layout"
+
+  # Set the project name to replace, and replace an earlier capture
+  maajise template capture ../billing --name svc --project-name=billing --force
+
+  # Write somewhere else, e.g. a shared template repository
+  maajise template capture . --name service --output=../templates
+
+  # Use the captured template
+  maajise init my-svc --template=service
+
+  # Check every template in ~/.maajise/templates
+  maajise template lint
+
+  # Check a template before sharing it
+  maajise template lint ./templates/service`
+}
+
+func (tc *TemplateCommand) Run(args []string) error {
+	if len(args) == 0 {
+		return ui.UsageError("template", "missing subcommand (capture, lint)")
+	}
+
+	switch args[0] {
+	case "capture":
+		return tc.runCapture(args[1:])
+	case "lint":
+		return tc.runLint(args[1:])
+	}
+	return ui.UsageError("template", fmt.Sprintf("unknown subcommand: %s (available: capture, lint)", args[0]))
+}
+
+func (tc *TemplateCommand) runLint(args []string) error {
+	paths, err := parseInterspersed(tc.lintFS, args)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		dir := templates.DefaultCustomTemplatesDir()
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			ui.Info(fmt.Sprintf("No custom templates in %s", dir))
+			return nil
+		}
+		paths = []string{dir}
+	}
+
+	var issues []templates.Issue
+	for _, path := range paths {
+		issues = append(issues, templates.Lint(path)...)
+	}
+
+	errors, warnings := 0, 0
+	for _, issue := range issues {
+		fmt.Println(issue)
+		if issue.Severity == templates.SeverityError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+
+	if errors > 0 {
+		return fmt.Errorf("%d errors, %d warnings", errors, warnings)
+	}
+	if warnings > 0 {
+		ui.Warn(fmt.Sprintf("%d warnings", warnings))
+	} else {
+		ui.Success("No problems found")
+	}
+	return nil
+}
+
+func (tc *TemplateCommand) runCapture(args []string) error {
+	positional, err := parseInterspersed(tc.captureFS, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return ui.UsageError("template", "capture takes exactly one directory")
+	}
+	if tc.name == "" {
+		return ui.UsageError("template", "--name is required")
+	}
+	if source.IsSource(tc.name) {
+		return ui.UsageError("template", fmt.Sprintf("invalid template name %q (no paths or URLs)", tc.name))
+	}
+	if tc.format != formatDir && tc.format != formatYAML {
+		return ui.UsageError("template", fmt.Sprintf("unknown format %q (use dir or yaml)", tc.format))
+	}
+
+	dir, err := filepath.Abs(positional[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", positional[0], err)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return ui.UsageError("template", fmt.Sprintf("%s is not a directory", positional[0]))
+	}
+
+	projectName := tc.projectName
+	if projectName == "" {
+		projectName = filepath.Base(dir)
+		if m := loadManifest(dir); m != nil && m.Vars.ProjectName != "" {
+			projectName = m.Vars.ProjectName
+		}
+		if !replaceableName(projectName) {
+			ui.Warn(fmt.Sprintf("Not replacing the project name %q: it is too short or common to replace safely (use --project-name=%s to replace it anyway)", projectName, projectName))
+			projectName = ""
+		}
+	}
+
+	output := tc.output
+	if output == "" {
+		output = templates.DefaultCustomTemplatesDir()
+	}
+	target := filepath.Join(output, tc.name)
+	if tc.format == formatYAML {
+		target += ".yaml"
+	}
+	if _, err := os.Stat(target); err == nil && !tc.force {
+		return ui.UsageError("template", fmt.Sprintf("%s already exists (use --force to replace it)", target))
+	}
+
+	captured, err := captureDir(dir, projectName)
+	if err != nil {
+		return err
+	}
+	if len(captured) == 0 {
+		return ui.UsageError("template", fmt.Sprintf("no files to capture in %s", dir))
+	}
+
+	description := tc.description
+	if description == "" {
+		description = fmt.Sprintf("Captured from %s", filepath.Base(dir))
+	}
+
+	if err := os.RemoveAll(target); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", target, err)
+	}
+	if tc.format == formatYAML {
+		err = writeYAMLTemplate(target, tc.name, description, captured)
+	} else {
+		err = writeDirTemplate(target, tc.name, description, captured)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Make sure what was written loads back
+	if tc.format == formatYAML {
+		_, err = templates.LoadFile(target)
+	} else {
+		_, err = templates.LoadDir(target)
+	}
+	if err != nil {
+		return fmt.Errorf("captured template does not load: %w", err)
+	}
+
+	replaced := 0
+	for _, f := range captured {
+		if f.rendered {
+			replaced++
+		}
+	}
+	ui.Success(fmt.Sprintf("Captured %d files from %s as template %s", len(captured), dir, tc.name))
+	if projectName != "" {
+		ui.Info(fmt.Sprintf("Replaced %q with {{.ProjectName}} in %d files", projectName, replaced))
+	}
+	ui.Info(fmt.Sprintf("Wrote %s", target))
+	fmt.Printf("\nUse with: maajise init <project> --template=%s\n", tc.name)
+	if manifest.Exists(dir) {
+		ui.Info(fmt.Sprintf("%s and .maajise/ were not captured", manifest.FileName))
+	}
+	return nil
+}
+
+// parseInterspersed parses flags that may come before, between or after
+// positional arguments, returning the positional ones
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := ParseFlags(fs, args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+func init() {
+	Register(NewTemplateCommand())
+}
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"maajise/templates"
+)
+
+func TestTemplateCommand_Registration(t *testing.T) {
+	c, ok := Get("template")
+	if !ok {
+		t.Fatal("template command not registered")
+	}
+	if _, ok := c.(*TemplateCommand); !ok {
+		t.Errorf("registered command is %T, want *TemplateCommand", c)
+	}
+}
+
+func TestTemplateCommand_UnknownSubcommand(t *testing.T) {
+	tc := NewTemplateCommand()
+	if err := tc.Run([]string{"explode"}); err == nil {
+		t.Error("Expected error for an unknown subcommand")
+	}
+	if err := tc.Run(nil); err == nil {
+		t.Error("Expected error without a subcommand")
+	}
+}
+
+// captureProject creates a project to capture in dir
+func captureProject(t *testing.T, dir string) {
+	files := map[string]string{
+		".gitignore":           "*.log\nbuild/\n",
+		"README.md":            "# billing\n\nUse {{ with care }}.\n",
+		"cmd/billing/main.go":  "package main\n",
+		"scripts/release.sh":   "#!/bin/sh\n",
+		"debug.log":            "ignored\n",
+		"build/out.bin":        "ignored\n",
+		".git/config":          "[core]\n",
+		".beads/issues.jsonl":  "{}\n",
+		"assets/logo.png":      "\x89PNG\x00billing",
+		"docs/layout.md.tmpl":  "{{.Title}}\n",
+		"docs/sub/.gitignore":  "*.tmp\n",
+		"docs/sub/scratch.tmp": "ignored\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.Chmod(filepath.Join(dir, "scripts", "release.sh"), 0755)
+}
+
+func TestTemplateCommand_CaptureDir(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-capture-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	project := filepath.Join(tmpDir, "billing")
+	captureProject(t, project)
+	output := filepath.Join(tmpDir, "templates")
+
+	tc := NewTemplateCommand()
+	if err := tc.Run([]string{"capture", project, "--name", "captured-svc", "--output", output}); err != nil {
+		t.Fatalf("capture error = %v", err)
+	}
+
+	tmpl, err := templates.LoadDir(filepath.Join(output, "captured-svc"))
+	if err != nil {
+		t.Fatalf("LoadDir() error = %v", err)
+	}
+
+	vars := templates.DefaultVars("payments")
+	files, err := templates.Render(tmpl, vars)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	want := map[string]string{
+		"README.md":            "# payments\n\nUse {{ with care }}.\n",
+		"cmd/payments/main.go": "package main\n",
+		"scripts/release.sh":   "#!/bin/sh\n",
+		"assets/logo.png":      "\x89PNG\x00billing",
+		"docs/layout.md.tmpl":  "{{.Title}}\n",
+		"docs/sub/.gitignore":  "*.tmp\n",
+	}
+	for name, content := range want {
+		if files[name] != content {
+			t.Errorf("files[%s] = %q, want %q", name, files[name], content)
+		}
+	}
+	for _, name := range []string{"debug.log", "build/out.bin", ".git/config", ".beads/issues.jsonl", "docs/sub/scratch.tmp"} {
+		if _, ok := files[name]; ok {
+			t.Errorf("%s should not be captured", name)
+		}
+	}
+	if !strings.Contains(files[".gitignore"], "*.log") {
+		t.Errorf(".gitignore = %q", files[".gitignore"])
+	}
+
+	if mode := templates.Modes(tmpl, vars)["scripts/release.sh"]; mode != 0755 {
+		t.Errorf("release.sh mode = %v, want 0755", mode)
+	}
+
+	// An existing template is only replaced with --force
+	tc = NewTemplateCommand()
+	if err := tc.Run([]string{"capture", "--name=captured-svc", "--output=" + output, project}); err == nil {
+		t.Error("capture should refuse to replace an existing template")
+	}
+	tc = NewTemplateCommand()
+	if err := tc.Run([]string{"capture", "--name=captured-svc", "--output=" + output, "--force", project}); err != nil {
+		t.Errorf("capture --force error = %v", err)
+	}
+}
+
+func TestTemplateCommand_CaptureYAML(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-capture-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	project := filepath.Join(tmpDir, "billing")
+	captureProject(t, project)
+	output := filepath.Join(tmpDir, "templates")
+
+	// Binary files need the directory form
+	tc := NewTemplateCommand()
+	if err := tc.Run([]string{"capture", project, "--name", "captured-yaml", "--format", "yaml", "--output", output}); err == nil {
+		t.Fatal("capture --format=yaml should fail on binary files")
+	}
+
+	os.Remove(filepath.Join(project, "assets", "logo.png"))
+	tc = NewTemplateCommand()
+	if err := tc.Run([]string{"capture", project, "--name", "captured-yaml", "--format", "yaml", "--output", output}); err != nil {
+		t.Fatalf("capture error = %v", err)
+	}
+
+	tmpl, err := templates.LoadFile(filepath.Join(output, "captured-yaml.yaml"))
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	files, err := templates.Render(tmpl, templates.DefaultVars("payments"))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if files["README.md"] != "# payments\n\nUse {{ with care }}.\n" {
+		t.Errorf("README.md = %q", files["README.md"])
+	}
+	if files["docs/layout.md.tmpl"] != "{{.Title}}\n" {
+		t.Errorf("docs/layout.md.tmpl = %q, want it unrendered", files["docs/layout.md.tmpl"])
+	}
+	if mode := templates.Modes(tmpl, templates.DefaultVars("payments"))["scripts/release.sh"]; mode != 0755 {
+		t.Errorf("mode of scripts/release.sh = %04o, want 0755", mode)
+	}
+}
+
+func TestCaptureFile_WholeWords(t *testing.T) {
+	content := "# billing\n\nbilling-api stores billingAddress, rebilling and billing_id.\n"
+	f := captureFile("cmd/billing/billing.go", []byte(content), 0644, namePattern("billing"))
+
+	if f.path != "cmd/{{.ProjectName}}/{{.ProjectName}}.go" {
+		t.Errorf("path = %q", f.path)
+	}
+	want := "# {{.ProjectName}}\n\n{{.ProjectName}}-api stores billingAddress, rebilling and billing_id.\n"
+	if string(f.content) != want || !f.rendered {
+		t.Errorf("content = %q, want %q", f.content, want)
+	}
+
+	f = captureFile("notes.md", []byte("rebilling\n"), 0644, namePattern("billing"))
+	if f.rendered {
+		t.Errorf("content = %q, want a name inside a word left alone", f.content)
+	}
+}
+
+func TestTemplateCommand_CaptureCommonName(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-capture-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	project := filepath.Join(tmpDir, "api")
+	os.MkdirAll(project, 0755)
+	os.WriteFile(filepath.Join(project, "README.md"), []byte("# api\n\nSee the api docs.\n"), 0644)
+	output := filepath.Join(tmpDir, "templates")
+
+	// A common name from the directory is left alone
+	tc := NewTemplateCommand()
+	if err := tc.Run([]string{"capture", project, "--name", "common", "--output", output}); err != nil {
+		t.Fatalf("capture error = %v", err)
+	}
+	tmpl, err := templates.LoadDir(filepath.Join(output, "common"))
+	if err != nil {
+		t.Fatalf("LoadDir() error = %v", err)
+	}
+	files, _ := templates.Render(tmpl, templates.DefaultVars("payments"))
+	if files["README.md"] != "# api\n\nSee the api docs.\n" {
+		t.Errorf("README.md = %q, want the common name kept", files["README.md"])
+	}
+
+	// --project-name replaces it anyway
+	tc = NewTemplateCommand()
+	if err := tc.Run([]string{"capture", project, "--name", "named", "--project-name", "api", "--output", output}); err != nil {
+		t.Fatalf("capture error = %v", err)
+	}
+	tmpl, err = templates.LoadDir(filepath.Join(output, "named"))
+	if err != nil {
+		t.Fatalf("LoadDir() error = %v", err)
+	}
+	files, _ = templates.Render(tmpl, templates.DefaultVars("payments"))
+	if files["README.md"] != "# payments\n\nSee the payments docs.\n" {
+		t.Errorf("README.md = %q, want --project-name replaced", files["README.md"])
+	}
+}
+
+func TestTemplateCommand_LintRejectsCaptureFlags(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-lint-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tc := NewTemplateCommand()
+	if err := tc.Run([]string{"lint", "--name", "svc", tmpDir}); err == nil {
+		t.Error("lint should not accept capture's flags")
+	}
+}
+
+func TestTemplateCommand_CaptureRequiresName(t *testing.T) {
+	tc := NewTemplateCommand()
+	if err := tc.Run([]string{"capture", "."}); err == nil {
+		t.Error("Expected error without --name")
+	}
+	tc = NewTemplateCommand()
+	if err := tc.Run([]string{"capture", ".", "--name", "a/b"}); err == nil {
+		t.Error("Expected error for a name that looks like a path")
+	}
+}
+
+func TestTemplateCommand_Lint(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-lint-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	good := filepath.Join(tmpDir, "good.yaml")
+	os.WriteFile(good, []byte("name: good\nfiles:\n  README.md: \"# {{.ProjectName}}\\n\"\n"), 0644)
+	warned := filepath.Join(tmpDir, "warned.yaml")
+	os.WriteFile(warned, []byte("name: warned\nfiles:\n  .env: \"PORT={{.port}}\\n\"\n"), 0644)
+	bad := filepath.Join(tmpDir, "bad.yaml")
+	os.WriteFile(bad, []byte("name: bad\nfiles:\n  ../escape.txt: x\n"), 0644)
+
+	if err := NewTemplateCommand().Run([]string{"lint", good}); err != nil {
+		t.Errorf("lint of a clean template error = %v", err)
+	}
+	if err := NewTemplateCommand().Run([]string{"lint", warned}); err != nil {
+		t.Errorf("lint with only warnings error = %v", err)
+	}
+	err = NewTemplateCommand().Run([]string{"lint", good, bad})
+	if err == nil || !strings.Contains(err.Error(), "1 errors") {
+		t.Errorf("lint of a broken template error = %v, want 1 errors", err)
+	}
+}
+
+func TestTemplateCommand_LintMissingPath(t *testing.T) {
+	if err := NewTemplateCommand().Run([]string{"lint", "/nonexistent/template.yaml"}); err == nil {
+		t.Error("Expected error for a missing template")
+	}
+}
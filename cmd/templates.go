@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 
+	"maajise/internal/ui"
 	"maajise/templates"
 )
 
@@ -48,7 +49,7 @@ func (tc *TemplatesCommand) Examples() string {
 }
 
 func (tc *TemplatesCommand) Run(args []string) error {
-	if err := tc.fs.Parse(args); err != nil {
+	if err := ParseFlags(tc.fs, args); err != nil {
 		return err
 	}
 
@@ -70,8 +71,24 @@ func (tc *TemplatesCommand) Run(args []string) error {
 	}
 
 	fmt.Println()
-	fmt.Println("Usage: maajise init <project-name> --template=<template>")
+	fmt.Println("Features (layered on any template):")
 	fmt.Println()
+	for _, f := range templates.AllFeatures() {
+		fmt.Printf("  %-12s  %s\n", f.Name(), f.Description())
+	}
+
+	fmt.Println()
+	fmt.Println("Usage: maajise init <project-name> --template=<template> [--with <feature>,...]")
+	fmt.Println()
+
+	if loadErrors := templates.LoadErrors(); len(loadErrors) > 0 {
+		ui.Warn(fmt.Sprintf("%d custom templates failed to load:", len(loadErrors)))
+		for _, e := range loadErrors {
+			fmt.Printf("  %v\n", e)
+		}
+		fmt.Println("Run 'maajise template lint' for details")
+		fmt.Println()
+	}
 
 	return nil
 }
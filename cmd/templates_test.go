@@ -3,10 +3,11 @@ package cmd
 import (
 	"bytes"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
-	_ "maajise/templates" // Import to register templates
+	"maajise/templates"
 )
 
 func TestTemplatesCommand(t *testing.T) {
@@ -47,3 +48,39 @@ func TestTemplatesCommand(t *testing.T) {
 		t.Error("output should contain header")
 	}
 }
+
+func TestTemplatesCommand_CustomReplacesBuiltin(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-templates-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	builtin, _ := templates.Get("zig")
+	defer templates.Register(builtin)
+
+	// zig registers late in the package, after a load from init would run
+	os.WriteFile(filepath.Join(tmpDir, "zig.yaml"), []byte("name: zig\ndescription: Team Zig layout\n"), 0644)
+	if err := templates.LoadCustomTemplates(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+	if tmpl, _ := templates.Get("zig"); tmpl.Description() != "Team Zig layout" {
+		t.Errorf("Get(zig) = %q, want the custom template", tmpl.Description())
+	}
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err = NewTemplatesCommand().Run([]string{})
+	w.Close()
+	os.Stdout = old
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	if !strings.Contains(buf.String(), "Team Zig layout") {
+		t.Errorf("output = %q, want the custom zig template listed", buf.String())
+	}
+}
@@ -5,20 +5,25 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
-	"maajise/internal/detect"
+	"maajise/internal/diff"
 	"maajise/internal/fsutil"
+	"maajise/internal/managed"
+	"maajise/internal/manifest"
 	"maajise/internal/ui"
 	"maajise/templates"
 )
 
 type UpdateCommand struct {
-	fs        *flag.FlagSet
-	force     bool
-	template  string
-	verbose   bool
-	dryRun    bool
-	filesOnly []string
+	fs            *flag.FlagSet
+	force         bool
+	template      string
+	verbose       bool
+	dryRun        bool
+	skipConflicts bool
+	diffOnly      bool
+	filesOnly     []string
 }
 
 func NewUpdateCommand() *UpdateCommand {
@@ -26,11 +31,13 @@ func NewUpdateCommand() *UpdateCommand {
 		fs: flag.NewFlagSet("update", flag.ContinueOnError),
 	}
 
-	uc.fs.BoolVar(&uc.force, "force", false, "Overwrite existing files (default: skip existing)")
-	uc.fs.StringVar(&uc.template, "template", "", "Template to use (auto-detects if not specified)")
+	uc.fs.BoolVar(&uc.force, "force", false, "Overwrite existing files instead of merging local changes")
+	uc.fs.BoolVar(&uc.skipConflicts, "skip-conflicts", false, "Leave files with merge conflicts untouched instead of writing conflict markers")
+	uc.fs.StringVar(&uc.template, "template", "", "Template to use (default: from .maajise.lock, else auto-detect)")
 	uc.fs.BoolVar(&uc.verbose, "v", false, "Verbose output")
 	uc.fs.BoolVar(&uc.verbose, "verbose", false, "Verbose output")
-	uc.fs.BoolVar(&uc.dryRun, "dry-run", false, "Show what would be updated without making changes")
+	uc.fs.BoolVar(&uc.dryRun, "dry-run", false, "Show what would be updated (as a diff) without making changes")
+	uc.fs.BoolVar(&uc.diffOnly, "diff-only", false, "Dry run that only summarizes changed line counts per file")
 
 	return uc
 }
@@ -48,7 +55,21 @@ func (uc *UpdateCommand) LongDescription() string {
 
 Updates files like .gitignore, .ubsignore, and README.md based on the project's template.
 If no specific files are provided, all standard configuration files are updated. Use --dry-run
-to preview changes before applying them.`
+to preview changes as a unified diff before applying them, or --diff-only for a summary of
+changed line counts per file.
+
+The template and variables are read from .maajise.lock when present; otherwise the template
+is auto-detected from marker files. The manifest is refreshed with the hashes of updated files.
+
+Files with maajise-managed blocks (.gitignore and .ubsignore, between "# >>> maajise:<name>"
+and "# <<< maajise:<name>") only have the content between the markers rewritten; lines you add
+outside the markers are kept, even with --force.
+
+Other existing files are three-way merged: the content maajise originally generated (stored under
+.maajise/baseline/), your current file, and the new template output. Hunks changed on only one
+side merge automatically; hunks changed on both sides are written with git-style conflict
+markers (or left untouched with --skip-conflicts). Files without a baseline are skipped unless
+--force is given, which overwrites local changes.`
 }
 
 func (uc *UpdateCommand) Usage() string {
@@ -59,30 +80,39 @@ func (uc *UpdateCommand) Examples() string {
 	return `  # Update all configuration files
   maajise update
 
-  # Force overwrite existing files
+  # Force overwrite existing files (discards local edits)
   maajise update --force
 
+  # Merge, but leave conflicting files untouched
+  maajise update --skip-conflicts
+
   # Update with specific template
   maajise update --template=typescript
 
   # Update specific files only
   maajise update .gitignore .ubsignore
 
-  # Preview changes without applying
+  # Preview changes as a colored unified diff without applying
   maajise update --dry-run
 
+  # Summarize changed line counts per file
+  maajise update --diff-only
+
   # Verbose output
   maajise update --verbose
       Shows detailed information about each file updated`
 }
 
 func (uc *UpdateCommand) Run(args []string) error {
-	if err := uc.fs.Parse(args); err != nil {
+	if err := ParseFlags(uc.fs, args); err != nil {
 		return err
 	}
 
 	// Get specific files to update (if any)
 	uc.filesOnly = uc.fs.Args()
+	if uc.diffOnly {
+		uc.dryRun = true
+	}
 
 	// Determine working directory
 	cwd, err := os.Getwd()
@@ -90,25 +120,40 @@ func (uc *UpdateCommand) Run(args []string) error {
 		return fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	// Get project name from directory
-	projectName := filepath.Base(cwd)
+	// Prefer the manifest over marker-file detection
+	m := loadManifest(cwd)
+	vars := projectVars(cwd, m)
 
-	// Detect or use specified template
-	templateName := uc.template
-	if templateName == "" {
-		templateName = detect.Template(cwd)
-		if uc.verbose {
-			ui.Info(fmt.Sprintf("Detected template: %s", templateName))
-		}
+	templateName, source := projectTemplate(cwd, uc.template, m)
+	if uc.verbose {
+		ui.Info(fmt.Sprintf("Template: %s (%s)", templateName, source))
 	}
 
-	tmpl, ok := templates.Get(templateName)
-	if !ok {
-		return ui.UsageError("update", fmt.Sprintf("unknown template: %s (available: base, typescript, python, rust, php, go, swift)", templateName))
+	// Features added with init --with or add feature are updated too
+	var features []string
+	if m != nil {
+		features = m.Features
+	}
+	tmpl, err := composeTemplate("update", templateName, features)
+	if err != nil {
+		return err
+	}
+
+	// Variables and questions added to the template since init take their defaults
+	if err := prepareVars("update", tmpl, &vars); err != nil {
+		return err
 	}
 
 	// Get files from template
-	files := tmpl.Files(projectName)
+	files, err := renderTemplate(tmpl, vars)
+	if err != nil {
+		return err
+	}
+	if err := projectFiles("update", cwd, m, files); err != nil {
+		return err
+	}
+
+	modes := templates.Modes(tmpl, vars)
 
 	// Filter to specific files if requested
 	if len(uc.filesOnly) > 0 {
@@ -127,65 +172,226 @@ func (uc *UpdateCommand) Run(args []string) error {
 		return ui.UsageError("update", "no files to update")
 	}
 
-	// Update files
+	if m == nil {
+		m = manifest.New(templateName, Version, vars)
+	}
+	m.Vars = vars
+	m.Template = templateName
+	m.Version = Version
+
+	// Update files in a stable order
+	names := make([]string, 0, len(files))
+	for filename := range files {
+		names = append(names, filename)
+	}
+	sort.Strings(names)
+
 	updated := 0
+	merged := 0
+	conflicted := 0
 	skipped := 0
-	for filename, content := range files {
-		path := filepath.Join(cwd, filename)
+	recorded := 0
+	totalAdded, totalRemoved := 0, 0
+	for _, filename := range names {
+		content := files[filename]
+		path, err := fsutil.SafeJoin(cwd, filename)
+		if err != nil {
+			return fmt.Errorf("template %s: %w", templateName, err)
+		}
 
-		// Check if file exists BEFORE writing
-		existed := fsutil.PathExists(path)
+		plan, err := uc.planFile(cwd, m, filename, content)
+		if err != nil {
+			return err
+		}
 
 		if uc.dryRun {
-			if existed {
-				if uc.force {
-					ui.Info(fmt.Sprintf("[dry-run] Would overwrite: %s", filename))
-				} else {
-					ui.Info(fmt.Sprintf("[dry-run] Would skip (exists): %s", filename))
-				}
-			} else {
-				ui.Info(fmt.Sprintf("[dry-run] Would create: %s", filename))
-			}
+			added, removed := uc.reportDryRun(filename, plan)
+			totalAdded += added
+			totalRemoved += removed
 			continue
 		}
 
-		if existed && !uc.force {
-			if uc.verbose {
-				ui.Warn(fmt.Sprintf("Skipped %s (exists, use --force to overwrite)", filename))
+		switch plan.action {
+		case actionSkip, actionSkipConflict:
+			if plan.action == actionSkipConflict {
+				ui.Warn(fmt.Sprintf("Skipped %s (%d merge conflicts, --skip-conflicts)", filename, plan.conflicts))
+				conflicted++
+			} else if uc.verbose {
+				ui.Warn(fmt.Sprintf("Skipped %s (exists, no baseline to merge with; use --force to overwrite)", filename))
 			}
 			skipped++
 			continue
-		}
-
-		// Create parent directories if needed
-		dir := filepath.Dir(path)
-		if dir != "." && dir != "" {
-			if err := os.MkdirAll(dir, 0755); err != nil {
-				return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		case actionUnchanged:
+			if uc.verbose {
+				ui.Info(fmt.Sprintf("Unchanged %s", filename))
+			}
+		default:
+			if err := writeGenerated(path, plan.content, templates.FileMode(modes, filename, plan.content)); err != nil {
+				return fmt.Errorf("failed to write %s: %w", filename, err)
 			}
 		}
 
-		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
-			return fmt.Errorf("failed to write %s: %w", filename, err)
+		if err := recordGenerated(cwd, m, filename, content); err != nil {
+			return err
 		}
+		recorded++
 
-		// Report based on whether file existed
-		if existed && uc.force {
-			ui.Success(fmt.Sprintf("Updated %s", filename))
-		} else {
+		switch plan.action {
+		case actionCreate:
 			ui.Success(fmt.Sprintf("Created %s", filename))
+			updated++
+		case actionOverwrite:
+			ui.Success(fmt.Sprintf("Updated %s", filename))
+			updated++
+		case actionMerge:
+			ui.Success(fmt.Sprintf("Merged %s (local changes kept)", filename))
+			merged++
+		case actionManaged:
+			ui.Success(fmt.Sprintf("Refreshed managed blocks in %s", filename))
+			updated++
+		case actionConflict:
+			ui.Error(fmt.Sprintf("Conflict in %s (%d hunks, resolve the markers)", filename, plan.conflicts))
+			conflicted++
 		}
-		updated++
+	}
+
+	if uc.diffOnly {
+		fmt.Println()
+		ui.Info(fmt.Sprintf("[dry-run] %d insertions(+), %d deletions(-)", totalAdded, totalRemoved))
 	}
 
 	if !uc.dryRun {
+		if recorded > 0 || !manifest.Exists(cwd) {
+			if err := m.Save(cwd); err != nil {
+				return fmt.Errorf("failed to write %s: %w", manifest.FileName, err)
+			}
+		}
+
 		fmt.Println()
-		ui.Info(fmt.Sprintf("Updated: %d, Skipped: %d", updated, skipped))
+		ui.Info(fmt.Sprintf("Updated: %d, Merged: %d, Conflicts: %d, Skipped: %d", updated, merged, conflicted, skipped))
+
+		if conflicted > 0 && !uc.skipConflicts {
+			return fmt.Errorf("%d file(s) have merge conflicts", conflicted)
+		}
 	}
 
 	return nil
 }
 
+// Actions update can take for a template file
+const (
+	actionCreate       = "create"
+	actionOverwrite    = "overwrite"
+	actionMerge        = "merge"
+	actionManaged      = "managed"
+	actionConflict     = "conflict"
+	actionSkipConflict = "skip-conflict"
+	actionUnchanged    = "unchanged"
+	actionSkip         = "skip"
+)
+
+// updatePlan describes what update will do with one file
+type updatePlan struct {
+	action    string
+	current   string // content on disk
+	content   string // content to write
+	conflicts int
+}
+
+// planFile decides how to update a file. Files containing managed blocks only
+// have those blocks refreshed. Other existing files are overwritten with
+// --force; otherwise the template output is three-way merged with the local
+// file, using the baseline m recorded when the file was last generated.
+func (uc *UpdateCommand) planFile(dir string, m *manifest.Manifest, filename, content string) (updatePlan, error) {
+	path := filepath.Join(dir, filename)
+	if !fsutil.PathExists(path) {
+		return updatePlan{action: actionCreate, content: content}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return updatePlan{}, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	plan := updatePlan{current: string(data)}
+	if plan.current == content {
+		plan.action, plan.content = actionUnchanged, content
+		return plan, nil
+	}
+
+	// Files with managed blocks only get the blocks refreshed, even with --force
+	if spliced, ok, err := managed.Splice(plan.current, content); err != nil {
+		ui.Warn(fmt.Sprintf("Ignoring managed blocks in %s: %v", filename, err))
+	} else if ok {
+		plan.action, plan.content = actionManaged, spliced
+		if spliced == plan.current {
+			plan.action = actionUnchanged
+		}
+		return plan, nil
+	}
+
+	base, hasBase := m.Baseline(dir, filename)
+	switch {
+	case uc.force:
+		plan.action, plan.content = actionOverwrite, content
+	case !hasBase:
+		plan.action = actionSkip
+	default:
+		result := diff.Merge3(base, plan.current, content)
+		plan.conflicts = result.Conflicts
+		switch {
+		case result.Conflicts > 0 && uc.skipConflicts:
+			plan.action = actionSkipConflict
+		case result.Conflicts > 0:
+			plan.action, plan.content = actionConflict, result.Content
+		case result.Content == plan.current:
+			// Template output hasn't changed since the baseline; keep local edits
+			plan.action, plan.content = actionUnchanged, plan.current
+		default:
+			plan.action, plan.content = actionMerge, result.Content
+		}
+	}
+
+	return plan, nil
+}
+
+// reportDryRun describes the planned action for a file and previews its diff,
+// returning the number of lines that would be added and removed
+func (uc *UpdateCommand) reportDryRun(filename string, plan updatePlan) (int, int) {
+	changes := plan.action == actionCreate || plan.action == actionOverwrite ||
+		plan.action == actionMerge || plan.action == actionManaged || plan.action == actionConflict
+
+	if uc.diffOnly {
+		if !changes {
+			return 0, 0
+		}
+		return previewChange(filename, plan.current, plan.content, plan.action != actionCreate, true)
+	}
+
+	switch plan.action {
+	case actionCreate:
+		ui.Info(fmt.Sprintf("[dry-run] Would create: %s", filename))
+	case actionOverwrite:
+		ui.Info(fmt.Sprintf("[dry-run] Would overwrite: %s", filename))
+	case actionMerge:
+		ui.Info(fmt.Sprintf("[dry-run] Would merge: %s", filename))
+	case actionManaged:
+		ui.Info(fmt.Sprintf("[dry-run] Would refresh managed blocks: %s", filename))
+	case actionConflict:
+		ui.Info(fmt.Sprintf("[dry-run] Would merge with %d conflicts: %s", plan.conflicts, filename))
+	case actionSkipConflict:
+		ui.Info(fmt.Sprintf("[dry-run] Would skip (%d conflicts): %s", plan.conflicts, filename))
+	case actionUnchanged:
+		ui.Info(fmt.Sprintf("[dry-run] Unchanged: %s", filename))
+	default:
+		ui.Info(fmt.Sprintf("[dry-run] Would skip (exists): %s", filename))
+	}
+
+	if !changes {
+		return 0, 0
+	}
+	return previewChange(filename, plan.current, plan.content, plan.action != actionCreate, false)
+}
+
 func init() {
 	Register(NewUpdateCommand())
 }
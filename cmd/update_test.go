@@ -3,10 +3,13 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"maajise/internal/fsutil"
-	_ "maajise/templates"
+	"maajise/internal/managed"
+	"maajise/internal/manifest"
+	"maajise/templates"
 )
 
 func TestUpdateCommand_Name(t *testing.T) {
@@ -174,6 +177,212 @@ func TestUpdateCommand_SpecificFiles(t *testing.T) {
 	}
 }
 
+func TestUpdateCommand_UsesManifest(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-update-manifest-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	// Manifest says python even though package.json would detect typescript
+	os.WriteFile("package.json", []byte("{}"), 0644)
+	m := manifest.New("python", "1.0.0", templates.DefaultVars("svc"))
+	if err := m.Save("."); err != nil {
+		t.Fatal(err)
+	}
+
+	uc := NewUpdateCommand()
+	if err := uc.Run([]string{"pyproject.toml"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	content, err := os.ReadFile("pyproject.toml")
+	if err != nil {
+		t.Fatal("pyproject.toml should be created from the manifest template")
+	}
+	if !contains(string(content), "svc") {
+		t.Error("pyproject.toml should use the project name recorded in the manifest")
+	}
+
+	loaded, err := manifest.Load(".")
+	if err != nil || loaded == nil {
+		t.Fatalf("manifest.Load() = %v, %v", loaded, err)
+	}
+	if loaded.Version != Version {
+		t.Errorf("manifest Version = %q, want %q", loaded.Version, Version)
+	}
+	if loaded.Modified(".", "pyproject.toml") {
+		t.Error("manifest should record the hash of pyproject.toml")
+	}
+}
+
+// saveBaseline records content as what base last generated for path in the
+// current directory, as an earlier init or update would have
+func saveBaseline(t *testing.T, path, content string) {
+	t.Helper()
+	m, _ := manifest.Load(".")
+	if m == nil {
+		cwd, _ := os.Getwd()
+		m = manifest.New("base", Version, templates.DefaultVars(filepath.Base(cwd)))
+	}
+	if err := recordGenerated(".", m, path, content); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Save("."); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateCommand_ThreeWayMerge(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-update-merge-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	tmpl, _ := templates.Get("base")
+	generated := tmpl.Files(filepath.Base(tmpDir))[".gitignore"]
+
+	// Pretend an older template generated the file without the "# OS" section,
+	// and the user has since appended a line of their own
+	lines := strings.SplitAfter(generated, "\n")
+	var older []string
+	for _, line := range lines {
+		if line != "Thumbs.db\n" {
+			older = append(older, line)
+		}
+	}
+	base := strings.Join(older, "")
+	if base == generated {
+		t.Fatal("test setup: base template .gitignore should contain Thumbs.db")
+	}
+	saveBaseline(t, ".gitignore", base)
+	os.WriteFile(".gitignore", []byte(base+"my-local-dir/\n"), 0644)
+
+	uc := NewUpdateCommand()
+	if err := uc.Run([]string{".gitignore"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(".gitignore")
+	if !strings.Contains(string(content), "Thumbs.db") {
+		t.Error("merge should pick up the new template line")
+	}
+	if !strings.Contains(string(content), "my-local-dir/") {
+		t.Error("merge should keep the local line")
+	}
+
+	// The baseline now tracks the latest template output
+	m, _ := manifest.Load(".")
+	if got, _ := m.Baseline(".", ".gitignore"); got != generated {
+		t.Error("baseline should be updated to the new template output")
+	}
+}
+
+func TestUpdateCommand_MergeConflict(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-update-conflict-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	saveBaseline(t, "README.md", "# Old title\n")
+	os.WriteFile("README.md", []byte("# My title\n"), 0644)
+
+	// --skip-conflicts leaves the file alone
+	uc := NewUpdateCommand()
+	if err := uc.Run([]string{"--skip-conflicts", "README.md"}); err != nil {
+		t.Errorf("Run(--skip-conflicts) error = %v", err)
+	}
+	content, _ := os.ReadFile("README.md")
+	if string(content) != "# My title\n" {
+		t.Error("--skip-conflicts should not modify the file")
+	}
+
+	// Default writes conflict markers and reports an error
+	uc = NewUpdateCommand()
+	if err := uc.Run([]string{"README.md"}); err == nil {
+		t.Error("Run() should report merge conflicts")
+	}
+	content, _ = os.ReadFile("README.md")
+	if !strings.Contains(string(content), "<<<<<<< local\n# My title\n=======") {
+		t.Errorf("README.md should contain conflict markers, got:\n%s", content)
+	}
+}
+
+func TestUpdateCommand_DiffOnly(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-update-diffonly-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	os.WriteFile(".gitignore", []byte("local\n"), 0644)
+
+	// --diff-only implies a dry run, even with --force
+	uc := NewUpdateCommand()
+	if err := uc.Run([]string{"--diff-only", "--force"}); err != nil {
+		t.Errorf("Run(--diff-only) error = %v", err)
+	}
+
+	content, _ := os.ReadFile(".gitignore")
+	if string(content) != "local\n" {
+		t.Error("--diff-only should not modify files")
+	}
+	if fsutil.FileExists("README.md") || manifest.Exists(".") {
+		t.Error("--diff-only should not create files")
+	}
+}
+
+func TestUpdateCommand_ManagedBlocks(t *testing.T) {
+	uc := NewUpdateCommand()
+
+	tmpDir, err := os.MkdirTemp("", "maajise-update-managed-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	oldDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(oldDir)
+
+	// A stale managed block surrounded by the user's own entries, without a baseline
+	existing := "secrets/\n" + managed.Wrap("base", "stale-entry/\n") + "\n*.local\n"
+	os.WriteFile(".gitignore", []byte(existing), 0644)
+
+	if err := uc.Run([]string{"--force", ".gitignore"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(".gitignore")
+	got := string(content)
+	for _, want := range []string{"secrets/\n", "*.local\n", "node_modules/"} {
+		if !strings.Contains(got, want) {
+			t.Errorf(".gitignore missing %q after update:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "stale-entry/") {
+		t.Errorf("managed block was not refreshed:\n%s", got)
+	}
+}
+
 func TestUpdateCommand_Registration(t *testing.T) {
 	// Verify the command is registered
 	cmd, ok := Get("update")
@@ -6,8 +6,9 @@ import (
 	"os"
 	"path/filepath"
 
-	"maajise/internal/detect"
 	"maajise/internal/fsutil"
+	"maajise/internal/manifest"
+	"maajise/internal/source"
 	"maajise/internal/ui"
 	"maajise/templates"
 )
@@ -48,7 +49,8 @@ func (vc *ValidateCommand) LongDescription() string {
 	return `Validate the current project's setup and configuration.
 
 Checks for Git initialization, Beads setup, required configuration files, and
-template-specific requirements. Reports any issues and warnings found.`
+template-specific requirements. The template is read from .maajise.lock when present,
+otherwise it is detected from marker files. Reports any issues and warnings found.`
 }
 
 func (vc *ValidateCommand) Usage() string {
@@ -67,7 +69,7 @@ func (vc *ValidateCommand) Examples() string {
 }
 
 func (vc *ValidateCommand) Run(args []string) error {
-	if err := vc.fs.Parse(args); err != nil {
+	if err := ParseFlags(vc.fs, args); err != nil {
 		return err
 	}
 
@@ -91,12 +93,18 @@ func (vc *ValidateCommand) Run(args []string) error {
 	// Check required files
 	results = append(results, vc.checkRequiredFiles(cwd)...)
 
-	// Detect and validate template-specific files
-	template := detect.Template(cwd)
+	// Validate template-specific files (manifest first, then detection)
+	m := loadManifest(cwd)
+	template, source := projectTemplate(cwd, "", m)
 	if vc.verbose {
-		ui.Info(fmt.Sprintf("Detected template: %s", template))
+		ui.Info(fmt.Sprintf("Template: %s (%s)", template, source))
 	}
-	results = append(results, vc.checkTemplateFiles(cwd, template)...)
+	var features []string
+	if m != nil {
+		features = m.Features
+	}
+	results = append(results, vc.checkTemplateFiles(cwd, template, features, projectVars(cwd, m))...)
+	results = append(results, vc.checkManifest(cwd, m)...)
 
 	// Display results
 	passes := 0
@@ -169,16 +177,31 @@ func (vc *ValidateCommand) checkRequiredFiles(dir string) []ValidationResult {
 	return results
 }
 
-func (vc *ValidateCommand) checkTemplateFiles(dir, template string) []ValidationResult {
+func (vc *ValidateCommand) checkTemplateFiles(dir, template string, features []string, vars templates.TemplateVars) []ValidationResult {
 	results := []ValidationResult{}
 
-	tmpl, ok := templates.Get(template)
-	if !ok {
+	tmpl, err := composeTemplate("validate", template, features)
+	if err != nil {
+		// Nothing to compare against for an unknown name, but a broken
+		// template or a source that can't be fetched is a failure
+		if _, ok := templates.Get(template); ok || source.IsSource(template) {
+			results = append(results, ValidationResult{"template", "fail", err.Error()})
+		}
+		return results
+	}
+
+	// Variables and questions added since the project was generated take their defaults
+	if err := prepareVars("validate", tmpl, &vars); err != nil {
+		results = append(results, ValidationResult{"template", "fail", err.Error()})
 		return results
 	}
 
 	// Get expected files from template
-	files := tmpl.Files(filepath.Base(dir))
+	files, err := templates.Render(tmpl, vars)
+	if err != nil {
+		results = append(results, ValidationResult{"template", "fail", err.Error()})
+		return results
+	}
 
 	for filename := range files {
 		// Skip common files already checked
@@ -198,6 +221,29 @@ func (vc *ValidateCommand) checkTemplateFiles(dir, template string) []Validation
 	return results
 }
 
+func (vc *ValidateCommand) checkManifest(dir string, m *manifest.Manifest) []ValidationResult {
+	results := []ValidationResult{}
+
+	if m == nil {
+		if vc.verbose {
+			results = append(results, ValidationResult{manifest.FileName, "warn", "Missing (template was detected from marker files)"})
+		}
+		return results
+	}
+
+	results = append(results, ValidationResult{manifest.FileName, "pass", fmt.Sprintf("Template %s (maajise %s)", m.Template, m.Version)})
+
+	if vc.verbose {
+		for _, path := range m.Paths() {
+			if m.Modified(dir, path) && fsutil.FileExists(filepath.Join(dir, path)) {
+				results = append(results, ValidationResult{path, "pass", "Modified since generation"})
+			}
+		}
+	}
+
+	return results
+}
+
 func init() {
 	Register(NewValidateCommand())
 }
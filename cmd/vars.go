@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"maajise/internal/config"
+	"maajise/internal/ui"
+	"maajise/templates"
+)
+
+// varList collects repeated --var key=value flags
+type varList []string
+
+func (v *varList) String() string {
+	return strings.Join(*v, ",")
+}
+
+func (v *varList) Set(value string) error {
+	if _, _, err := templates.ParseVar(value); err != nil {
+		return err
+	}
+	*v = append(*v, value)
+	return nil
+}
+
+// applyConfigVars copies the variables from ~/.maajiserc over vars, skipping empty values
+func applyConfigVars(vars *templates.TemplateVars, fc *config.FileConfig) {
+	if fc == nil {
+		return
+	}
+
+	fields := map[string]string{
+		"author":  fc.Variables.Author,
+		"email":   fc.Variables.Email,
+		"year":    fc.Variables.Year,
+		"license": fc.Variables.License,
+		"github":  fc.Variables.GitHub,
+	}
+	for key, value := range fc.Variables.Extra {
+		fields[key] = value
+	}
+
+	for key, value := range fields {
+		if value == "" {
+			continue
+		}
+		if err := vars.Set(key, value); err != nil {
+			ui.Warn(fmt.Sprintf("Ignoring variable in %s: %v", config.ConfigPath(), err))
+		}
+	}
+}
+
+// applyVarFlags sets the --var assignments on vars, which win over the config file
+func applyVarFlags(vars *templates.TemplateVars, assignments []string) error {
+	for _, assignment := range assignments {
+		key, value, err := templates.ParseVar(assignment)
+		if err != nil {
+			return err
+		}
+		if err := vars.Set(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prepareVars completes the variables recorded for an existing project with
+// what the template gained since: default variables and question answers
+func prepareVars(cmdName string, tmpl templates.Template, vars *templates.TemplateVars) error {
+	if err := templates.SetDefaultVariables(vars, tmpl); err != nil {
+		return fmt.Errorf("template %s: %w", tmpl.Name(), err)
+	}
+	return askQuestions(cmdName, nil, templates.QuestionsOf(tmpl), vars)
+}
+
+// renderTemplate renders a template's paths and contents with vars, adding
+// a LICENSE file for the project's license
+func renderTemplate(tmpl templates.Template, vars templates.TemplateVars) (map[string]string, error) {
+	files, err := templates.Render(tmpl, vars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render template %s: %w", tmpl.Name(), err)
+	}
+	if err := templates.AddLicense(files, vars); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// parseLicense checks a license given on the command line. "none" means no
+// license: no LICENSE file, and manifests say the project is unlicensed.
+func parseLicense(cmdName, name string) (string, error) {
+	if strings.EqualFold(name, "none") {
+		return "", nil
+	}
+	id, ok := templates.LicenseID(name)
+	if !ok {
+		return "", ui.UsageError(cmdName, fmt.Sprintf("unknown license: %s (available: %s, none; use --var license=<spdx> for others)", name, strings.Join(templates.Licenses(), ", ")))
+	}
+	return id, nil
+}
+
+// licenseLabel returns a license for display, "none" when unset
+func licenseLabel(license string) string {
+	if license == "" {
+		return "none"
+	}
+	return license
+}
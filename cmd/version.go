@@ -46,7 +46,7 @@ func (vc *VersionCommand) FlagSet() *flag.FlagSet {
 }
 
 func (vc *VersionCommand) Run(args []string) error {
-	if err := vc.fs.Parse(args); err != nil {
+	if err := ParseFlags(vc.fs, args); err != nil {
 		return err
 	}
 
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"fmt"
+	"go/version"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"maajise/internal/managed"
+	"maajise/internal/manifest"
+	"maajise/templates"
+)
+
+// ignoreFiles are the root files that gain a scoped block per sub-project
+var ignoreFiles = []string{".gitignore", ".ubsignore"}
+
+// projectFiles adjusts the rendered files of a project for its place in a
+// workspace. A workspace root gains each sub-project's ignore rules and the
+// language workspace manifests; a sub-project drops the files the root
+// generates for it.
+func projectFiles(cmdName, dir string, m *manifest.Manifest, files map[string]string) error {
+	if m == nil {
+		return nil
+	}
+	if m.Workspace != "" {
+		for _, filename := range templates.WorkspaceOwned {
+			delete(files, filename)
+		}
+		return nil
+	}
+	if m.Template != templates.WorkspaceName {
+		return nil
+	}
+
+	// Project rules are scoped against the root's own rules only, then
+	// appended to them as one managed block per project
+	roots := make(map[string]string)
+	for _, filename := range ignoreFiles {
+		if content, ok := files[filename]; ok {
+			roots[filename] = content
+		}
+	}
+
+	bases := make(map[string]string)
+	for i := range m.Projects {
+		p := &m.Projects[i]
+		if err := refreshProject(cmdName, dir, m, p, roots); err != nil {
+			return fmt.Errorf("project %s: %w", p.Name, err)
+		}
+		bases[p.Name] = p.Base
+
+		for filename := range roots {
+			if scoped := p.Ignores[filename]; scoped != "" {
+				files[filename] += "\n" + managed.Wrap(templates.ProjectBlock(p.Name), scoped)
+			}
+		}
+	}
+
+	maps.Copy(files, templates.WorkspaceManifests(m.Vars.ProjectName, m.Projects, bases, membersGoVersion(dir, m.Projects, bases)))
+	return nil
+}
+
+// membersGoVersion returns the highest go directive in the go.mod files of
+// the Go projects, "" if none has one
+func membersGoVersion(root string, projects []templates.Project, bases map[string]string) string {
+	highest := ""
+	for _, p := range projects {
+		if bases[p.Name] != "go" {
+			continue
+		}
+		v := goDirective(filepath.Join(root, filepath.FromSlash(p.Dir()), "go.mod"))
+		if v != "" && (highest == "" || version.Compare("go"+v, "go"+highest) > 0) {
+			highest = v
+		}
+	}
+	return highest
+}
+
+// goDirective returns the version of the go directive in a go.mod file
+func goDirective(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "go" && version.IsValid("go"+fields[1]) {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// refreshProject records a sub-project's base template and scoped ignore
+// rules in p. They are only rendered again when the project's manifest or
+// the root's own rules changed since the root last rendered them, so updating
+// the root doesn't look up, or fetch, the template of every project.
+func refreshProject(cmdName, root string, m *manifest.Manifest, p *templates.Project, roots map[string]string) error {
+	digest := manifest.Digest(filepath.Join(root, filepath.FromSlash(p.Dir())))
+	if digest != "" {
+		key := digest
+		for _, filename := range ignoreFiles {
+			key += "\x00" + roots[filename]
+		}
+		digest = manifest.Hash(key)
+	}
+	if digest != "" && digest == p.Digest {
+		return nil
+	}
+
+	sub, base, err := renderProject(cmdName, root, m, *p)
+	if err != nil {
+		return err
+	}
+	ignores := make(map[string]string)
+	for filename, content := range roots {
+		if scoped := templates.ScopeIgnore(sub[filename], p.Dir(), content); scoped != "" {
+			ignores[filename] = scoped
+		}
+	}
+	p.Base, p.Ignores, p.Digest = base, ignores, digest
+	return nil
+}
+
+// renderProject renders a workspace sub-project with the variables and
+// features recorded in its own manifest, and returns the built-in template
+// it is based on
+func renderProject(cmdName, root string, m *manifest.Manifest, p templates.Project) (map[string]string, string, error) {
+	vars := workspaceVars(m, p.Name)
+	var features []string
+	if sub := loadManifest(filepath.Join(root, filepath.FromSlash(p.Dir()))); sub != nil {
+		vars = sub.Vars
+		features = sub.Features
+	}
+
+	tmpl, err := lookupTemplate(cmdName, p.Template)
+	if err != nil {
+		return nil, "", err
+	}
+	composed, err := composeTemplate(cmdName, p.Template, features)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := prepareVars(cmdName, composed, &vars); err != nil {
+		return nil, "", err
+	}
+	files, err := renderTemplate(composed, vars)
+	return files, templates.BaseOf(tmpl), err
+}
+
+// workspaceVars returns the variables for a new sub-project: the
+// workspace's author, license and custom variables under the project's name
+func workspaceVars(m *manifest.Manifest, name string) templates.TemplateVars {
+	vars := m.Vars
+	vars.ProjectName = name
+	vars.Extra = maps.Clone(m.Vars.Extra)
+	vars.Answers = nil
+	return vars
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"maajise/internal/managed"
+	"maajise/internal/manifest"
+	"maajise/templates"
+)
+
+func TestWorkspace_Projects(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed, skipping test")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "maajise-workspace-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	t.Setenv("HOME", filepath.Join(tmpDir, "home"))
+
+	// worker comes from a git source that follows its default branch, so
+	// every lookup fetches the repository again
+	repo := filepath.Join(tmpDir, "worker-template")
+	os.MkdirAll(repo, 0755)
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "--quiet")
+	os.WriteFile(filepath.Join(repo, "template.yaml"), []byte(`name: worker
+extends: go
+files:
+  .gitignore: |
+    /queue/
+`), 0644)
+	git("add", ".")
+	git("commit", "--quiet", "-m", "worker")
+	ref := "git+file://" + repo
+	defer delete(sourceTemplates, ref)
+
+	root := filepath.Join(tmpDir, "platform")
+	os.MkdirAll(root, 0755)
+	ac := NewAddCommand()
+	ac.vars = templates.DefaultVars("platform")
+	ac.template = templates.WorkspaceName
+	for _, filename := range []string{".gitignore", "README.md"} {
+		if err := ac.addFile(root, "platform", filename); err != nil {
+			t.Fatalf("addFile(%s) error = %v", filename, err)
+		}
+	}
+	ac.template = ""
+	ac.skipHooks = true
+	if err := ac.addProject(root, []string{"api", "--template=go"}); err != nil {
+		t.Fatalf("addProject(api) error = %v", err)
+	}
+	if err := ac.addProject(root, []string{"worker", "--template=" + ref}); err != nil {
+		t.Fatalf("addProject(worker) error = %v", err)
+	}
+
+	check := func(when string) {
+		t.Helper()
+		gitignore, _ := os.ReadFile(filepath.Join(root, ".gitignore"))
+		for _, want := range []string{managed.Begin("project-api"), managed.Begin("project-worker"), "apps/worker/queue/"} {
+			if !strings.Contains(string(gitignore), want) {
+				t.Errorf("%s: .gitignore = %q, want %q", when, gitignore, want)
+			}
+		}
+		if strings.Count(string(gitignore), managed.Begin("project-api")) != 1 {
+			t.Errorf("%s: .gitignore = %q, want one project-api block", when, gitignore)
+		}
+		gowork, _ := os.ReadFile(filepath.Join(root, "go.work"))
+		for _, want := range []string{"./apps/api", "./apps/worker"} {
+			if !strings.Contains(string(gowork), want) {
+				t.Errorf("%s: go.work = %q, want %s in use", when, gowork, want)
+			}
+		}
+	}
+	check("add project")
+
+	m, _ := manifest.Load(root)
+	if m == nil || len(m.Projects) != 2 || m.Projects[1].Base != "go" || m.Projects[1].Digest == "" {
+		t.Errorf("workspace manifest = %+v, want worker recorded with base go", m)
+	}
+
+	// Nothing changed in either project's manifest, so updating the root
+	// reuses what it recorded instead of fetching the worker template, which
+	// is now gone. go.work follows the highest go directive of its members.
+	os.WriteFile(filepath.Join(root, "apps", "worker", "go.mod"), []byte("module worker\n\ngo 1.24.1\n"), 0644)
+	os.RemoveAll(repo)
+	delete(sourceTemplates, ref)
+	oldDir, _ := os.Getwd()
+	os.Chdir(root)
+	defer os.Chdir(oldDir)
+	if err := NewUpdateCommand().Run([]string{}); err != nil {
+		t.Fatalf("update error = %v, want the recorded projects reused", err)
+	}
+	check("update")
+	if gowork, _ := os.ReadFile(filepath.Join(root, "go.work")); !strings.HasPrefix(string(gowork), "go 1.24.1\n") {
+		t.Errorf("go.work = %q, want go 1.24.1 from apps/worker", gowork)
+	}
+}
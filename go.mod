@@ -2,4 +2,9 @@ module maajise
 
 go 1.23
 
-require gopkg.in/yaml.v3 v3.0.1 // indirect
+require (
+	golang.org/x/term v0.25.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/sys v0.26.0 // indirect
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Answers holds a value for every prompt of "maajise init", so a project can
+// be bootstrapped without reading stdin. Pointer fields distinguish "not
+// answered" from an explicit false.
+type Answers struct {
+	ProjectName string `yaml:"project_name"`
+	Template    string `yaml:"template"`
+	Git         *bool  `yaml:"git"`
+	GitName     string `yaml:"git_name"`
+	GitEmail    string `yaml:"git_email"`
+	Branch      string `yaml:"branch"`
+	Remote      string `yaml:"remote"`
+	Beads       *bool  `yaml:"beads"`
+
+	// Vars sets template variables and answers template questions by name
+	Vars map[string]string `yaml:"vars"`
+}
+
+// LoadAnswers reads an answers file. Unknown keys are rejected so typos
+// don't silently fall back to defaults.
+func LoadAnswers(path string) (*Answers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read answers file: %w", err)
+	}
+
+	answers := &Answers{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(answers); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid answers file %s: %w", path, err)
+	}
+
+	return answers, nil
+}
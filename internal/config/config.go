@@ -1,5 +1,8 @@
 package config
 
+// DefaultMainBranch is the initial branch used when none is configured
+const DefaultMainBranch = "main"
+
 // Config holds initialization configuration for project setup
 type Config struct {
 	ProjectName   string
@@ -7,6 +10,7 @@ type Config struct {
 	NoOverwrite   bool
 	SkipGit       bool
 	SkipBeads     bool
+	SkipHooks     bool
 	SkipCommit    bool
 	SkipRemote    bool
 	SkipGitUser   bool
@@ -25,6 +29,7 @@ func DefaultConfig() Config {
 		NoOverwrite:   false,
 		SkipGit:       false,
 		SkipBeads:     false,
+		SkipHooks:     false,
 		SkipCommit:    false,
 		SkipRemote:    false,
 		SkipGitUser:   false,
@@ -32,7 +37,7 @@ func DefaultConfig() Config {
 		GitEmail:      "",
 		Verbose:       false,
 		Template:      "",
-		MainBranch:    "main",
+		MainBranch:    DefaultMainBranch,
 		DefaultRemote: "",
 	}
 }
@@ -26,6 +26,9 @@ type FileConfig struct {
 		Year    string `yaml:"year"`
 		License string `yaml:"license"`
 		GitHub  string `yaml:"github"`
+
+		// Any other key is a user-defined template variable
+		Extra map[string]string `yaml:",inline"`
 	} `yaml:"variables"`
 
 	// Custom template directory
@@ -95,7 +98,7 @@ func ConfigExists() bool {
 func DefaultFileConfig() *FileConfig {
 	cfg := &FileConfig{}
 	cfg.Defaults.Template = "base"
-	cfg.Defaults.MainBranch = "main"
+	cfg.Defaults.MainBranch = DefaultMainBranch
 	cfg.Variables.Year = "2025"
 	cfg.Variables.License = "MIT"
 	return cfg
@@ -112,7 +115,8 @@ func (c *Config) MergeFileConfig(fc *FileConfig) {
 	if c.GitEmail == "" && fc.Defaults.GitEmail != "" {
 		c.GitEmail = fc.Defaults.GitEmail
 	}
-	if c.MainBranch == "" && fc.Defaults.MainBranch != "" {
+	// MainBranch starts out as the built-in default rather than empty
+	if (c.MainBranch == "" || c.MainBranch == DefaultMainBranch) && fc.Defaults.MainBranch != "" {
 		c.MainBranch = fc.Defaults.MainBranch
 	}
 	// Bool flags: only apply if explicitly set in file AND not overridden by CLI
@@ -85,6 +85,26 @@ func TestMergeFileConfig(t *testing.T) {
 	}
 }
 
+func TestMergeFileConfig_MainBranch(t *testing.T) {
+	fc := &FileConfig{}
+	fc.Defaults.MainBranch = "trunk"
+
+	// The built-in default is replaced by the file value
+	c := DefaultConfig()
+	c.MergeFileConfig(fc)
+	if c.MainBranch != "trunk" {
+		t.Errorf("MainBranch after merge = %q, want %q", c.MainBranch, "trunk")
+	}
+
+	// An explicitly chosen branch is kept
+	c = DefaultConfig()
+	c.MainBranch = "develop"
+	c.MergeFileConfig(fc)
+	if c.MainBranch != "develop" {
+		t.Errorf("MainBranch after merge = %q, want %q", c.MainBranch, "develop")
+	}
+}
+
 func TestDefaultFileConfig(t *testing.T) {
 	cfg := DefaultFileConfig()
 	if cfg.Defaults.Template != "base" {
@@ -100,3 +120,74 @@ func TestDefaultFileConfig(t *testing.T) {
 		t.Errorf("Default License = %q, want %q", cfg.Variables.License, "MIT")
 	}
 }
+
+func TestLoadAnswers(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-answers-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "answers.yaml")
+	data := []byte(`project_name: my-app
+template: go
+git_name: CI Bot
+git_email: ci@example.com
+remote: git@github.com:me/my-app.git
+beads: false
+`)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	answers, err := LoadAnswers(path)
+	if err != nil {
+		t.Fatalf("LoadAnswers() error = %v", err)
+	}
+	if answers.ProjectName != "my-app" || answers.Template != "go" {
+		t.Errorf("LoadAnswers() = %+v", answers)
+	}
+	if answers.Beads == nil || *answers.Beads {
+		t.Errorf("Beads = %v, want explicit false", answers.Beads)
+	}
+	if answers.Git != nil {
+		t.Errorf("Git = %v, want nil when not answered", *answers.Git)
+	}
+}
+
+func TestLoadAnswers_UnknownKey(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-answers-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "answers.yaml")
+	os.WriteFile(path, []byte("projct_name: typo\n"), 0644)
+
+	if _, err := LoadAnswers(path); err == nil {
+		t.Error("LoadAnswers() should reject unknown keys")
+	}
+}
+
+func TestFileConfig_ExtraVariables(t *testing.T) {
+	data := []byte(`variables:
+  author: Test Author
+  team: platform
+  company: Acme
+`)
+	cfg := &FileConfig{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		t.Fatalf("yaml.Unmarshal error = %v", err)
+	}
+
+	if cfg.Variables.Author != "Test Author" {
+		t.Errorf("Author = %q, want %q", cfg.Variables.Author, "Test Author")
+	}
+	if cfg.Variables.Extra["team"] != "platform" || cfg.Variables.Extra["company"] != "Acme" {
+		t.Errorf("Extra = %v, want team and company", cfg.Variables.Extra)
+	}
+	if _, ok := cfg.Variables.Extra["author"]; ok {
+		t.Error("known keys should not be duplicated in Extra")
+	}
+}
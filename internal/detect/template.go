@@ -1,14 +1,17 @@
 package detect
 
 import (
+	"os"
 	"path/filepath"
 
 	"maajise/internal/fsutil"
 )
 
 // Template detects the project template based on marker files found in the directory.
-// It checks for language-specific files in a specific order and returns the corresponding
-// template name. If no markers are found, it returns "base".
+// It checks for language-specific files in a specific order and returns the template of
+// the first one found, refined by the project's sources where one build tool serves two
+// templates (java or kotlin, cpp or c). Without a marker file, a *.sln or *.csproj file
+// marks a dotnet project; otherwise it returns "base".
 func Template(dir string) string {
 	checks := []struct {
 		file     string
@@ -22,13 +25,55 @@ func Template(dir string) string {
 		{"requirements.txt", "python"},
 		{"composer.json", "php"},
 		{"go.mod", "go"},
+		{"build.gradle.kts", "java"},
+		{"build.gradle", "java"},
+		{"pom.xml", "java"},
+		{"CMakeLists.txt", "cpp"},
+		{"meson.build", "cpp"},
+		{"Gemfile", "ruby"},
+		{"mix.exs", "elixir"},
+		{"build.zig", "zig"},
 	}
 
 	for _, check := range checks {
 		if fsutil.FileExists(filepath.Join(dir, check.file)) {
+			// Gradle and Maven build both; Kotlin sources tell them apart
+			if check.template == "java" && fsutil.DirExists(filepath.Join(dir, "src", "main", "kotlin")) {
+				return "kotlin"
+			}
+			if check.template == "cpp" && cOnly(dir) {
+				return "c"
+			}
 			return check.template
 		}
 	}
 
+	// Solution and project files are named after the project
+	for _, pattern := range []string{"*.sln", "*.csproj"} {
+		if matches, _ := filepath.Glob(filepath.Join(dir, pattern)); len(matches) > 0 {
+			return "dotnet"
+		}
+	}
+
 	return "base"
 }
+
+// cOnly reports whether the C and C++ sources in dir and dir/src are all C
+func cOnly(dir string) bool {
+	hasC := false
+	for _, d := range []string{dir, filepath.Join(dir, "src")} {
+		entries, err := os.ReadDir(d)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			switch filepath.Ext(entry.Name()) {
+			case ".c":
+				hasC = true
+			case ".cc", ".cpp", ".cxx", ".c++":
+				return false
+			}
+		}
+	}
+	return hasC
+}
@@ -10,6 +10,7 @@ func TestTemplate(t *testing.T) {
 	tests := []struct {
 		name        string
 		markers     []string // Files to create
+		content     string   // Content of the marker files
 		expected    string
 		description string
 	}{
@@ -67,6 +68,84 @@ func TestTemplate(t *testing.T) {
 			expected:    "go",
 			description: "go.mod should trigger go detection",
 		},
+		{
+			name:        "build.gradle.kts detects java",
+			markers:     []string{"build.gradle.kts"},
+			expected:    "java",
+			description: "build.gradle.kts should trigger java detection",
+		},
+		{
+			name:        "build.gradle detects java",
+			markers:     []string{"build.gradle"},
+			expected:    "java",
+			description: "build.gradle should trigger java detection",
+		},
+		{
+			name:        "pom.xml detects java",
+			markers:     []string{"pom.xml"},
+			expected:    "java",
+			description: "pom.xml should trigger java detection",
+		},
+		{
+			name:        "Kotlin sources detect kotlin",
+			markers:     []string{"build.gradle.kts", "src/main/kotlin/Main.kt"},
+			expected:    "kotlin",
+			description: "Gradle projects with src/main/kotlin should be detected as kotlin",
+		},
+		{
+			name:        "CMakeLists.txt detects cpp",
+			markers:     []string{"CMakeLists.txt", "src/main.cpp"},
+			expected:    "cpp",
+			description: "CMakeLists.txt should trigger cpp detection",
+		},
+		{
+			name:        "meson.build detects cpp",
+			markers:     []string{"meson.build"},
+			expected:    "cpp",
+			description: "meson.build should trigger cpp detection",
+		},
+		{
+			name:        "C sources detect c",
+			markers:     []string{"CMakeLists.txt", "src/main.c", "include/app.h"},
+			expected:    "c",
+			description: "CMake projects with only C sources should be detected as c",
+		},
+		{
+			name:        "mixed C and C++ sources detect cpp",
+			markers:     []string{"meson.build", "src/main.cpp", "src/legacy.c"},
+			expected:    "cpp",
+			description: "C++ sources should win over C sources",
+		},
+		{
+			name:        "solution file detects dotnet",
+			markers:     []string{"BillingApi.sln"},
+			expected:    "dotnet",
+			description: "*.sln should trigger dotnet detection",
+		},
+		{
+			name:        "project file detects dotnet",
+			markers:     []string{"Tool.csproj"},
+			expected:    "dotnet",
+			description: "*.csproj should trigger dotnet detection",
+		},
+		{
+			name:        "Gemfile detects ruby",
+			markers:     []string{"Gemfile"},
+			expected:    "ruby",
+			description: "Gemfile should trigger ruby detection",
+		},
+		{
+			name:        "mix.exs detects elixir",
+			markers:     []string{"mix.exs"},
+			expected:    "elixir",
+			description: "mix.exs should trigger elixir detection",
+		},
+		{
+			name:        "build.zig detects zig",
+			markers:     []string{"build.zig"},
+			expected:    "zig",
+			description: "build.zig should trigger zig detection",
+		},
 		{
 			name:        "priority: package.json before tsconfig",
 			markers:     []string{"package.json", "tsconfig.json"},
@@ -85,6 +164,28 @@ func TestTemplate(t *testing.T) {
 			expected:    "python",
 			description: "pyproject.toml should be checked before requirements.txt",
 		},
+		// Formerly covered by AddCommand.detectTemplate, with real marker content
+		{
+			name:        "package.json with content detects typescript",
+			markers:     []string{"package.json"},
+			content:     "{}",
+			expected:    "typescript",
+			description: "A non-empty package.json should trigger typescript detection",
+		},
+		{
+			name:        "Package.swift with content detects swift",
+			markers:     []string{"Package.swift"},
+			content:     "// swift package",
+			expected:    "swift",
+			description: "A non-empty Package.swift should trigger swift detection",
+		},
+		{
+			name:        "Cargo.toml with content detects rust",
+			markers:     []string{"Cargo.toml"},
+			content:     "[package]",
+			expected:    "rust",
+			description: "A non-empty Cargo.toml should trigger rust detection",
+		},
 	}
 
 	for _, tt := range tests {
@@ -99,7 +200,10 @@ func TestTemplate(t *testing.T) {
 			// Create marker files
 			for _, marker := range tt.markers {
 				filePath := filepath.Join(tmpDir, marker)
-				if err := os.WriteFile(filePath, []byte(""), 0644); err != nil {
+				if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+					t.Fatalf("Failed to create directory for %s: %v", marker, err)
+				}
+				if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
 					t.Fatalf("Failed to create marker file %s: %v", marker, err)
 				}
 			}
@@ -0,0 +1,78 @@
+package diff
+
+import "strings"
+
+// SplitLines splits s into lines, keeping each line's trailing newline so that
+// joining the result reproduces s exactly
+func SplitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Match computes a longest common subsequence of a and b. The result has one
+// entry per line of a: the index of the matching line in b, or -1 if the line
+// was deleted. Matched indexes are strictly increasing.
+func Match(a, b []string) []int {
+	match := make([]int, len(a))
+	for i := range match {
+		match[i] = -1
+	}
+
+	// Common prefix and suffix don't need the quadratic table
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		match[prefix] = prefix
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		match[len(a)-1-suffix] = len(b) - 1 - suffix
+		suffix++
+	}
+
+	am := a[prefix : len(a)-suffix]
+	bm := b[prefix : len(b)-suffix]
+	n, m := len(am), len(bm)
+	if n == 0 || m == 0 {
+		return match
+	}
+
+	// lcs[i][j] is the LCS length of am[i:] and bm[j:]
+	lcs := make([][]int32, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if am[i] == bm[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case am[i] == bm[j]:
+			match[prefix+i] = prefix + j
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return match
+}
@@ -0,0 +1,162 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitLines(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", nil},
+		{"a", []string{"a"}},
+		{"a\n", []string{"a\n"}},
+		{"a\nb", []string{"a\n", "b"}},
+		{"a\n\nb\n", []string{"a\n", "\n", "b\n"}},
+	}
+
+	for _, tt := range tests {
+		got := SplitLines(tt.input)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") || len(got) != len(tt.want) {
+			t.Errorf("SplitLines(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+		if strings.Join(got, "") != tt.input {
+			t.Errorf("SplitLines(%q) does not round-trip", tt.input)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	a := []string{"a", "b", "c", "d"}
+	b := []string{"a", "x", "c", "d", "e"}
+
+	got := Match(a, b)
+	want := []int{0, -1, 2, 3}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Match() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMatch_Increasing(t *testing.T) {
+	a := SplitLines("x\ny\nz\nx\ny\n")
+	b := SplitLines("y\nx\ny\nz\n")
+
+	last := -1
+	for i, m := range Match(a, b) {
+		if m == -1 {
+			continue
+		}
+		if m <= last {
+			t.Fatalf("Match() index %d = %d is not increasing", i, m)
+		}
+		if a[i] != b[m] {
+			t.Errorf("Match() paired %q with %q", a[i], b[m])
+		}
+		last = m
+	}
+}
+
+func TestMerge3_Clean(t *testing.T) {
+	base := "node_modules/\ndist/\n.env\n"
+	ours := "node_modules/\ndist/\n.env\nmy-local-dir/\n"
+	theirs := "node_modules/\nbuild/\ndist/\n.env\n"
+
+	result := Merge3(base, ours, theirs)
+	if result.Conflicts != 0 {
+		t.Fatalf("Merge3() conflicts = %d, want 0:\n%s", result.Conflicts, result.Content)
+	}
+
+	want := "node_modules/\nbuild/\ndist/\n.env\nmy-local-dir/\n"
+	if result.Content != want {
+		t.Errorf("Merge3() = %q, want %q", result.Content, want)
+	}
+}
+
+func TestMerge3_OneSideUnchanged(t *testing.T) {
+	base := "a\nb\n"
+	theirs := "a\nb\nc\n"
+
+	if got := Merge3(base, base, theirs); got.Content != theirs || got.Conflicts != 0 {
+		t.Errorf("Merge3() with unchanged ours = %+v, want theirs", got)
+	}
+	if got := Merge3(base, theirs, base); got.Content != theirs || got.Conflicts != 0 {
+		t.Errorf("Merge3() with unchanged theirs = %+v, want ours", got)
+	}
+}
+
+func TestMerge3_SameChange(t *testing.T) {
+	base := "a\nb\nc\n"
+	both := "a\nB\nc\n"
+
+	result := Merge3(base, both, both)
+	if result.Conflicts != 0 || result.Content != both {
+		t.Errorf("Merge3() identical changes = %+v, want %q", result, both)
+	}
+}
+
+func TestMerge3_Conflict(t *testing.T) {
+	base := "# Title\n\nDescription\n"
+	ours := "# Title\n\nMy description\n"
+	theirs := "# Title\n\nTemplate description\n"
+
+	result := Merge3(base, ours, theirs)
+	if result.Conflicts != 1 {
+		t.Fatalf("Merge3() conflicts = %d, want 1", result.Conflicts)
+	}
+
+	want := "# Title\n\n" +
+		MarkerOurs + "\nMy description\n" +
+		MarkerSep + "\nTemplate description\n" +
+		MarkerTheirs + "\n"
+	if result.Content != want {
+		t.Errorf("Merge3() =\n%s\nwant\n%s", result.Content, want)
+	}
+}
+
+func TestMerge3_ConflictWithoutTrailingNewline(t *testing.T) {
+	result := Merge3("a", "b", "c")
+	if result.Conflicts != 1 {
+		t.Fatalf("Merge3() conflicts = %d, want 1", result.Conflicts)
+	}
+	if !strings.Contains(result.Content, "b\n"+MarkerSep+"\nc\n"+MarkerTheirs) {
+		t.Errorf("markers should start on their own line, got %q", result.Content)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	before := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	after := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\n"
+
+	got := Unified("a/x", "b/x", before, after, 2)
+	want := "--- a/x\n+++ b/x\n" +
+		"@@ -1,4 +1,4 @@\n a\n-b\n+B\n c\n d\n" +
+		"@@ -9,2 +9,3 @@\n i\n j\n+k\n"
+	if got != want {
+		t.Errorf("Unified() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUnified_Identical(t *testing.T) {
+	if got := Unified("a", "b", "same\n", "same\n", DefaultContext); got != "" {
+		t.Errorf("Unified() of identical content = %q, want empty", got)
+	}
+}
+
+func TestUnified_NewFile(t *testing.T) {
+	got := Unified(DevNull, "b/x", "", "one\ntwo", DefaultContext)
+	want := "--- /dev/null\n+++ b/x\n@@ -0,0 +1,2 @@\n+one\n+two\n\\ No newline at end of file\n"
+	if got != want {
+		t.Errorf("Unified() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestStat(t *testing.T) {
+	added, removed := Stat("a\nb\nc\n", "a\nc\nd\ne\n")
+	if added != 2 || removed != 1 {
+		t.Errorf("Stat() = +%d -%d, want +2 -1", added, removed)
+	}
+}
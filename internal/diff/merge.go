@@ -0,0 +1,117 @@
+package diff
+
+import "strings"
+
+// Conflict markers written around hunks that changed on both sides
+const (
+	MarkerOurs   = "<<<<<<< local"
+	MarkerSep    = "======="
+	MarkerTheirs = ">>>>>>> template"
+)
+
+// MergeResult is the outcome of a three-way merge
+type MergeResult struct {
+	Content   string
+	Conflicts int
+}
+
+// Merge3 merges the changes made from base to ours with the changes made from
+// base to theirs. Hunks changed on only one side (or identically on both) are
+// merged cleanly; hunks changed differently on both sides are written with
+// git-style conflict markers and counted in Conflicts.
+func Merge3(base, ours, theirs string) MergeResult {
+	b := SplitLines(base)
+	o := SplitLines(ours)
+	t := SplitLines(theirs)
+
+	mo := Match(b, o)
+	mt := Match(b, t)
+
+	var out strings.Builder
+	conflicts := 0
+
+	i, a, c := 0, 0, 0
+	for {
+		// Find the next base line that is unchanged on both sides
+		j := i
+		for j < len(b) && (mo[j] < a || mt[j] < c) {
+			j++
+		}
+
+		// Resolve the unstable chunk before it
+		oe, te := len(o), len(t)
+		if j < len(b) {
+			oe, te = mo[j], mt[j]
+		}
+		if resolved, ok := resolve(b[i:j], o[a:oe], t[c:te]); ok {
+			writeLines(&out, resolved)
+		} else {
+			conflicts++
+			writeConflict(&out, o[a:oe], t[c:te])
+		}
+
+		if j >= len(b) {
+			break
+		}
+
+		// Copy the stable run
+		i, a, c = j, oe, te
+		for i < len(b) && mo[i] == a && mt[i] == c {
+			out.WriteString(b[i])
+			i++
+			a++
+			c++
+		}
+	}
+
+	return MergeResult{Content: out.String(), Conflicts: conflicts}
+}
+
+// resolve picks the side of a chunk that changed, reporting false on a conflict
+func resolve(base, ours, theirs []string) ([]string, bool) {
+	switch {
+	case equal(ours, base):
+		return theirs, true
+	case equal(theirs, base):
+		return ours, true
+	case equal(ours, theirs):
+		return ours, true
+	default:
+		return nil, false
+	}
+}
+
+func writeConflict(out *strings.Builder, ours, theirs []string) {
+	out.WriteString(MarkerOurs + "\n")
+	writeTerminated(out, ours)
+	out.WriteString(MarkerSep + "\n")
+	writeTerminated(out, theirs)
+	out.WriteString(MarkerTheirs + "\n")
+}
+
+func writeLines(out *strings.Builder, lines []string) {
+	for _, line := range lines {
+		out.WriteString(line)
+	}
+}
+
+// writeTerminated writes lines, making sure the last one ends with a newline
+// so a following marker starts on its own line
+func writeTerminated(out *strings.Builder, lines []string) {
+	writeLines(out, lines)
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		out.WriteString("\n")
+	}
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines shown around each hunk
+const DefaultContext = 3
+
+// DevNull is used as the file name for the missing side of a created or deleted file
+const DevNull = "/dev/null"
+
+type lineOp struct {
+	kind byte // ' ', '-', '+'
+	text string
+	a, b int // line numbers (0-based) in old and new
+}
+
+// edits builds a line-by-line edit script turning a into b
+func edits(a, b []string) []lineOp {
+	match := Match(a, b)
+	ops := make([]lineOp, 0, len(a)+len(b))
+
+	j := 0
+	for i, m := range match {
+		if m == -1 {
+			ops = append(ops, lineOp{'-', a[i], i, j})
+			continue
+		}
+		for ; j < m; j++ {
+			ops = append(ops, lineOp{'+', b[j], i, j})
+		}
+		ops = append(ops, lineOp{' ', a[i], i, j})
+		j++
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, lineOp{'+', b[j], len(a), j})
+	}
+
+	return ops
+}
+
+// Stat returns the number of lines added and removed going from before to after
+func Stat(before, after string) (added, removed int) {
+	for _, op := range edits(SplitLines(before), SplitLines(after)) {
+		switch op.kind {
+		case '+':
+			added++
+		case '-':
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// Unified renders a unified diff from before to after with the given number of
+// context lines. It returns "" when the contents are identical.
+func Unified(oldName, newName, before, after string, context int) string {
+	a := SplitLines(before)
+	b := SplitLines(after)
+	ops := edits(a, b)
+
+	var out strings.Builder
+	for start := 0; start < len(ops); {
+		// Skip to the next change
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		// Extend the hunk while changes are within 2*context lines of each other
+		end := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				end = i + 1
+			} else if i-end >= 2*context {
+				break
+			}
+		}
+
+		lo := max(start-context, 0)
+		hi := min(end+context, len(ops))
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+		}
+		writeHunk(&out, ops[lo:hi])
+		start = hi
+	}
+
+	return out.String()
+}
+
+func writeHunk(out *strings.Builder, ops []lineOp) {
+	aStart, bStart := ops[0].a, ops[0].b
+	aCount, bCount := 0, 0
+	for _, op := range ops {
+		if op.kind != '+' {
+			aCount++
+		}
+		if op.kind != '-' {
+			bCount++
+		}
+	}
+
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(aStart, aCount), hunkRange(bStart, bCount))
+	for _, op := range ops {
+		out.WriteByte(op.kind)
+		out.WriteString(op.text)
+		if !strings.HasSuffix(op.text, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats a hunk range the way diff -u does: 1-based start, and an
+// empty range is reported as starting on the line before it
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
@@ -1,8 +1,11 @@
 package fsutil
 
 import (
+	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 )
 
 // FileExists returns true if the path exists and is a regular file (not a directory).
@@ -47,3 +50,54 @@ func EnsureParentDir(path string) error {
 	}
 	return EnsureDir(parent)
 }
+
+// UnsafePathError reports a relative path that would be written outside its root
+type UnsafePathError struct {
+	Path   string
+	Reason string
+}
+
+func (e *UnsafePathError) Error() string {
+	return fmt.Sprintf("unsafe path %q: %s", e.Path, e.Reason)
+}
+
+// CheckRelPath returns an *UnsafePathError unless rel, a slash-separated path,
+// is relative and stays inside its root
+func CheckRelPath(rel string) error {
+	switch {
+	case strings.TrimSpace(rel) == "":
+		return &UnsafePathError{Path: rel, Reason: "is empty"}
+	case path.IsAbs(filepath.ToSlash(rel)) || filepath.IsAbs(rel) || filepath.VolumeName(rel) != "":
+		return &UnsafePathError{Path: rel, Reason: "is absolute"}
+	}
+	clean := path.Clean(filepath.ToSlash(rel))
+	if clean == ".." || strings.HasPrefix(clean, "../") {
+		return &UnsafePathError{Path: rel, Reason: "escapes the project directory"}
+	}
+	return nil
+}
+
+// SafeJoin joins rel onto root for writing. Besides the checks of
+// CheckRelPath, it rejects paths where the file itself or any parent
+// directory inside root is a symlink, since writing would follow it anywhere.
+func SafeJoin(root, rel string) (string, error) {
+	if err := CheckRelPath(rel); err != nil {
+		return "", err
+	}
+
+	parts := strings.Split(path.Clean(filepath.ToSlash(rel)), "/")
+	for i := 1; i <= len(parts); i++ {
+		parent := path.Join(parts[:i]...)
+		info, err := os.Lstat(filepath.Join(root, filepath.FromSlash(parent)))
+		if os.IsNotExist(err) {
+			break // Created as a real directory when written
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return "", &UnsafePathError{Path: rel, Reason: parent + " is a symlink"}
+		}
+	}
+	return filepath.Join(root, filepath.FromSlash(rel)), nil
+}
@@ -272,3 +272,66 @@ func TestPathExists_Generic(t *testing.T) {
 		t.Error("PathExists should return false for non-existent paths")
 	}
 }
+
+func TestCheckRelPath(t *testing.T) {
+	tests := []struct {
+		path string
+		safe bool
+	}{
+		{"README.md", true},
+		{"cmd/app/main.go", true},
+		{"./docs/../README.md", true},
+		{"a..b", true},
+		{"", false},
+		{"/etc/passwd", false},
+		{"..", false},
+		{"../.bashrc", false},
+		{"docs/../../x", false},
+	}
+
+	for _, tt := range tests {
+		err := CheckRelPath(tt.path)
+		if (err == nil) != tt.safe {
+			t.Errorf("CheckRelPath(%q) = %v, want safe = %v", tt.path, err, tt.safe)
+		}
+		if err != nil {
+			if _, ok := err.(*UnsafePathError); !ok {
+				t.Errorf("CheckRelPath(%q) error is %T, want *UnsafePathError", tt.path, err)
+			}
+		}
+	}
+}
+
+func TestSafeJoin(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "fsutil-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	root := filepath.Join(tmpDir, "project")
+	outside := filepath.Join(tmpDir, "outside")
+	os.MkdirAll(filepath.Join(root, "docs"), 0755)
+	os.MkdirAll(outside, 0755)
+	if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	got, err := SafeJoin(root, "docs/new/guide.md")
+	if err != nil {
+		t.Fatalf("SafeJoin() error = %v", err)
+	}
+	if want := filepath.Join(root, "docs", "new", "guide.md"); got != want {
+		t.Errorf("SafeJoin() = %q, want %q", got, want)
+	}
+
+	// A symlinked file would be written through, e.g. README.md -> ~/.bashrc
+	os.WriteFile(filepath.Join(outside, "bashrc"), nil, 0644)
+	os.Symlink(filepath.Join(outside, "bashrc"), filepath.Join(root, "README.md"))
+
+	for _, rel := range []string{"link/x.txt", "link/deeper/x.txt", "../outside/x.txt", "/tmp/x", "link", "README.md"} {
+		if _, err := SafeJoin(root, rel); err == nil {
+			t.Errorf("SafeJoin(%q) should fail", rel)
+		}
+	}
+}
@@ -5,13 +5,16 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"maajise/internal/ui"
 )
 
-// Init initializes a Git repository
-func Init(repoDir string, verbose bool) error {
+// Init initializes a Git repository. If branch is not empty it becomes the
+// initial branch; git versions without "init --initial-branch" (before 2.28)
+// get HEAD pointed at the branch after a plain init instead.
+func Init(repoDir string, branch string, verbose bool) error {
 	// Check if already a git repo
 	gitDir := filepath.Join(repoDir, ".git")
 	if _, err := os.Stat(gitDir); err == nil {
@@ -21,7 +24,17 @@ func Init(repoDir string, verbose bool) error {
 
 	ui.Info("Initializing Git...")
 
-	cmd := exec.Command("git", "init")
+	args := []string{"init"}
+	setHead := false
+	if branch != "" {
+		if SupportsInitialBranch() {
+			args = append(args, "--initial-branch="+branch)
+		} else {
+			setHead = true
+		}
+	}
+
+	cmd := exec.Command("git", args...)
 	cmd.Dir = repoDir
 	if verbose {
 		cmd.Stdout = os.Stdout
@@ -33,10 +46,68 @@ func Init(repoDir string, verbose bool) error {
 		return err
 	}
 
+	if setHead {
+		head := exec.Command("git", "symbolic-ref", "HEAD", "refs/heads/"+branch)
+		head.Dir = repoDir
+		if out, err := head.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to set initial branch %s: %s", branch, strings.TrimSpace(string(out)))
+		}
+	}
+
 	ui.Success("Git initialized")
 	return nil
 }
 
+// SupportsInitialBranch reports whether the installed git accepts
+// "git init --initial-branch" (added in git 2.28)
+func SupportsInitialBranch() bool {
+	output, err := exec.Command("git", "version").Output()
+	if err != nil {
+		return false
+	}
+	major, minor, ok := parseVersion(string(output))
+	if !ok {
+		return false
+	}
+	return major > 2 || (major == 2 && minor >= 28)
+}
+
+// parseVersion extracts the major and minor version from "git version" output,
+// e.g. "git version 2.39.3 (Apple Git-146)"
+func parseVersion(output string) (int, int, bool) {
+	fields := strings.Fields(output)
+	if len(fields) < 3 || fields[0] != "git" || fields[1] != "version" {
+		return 0, 0, false
+	}
+
+	parts := strings.SplitN(fields[2], ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+// CurrentBranch returns the branch HEAD points to, even before the first commit
+func CurrentBranch(repoDir string) (string, error) {
+	cmd := exec.Command("git", "symbolic-ref", "--short", "HEAD")
+	cmd.Dir = repoDir
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read current branch: %w", err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
 // SetConfig sets a git configuration value in a specific repository
 func SetConfig(repoDir string, key string, value string, verbose bool) error {
 	cmd := exec.Command("git", "config", key, value)
@@ -66,6 +137,19 @@ func GetConfig(repoDir string, key string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// GetGlobalConfig retrieves a git configuration value from the user's
+// global config, ignoring any repository the working directory is in
+func GetGlobalConfig(key string) (string, error) {
+	cmd := exec.Command("git", "config", "--global", key)
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get global git config %s: %w", key, err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
 // HasChanges checks if there are any uncommitted changes
 func HasChanges(repoDir string) (bool, error) {
 	cmd := exec.Command("git", "status", "--porcelain")
@@ -100,6 +184,28 @@ func AddFiles(repoDir string, files []string, verbose bool) error {
 	return nil
 }
 
+// SetExecutable marks staged files executable in the index, so the commit
+// keeps the bit even where core.fileMode is false
+func SetExecutable(repoDir string, files []string, verbose bool) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	args := append([]string{"update-index", "--chmod=+x", "--"}, files...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoDir
+	if verbose {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to mark files executable: %w", err)
+	}
+
+	return nil
+}
+
 // CreateCommit creates a commit with the given message
 func CreateCommit(repoDir string, message string, verbose bool) error {
 	cmd := exec.Command("git", "commit", "-m", message)
@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -55,7 +56,7 @@ func TestInit(t *testing.T) {
 	tmpDir := createTempDir(t)
 	defer cleanup(t, tmpDir)
 
-	err := Init(tmpDir, false)
+	err := Init(tmpDir, "", false)
 	if err != nil {
 		t.Errorf("Init() failed: %v", err)
 	}
@@ -73,24 +74,64 @@ func TestInit_AlreadyInitialized(t *testing.T) {
 	defer cleanup(t, tmpDir)
 
 	// Initialize first time
-	if err := Init(tmpDir, false); err != nil {
+	if err := Init(tmpDir, "", false); err != nil {
 		t.Fatalf("Initial Init() failed: %v", err)
 	}
 
 	// Initialize again - should not error
-	err := Init(tmpDir, false)
+	err := Init(tmpDir, "", false)
 	if err != nil {
 		t.Errorf("Init() on existing repo should not error: %v", err)
 	}
 }
 
+func TestInit_Branch(t *testing.T) {
+	gitAvailable(t)
+	tmpDir := createTempDir(t)
+	defer cleanup(t, tmpDir)
+
+	if err := Init(tmpDir, "trunk", false); err != nil {
+		t.Fatalf("Init() failed: %v", err)
+	}
+
+	branch, err := CurrentBranch(tmpDir)
+	if err != nil {
+		t.Fatalf("CurrentBranch() failed: %v", err)
+	}
+	if branch != "trunk" {
+		t.Errorf("CurrentBranch() = %q, want %q", branch, "trunk")
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		output       string
+		major, minor int
+		ok           bool
+	}{
+		{"git version 2.39.3\n", 2, 39, true},
+		{"git version 2.39.3 (Apple Git-146)", 2, 39, true},
+		{"git version 2.45.1.windows.1", 2, 45, true},
+		{"git version 1.8.3.1", 1, 8, true},
+		{"not git", 0, 0, false},
+		{"git version x.y", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		major, minor, ok := parseVersion(tt.output)
+		if major != tt.major || minor != tt.minor || ok != tt.ok {
+			t.Errorf("parseVersion(%q) = %d, %d, %v, want %d, %d, %v", tt.output, major, minor, ok, tt.major, tt.minor, tt.ok)
+		}
+	}
+}
+
 func TestSetConfig(t *testing.T) {
 	gitAvailable(t)
 	tmpDir := createTempDir(t)
 	defer cleanup(t, tmpDir)
 
 	// Initialize repo first
-	if err := Init(tmpDir, false); err != nil {
+	if err := Init(tmpDir, "", false); err != nil {
 		t.Fatalf("Init() failed: %v", err)
 	}
 
@@ -116,7 +157,7 @@ func TestGetConfig(t *testing.T) {
 	defer cleanup(t, tmpDir)
 
 	// Initialize repo first
-	if err := Init(tmpDir, false); err != nil {
+	if err := Init(tmpDir, "", false); err != nil {
 		t.Fatalf("Init() failed: %v", err)
 	}
 
@@ -140,7 +181,7 @@ func TestGetConfig_NotFound(t *testing.T) {
 	defer cleanup(t, tmpDir)
 
 	// Initialize repo first
-	if err := Init(tmpDir, false); err != nil {
+	if err := Init(tmpDir, "", false); err != nil {
 		t.Fatalf("Init() failed: %v", err)
 	}
 
@@ -151,6 +192,28 @@ func TestGetConfig_NotFound(t *testing.T) {
 	}
 }
 
+func TestGetGlobalConfig(t *testing.T) {
+	gitAvailable(t)
+	tmpDir := createTempDir(t)
+	defer cleanup(t, tmpDir)
+
+	global := filepath.Join(tmpDir, "gitconfig")
+	os.WriteFile(global, []byte("[user]\n\tname = Global User\n"), 0644)
+	t.Setenv("GIT_CONFIG_GLOBAL", global)
+
+	result, err := GetGlobalConfig("user.name")
+	if err != nil {
+		t.Errorf("GetGlobalConfig() failed: %v", err)
+	}
+	if result != "Global User" {
+		t.Errorf("Expected 'Global User', got '%s'", result)
+	}
+
+	if _, err := GetGlobalConfig("user.email"); err == nil {
+		t.Error("GetGlobalConfig() should error for a missing key")
+	}
+}
+
 func TestHasChanges(t *testing.T) {
 	gitAvailable(t)
 	tmpDir := createTempDir(t)
@@ -217,6 +280,40 @@ func TestAddFiles(t *testing.T) {
 	}
 }
 
+func TestSetExecutable(t *testing.T) {
+	gitAvailable(t)
+	tmpDir := createTempDir(t)
+	defer cleanup(t, tmpDir)
+
+	initGitRepo(t, tmpDir)
+
+	// core.fileMode=false makes git ignore the bit on disk
+	exec.Command("git", "-C", tmpDir, "config", "core.fileMode", "false").Run()
+	if err := os.WriteFile(filepath.Join(tmpDir, "run.sh"), []byte("#!/bin/sh\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddFiles(tmpDir, []string{"run.sh"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetExecutable(tmpDir, []string{"run.sh"}, false); err != nil {
+		t.Fatalf("SetExecutable() failed: %v", err)
+	}
+	if err := SetExecutable(tmpDir, nil, false); err != nil {
+		t.Errorf("SetExecutable(nil) failed: %v", err)
+	}
+
+	cmd := exec.Command("git", "ls-files", "-s", "run.sh")
+	cmd.Dir = tmpDir
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("failed to list staged files: %v", err)
+	}
+	if !strings.HasPrefix(string(output), "100755") {
+		t.Errorf("staged mode = %q, want 100755", output)
+	}
+}
+
 func TestAddFiles_NoFiles(t *testing.T) {
 	gitAvailable(t)
 	tmpDir := createTempDir(t)
@@ -314,7 +411,7 @@ func TestIntegration_FullWorkflow(t *testing.T) {
 	defer cleanup(t, tmpDir)
 
 	// 1. Initialize repo
-	if err := Init(tmpDir, false); err != nil {
+	if err := Init(tmpDir, "", false); err != nil {
 		t.Fatalf("Init() failed: %v", err)
 	}
 
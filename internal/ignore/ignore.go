@@ -0,0 +1,127 @@
+package ignore
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Matcher decides whether paths are ignored by .gitignore files. It covers
+// the common syntax: comments, negation with "!", directory-only patterns
+// ending in "/", patterns anchored by a "/", "*", "?", "[...]" and "**".
+type Matcher struct {
+	rules []rule
+}
+
+type rule struct {
+	base     string   // directory of the .gitignore, relative to the root ("" for the root)
+	segments []string // pattern split on "/"
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// Add parses the lines of a .gitignore located in dir, a slash-separated
+// path relative to the root ("" for the root itself)
+func (m *Matcher) Add(dir, content string) {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		r := rule{base: dir}
+		if strings.HasPrefix(line, "!") {
+			r.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			line = line[1:] // "\#" and "\!" escape the first character
+		}
+		if strings.HasSuffix(line, "/") {
+			r.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		// A slash anywhere but the end anchors the pattern to the .gitignore's directory
+		r.anchored = strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		if line == "" {
+			continue
+		}
+
+		r.segments = strings.Split(line, "/")
+		m.rules = append(m.rules, r)
+	}
+}
+
+// AddFile reads a .gitignore file if it exists
+func (m *Matcher) AddFile(dir, file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	m.Add(dir, string(data))
+	return nil
+}
+
+// Ignored reports whether the slash-separated path, relative to the root, is
+// ignored. The last matching rule wins, as in git.
+func (m *Matcher) Ignored(rel string, isDir bool) bool {
+	rel = filepath.ToSlash(rel)
+	ignored := false
+	for _, r := range m.rules {
+		if r.matches(rel, isDir) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+func (r rule) matches(rel string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+
+	if r.base != "" {
+		if !strings.HasPrefix(rel, r.base+"/") {
+			return false
+		}
+		rel = strings.TrimPrefix(rel, r.base+"/")
+	}
+
+	if !r.anchored {
+		// Matches the name at any depth
+		return matchSegment(r.segments[0], path.Base(rel))
+	}
+	return matchSegments(r.segments, strings.Split(rel, "/"))
+}
+
+// matchSegments matches path segments against pattern segments, where "**"
+// matches zero or more segments
+func matchSegments(pattern, parts []string) bool {
+	if len(pattern) == 0 {
+		return len(parts) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(parts); i++ {
+			if matchSegments(pattern[1:], parts[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(parts) == 0 || !matchSegment(pattern[0], parts[0]) {
+		return false
+	}
+	return matchSegments(pattern[1:], parts[1:])
+}
+
+func matchSegment(pattern, name string) bool {
+	ok, err := path.Match(pattern, name)
+	return err == nil && ok
+}
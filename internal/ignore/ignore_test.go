@@ -0,0 +1,44 @@
+package ignore
+
+import "testing"
+
+func TestMatcher_Ignored(t *testing.T) {
+	m := &Matcher{}
+	m.Add("", `# build output
+node_modules/
+*.log
+!keep.log
+/dist
+docs/**/*.pdf
+\#notes
+`)
+	m.Add("web", "cache/\n")
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"node_modules", true, true},
+		{"packages/app/node_modules", true, true},
+		{"node_modules", false, false},
+		{"debug.log", false, true},
+		{"logs/server.log", false, true},
+		{"keep.log", false, false},
+		{"dist", true, true},
+		{"pkg/dist", true, false},
+		{"docs/manual.pdf", false, true},
+		{"docs/a/b/manual.pdf", false, true},
+		{"manual.pdf", false, false},
+		{"#notes", false, true},
+		{"web/cache", true, true},
+		{"cache", true, false},
+		{"main.go", false, false},
+	}
+
+	for _, tt := range tests {
+		if got := m.Ignored(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("Ignored(%q, %v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
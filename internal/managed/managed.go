@@ -0,0 +1,161 @@
+package managed
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Marker prefixes delimiting a block of lines owned by maajise. Everything
+// outside the markers belongs to the user and is preserved on update.
+const (
+	BeginPrefix = "# >>> maajise:"
+	EndPrefix   = "# <<< maajise:"
+)
+
+// Begin returns the opening marker for a named block
+func Begin(name string) string {
+	return BeginPrefix + name
+}
+
+// End returns the closing marker for a named block
+func End(name string) string {
+	return EndPrefix + name
+}
+
+// Wrap surrounds content with the markers for a named block
+func Wrap(name, content string) string {
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return Begin(name) + "\n" + content + End(name) + "\n"
+}
+
+// Has reports whether content contains at least one managed block marker
+func Has(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		if _, ok := markerName(line, BeginPrefix); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// block is a managed block found in a file, as line indexes of its markers
+type block struct {
+	name       string
+	begin, end int
+}
+
+// parse finds the managed blocks in lines, rejecting unbalanced markers
+func parse(lines []string) ([]block, error) {
+	var blocks []block
+	open := -1
+	name := ""
+
+	for i, line := range lines {
+		if n, ok := markerName(line, BeginPrefix); ok {
+			if open != -1 {
+				return nil, fmt.Errorf("line %d: block %q opened inside block %q", i+1, n, name)
+			}
+			open, name = i, n
+			continue
+		}
+		if n, ok := markerName(line, EndPrefix); ok {
+			if open == -1 || n != name {
+				return nil, fmt.Errorf("line %d: unexpected end of block %q", i+1, n)
+			}
+			blocks = append(blocks, block{name: name, begin: open, end: i})
+			open = -1
+		}
+	}
+
+	if open != -1 {
+		return nil, fmt.Errorf("line %d: block %q is never closed", open+1, name)
+	}
+	return blocks, nil
+}
+
+func markerName(line, prefix string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, prefix) {
+		return "", false
+	}
+	name := strings.TrimSpace(strings.TrimPrefix(line, prefix))
+	return name, name != ""
+}
+
+// Splice refreshes the managed blocks of existing with the same-named blocks
+// from generated, leaving every line outside the markers untouched. Blocks in
+// generated that existing doesn't have yet are appended; blocks only present
+// in existing are kept as they are. It reports false if existing has no
+// managed blocks, in which case the caller should fall back to its usual
+// overwrite or merge behavior.
+func Splice(existing, generated string) (string, bool, error) {
+	oldLines := strings.SplitAfter(existing, "\n")
+	oldBlocks, err := parse(oldLines)
+	if err != nil {
+		return "", false, err
+	}
+	if len(oldBlocks) == 0 {
+		return "", false, nil
+	}
+
+	newLines := strings.SplitAfter(generated, "\n")
+	newBlocks, err := parse(newLines)
+	if err != nil {
+		return "", false, fmt.Errorf("generated content: %w", err)
+	}
+
+	replacement := make(map[string]string, len(newBlocks))
+	var order []string
+	for _, b := range newBlocks {
+		if _, dup := replacement[b.name]; !dup {
+			order = append(order, b.name)
+		}
+		replacement[b.name] = strings.Join(newLines[b.begin:b.end+1], "")
+	}
+
+	var out strings.Builder
+	used := make(map[string]bool)
+	next := 0
+	for _, b := range oldBlocks {
+		out.WriteString(strings.Join(oldLines[next:b.begin], ""))
+		if text, ok := replacement[b.name]; ok && !used[b.name] {
+			out.WriteString(terminate(text, oldLines, b.end))
+			used[b.name] = true
+		} else {
+			out.WriteString(strings.Join(oldLines[b.begin:b.end+1], ""))
+		}
+		next = b.end + 1
+	}
+	out.WriteString(strings.Join(oldLines[next:], ""))
+
+	// Append blocks the file doesn't have yet
+	result := out.String()
+	for _, name := range order {
+		if used[name] {
+			continue
+		}
+		if result != "" && !strings.HasSuffix(result, "\n") {
+			result += "\n"
+		}
+		if result != "" && !strings.HasSuffix(result, "\n\n") {
+			result += "\n"
+		}
+		result += replacement[name]
+	}
+
+	return result, true, nil
+}
+
+// terminate keeps the newline state of the block being replaced: a block at
+// the very end of a file without a trailing newline stays that way
+func terminate(text string, lines []string, end int) string {
+	if end == len(lines)-1 && !strings.HasSuffix(lines[end], "\n") {
+		return strings.TrimSuffix(text, "\n")
+	}
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return text
+}
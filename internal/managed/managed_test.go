@@ -0,0 +1,86 @@
+package managed
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	got := Wrap("base", "node_modules/\n.env")
+	want := "# >>> maajise:base\nnode_modules/\n.env\n# <<< maajise:base\n"
+	if got != want {
+		t.Errorf("Wrap() = %q, want %q", got, want)
+	}
+}
+
+func TestHas(t *testing.T) {
+	if Has("node_modules/\n") {
+		t.Error("Has() = true for content without markers")
+	}
+	if !Has("local\n" + Wrap("go", "bin/\n")) {
+		t.Error("Has() = false for content with a block")
+	}
+}
+
+func TestSplice_PreservesUserLines(t *testing.T) {
+	existing := "# my stuff\nsecrets/\n\n" +
+		Wrap("base", "node_modules/\n") +
+		"\n*.local\n"
+	generated := Wrap("base", "node_modules/\ndist/\n")
+
+	got, ok, err := Splice(existing, generated)
+	if err != nil || !ok {
+		t.Fatalf("Splice() ok = %v, err = %v", ok, err)
+	}
+
+	want := "# my stuff\nsecrets/\n\n" +
+		Wrap("base", "node_modules/\ndist/\n") +
+		"\n*.local\n"
+	if got != want {
+		t.Errorf("Splice() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSplice_NoMarkers(t *testing.T) {
+	_, ok, err := Splice("node_modules/\n", Wrap("base", "dist/\n"))
+	if err != nil {
+		t.Errorf("Splice() error = %v", err)
+	}
+	if ok {
+		t.Error("Splice() ok = true for a file without managed blocks")
+	}
+}
+
+func TestSplice_AppendsNewBlocksAndKeepsOthers(t *testing.T) {
+	existing := "mine/\n" + Wrap("base", "old/\n") + Wrap("feature-docker", "docker-data/\n")
+	generated := Wrap("base", "new/\n") + Wrap("feature-ci", "ci-cache/\n")
+
+	got, ok, err := Splice(existing, generated)
+	if err != nil || !ok {
+		t.Fatalf("Splice() ok = %v, err = %v", ok, err)
+	}
+
+	for _, want := range []string{"mine/", "new/", "docker-data/", "ci-cache/"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Splice() result missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "old/") {
+		t.Errorf("Splice() should replace the base block:\n%s", got)
+	}
+	if !strings.HasSuffix(got, Wrap("feature-ci", "ci-cache/\n")) {
+		t.Errorf("new block should be appended at the end:\n%s", got)
+	}
+}
+
+func TestSplice_Unbalanced(t *testing.T) {
+	existing := Begin("base") + "\nnode_modules/\n"
+	if _, _, err := Splice(existing, Wrap("base", "")); err == nil {
+		t.Error("Splice() should reject an unclosed block")
+	}
+
+	existing = "x\n" + End("base") + "\n"
+	if _, _, err := Splice(existing, Wrap("base", "")); err == nil {
+		t.Error("Splice() should reject an end marker without a begin")
+	}
+}
@@ -0,0 +1,106 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"maajise/internal/fsutil"
+)
+
+// BaselineDir holds the content of each file as it was last generated, one
+// blob per content hash, relative to the project root. update uses it as the
+// common ancestor for three-way merges. Blobs are named by hash rather than by
+// the file they belong to, so a baseline .gitignore doesn't act as one.
+const BaselineDir = ".maajise/baseline"
+
+// BaselinePath returns where the baseline with the given content hash is stored
+func BaselinePath(dir, hash string) string {
+	return filepath.Join(dir, filepath.FromSlash(BaselineDir), blobName(hash))
+}
+
+// blobName returns the file name of a baseline blob: the hex digest of its hash
+func blobName(hash string) string {
+	return strings.TrimPrefix(hash, "sha256:")
+}
+
+// SaveBaseline stores generated content under its hash
+func SaveBaseline(dir, content string) error {
+	// Joined from dir so a symlinked .maajise or .maajise/baseline is caught too
+	full, err := fsutil.SafeJoin(dir, BaselineDir+"/"+blobName(Hash(content)))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(full, []byte(content), 0644)
+}
+
+// LoadBaseline returns the generated content with the given hash, if a
+// baseline was stored
+func LoadBaseline(dir, hash string) (string, bool) {
+	data, err := os.ReadFile(BaselinePath(dir, hash))
+	if err != nil || Hash(string(data)) != hash {
+		return "", false
+	}
+	return string(data), true
+}
+
+// Baseline returns the content a recorded file was last generated with
+func (m *Manifest) Baseline(dir, path string) (string, bool) {
+	hash, ok := m.Files[filepath.ToSlash(path)]
+	if !ok {
+		return "", false
+	}
+	if content, ok := LoadBaseline(dir, hash); ok {
+		return content, true
+	}
+
+	// Older versions stored a copy under the file's own path
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(BaselineDir), filepath.FromSlash(path)))
+	if err != nil || Hash(string(data)) != hash {
+		return "", false
+	}
+	return string(data), true
+}
+
+// pruneBaselines keeps the baselines of the recorded files, moving copies
+// stored by older versions to blobs, and removes everything else
+func (m *Manifest) pruneBaselines(dir string) error {
+	base, err := fsutil.SafeJoin(dir, BaselineDir)
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	keep := make(map[string]bool)
+	for path, hash := range m.Files {
+		content, ok := m.Baseline(dir, path)
+		if !ok {
+			continue
+		}
+		if !fsutil.FileExists(BaselinePath(dir, hash)) {
+			if err := SaveBaseline(dir, content); err != nil {
+				return err
+			}
+		}
+		keep[blobName(hash)] = true
+	}
+
+	for _, entry := range entries {
+		if keep[entry.Name()] {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(base, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
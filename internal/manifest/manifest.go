@@ -0,0 +1,144 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"maajise/internal/fsutil"
+	"maajise/templates"
+)
+
+// FileName is the manifest written to the project root
+const FileName = ".maajise.lock"
+
+// Manifest records how a project was generated so later commands don't
+// have to guess the template from marker files
+type Manifest struct {
+	Template string                 `yaml:"template"`
+	Version  string                 `yaml:"version"`
+	Vars     templates.TemplateVars `yaml:"vars"`
+	Features []string               `yaml:"features,omitempty"`
+	Files    map[string]string      `yaml:"files"`
+
+	// Projects lists the sub-projects of a workspace root
+	Projects []templates.Project `yaml:"projects,omitempty"`
+	// Workspace is the path from a sub-project back to its workspace root
+	Workspace string `yaml:"workspace,omitempty"`
+}
+
+// New creates an empty manifest for the given template
+func New(template, version string, vars templates.TemplateVars) *Manifest {
+	return &Manifest{
+		Template: template,
+		Version:  version,
+		Vars:     vars,
+		Files:    make(map[string]string),
+	}
+}
+
+// Path returns the manifest path for a project directory
+func Path(dir string) string {
+	return filepath.Join(dir, FileName)
+}
+
+// Exists returns true if the project directory has a manifest
+func Exists(dir string) bool {
+	_, err := os.Stat(Path(dir))
+	return err == nil
+}
+
+// Load reads the manifest from a project directory
+// Returns nil (not error) if the manifest doesn't exist
+func Load(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(Path(dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	m := &Manifest{}
+	if err := yaml.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", FileName, err)
+	}
+	if m.Files == nil {
+		m.Files = make(map[string]string)
+	}
+
+	return m, nil
+}
+
+// Save writes the manifest to a project directory and drops the baselines
+// of files it no longer records
+func (m *Manifest) Save(dir string) error {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	path, err := fsutil.SafeJoin(dir, FileName)
+	if err != nil {
+		return err
+	}
+	header := "# Generated by maajise. Records the template used for this project.\n"
+	if err := os.WriteFile(path, append([]byte(header), data...), 0644); err != nil {
+		return err
+	}
+	return m.pruneBaselines(dir)
+}
+
+// Record stores the hash of a generated file
+func (m *Manifest) Record(path, content string) {
+	if m.Files == nil {
+		m.Files = make(map[string]string)
+	}
+	m.Files[filepath.ToSlash(path)] = Hash(content)
+}
+
+// Paths returns the recorded file paths in sorted order
+func (m *Manifest) Paths() []string {
+	paths := make([]string, 0, len(m.Files))
+	for path := range m.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Modified reports whether a recorded file differs from its generated content.
+// Files that are not recorded or no longer exist are reported as modified.
+func (m *Manifest) Modified(dir, path string) bool {
+	want, ok := m.Files[filepath.ToSlash(path)]
+	if !ok {
+		return true
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, path))
+	if err != nil {
+		return true
+	}
+
+	return Hash(string(data)) != want
+}
+
+// Digest returns the hash of the manifest file in dir, "" if there is none
+func Digest(dir string) string {
+	data, err := os.ReadFile(Path(dir))
+	if err != nil {
+		return ""
+	}
+	return Hash(string(data))
+}
+
+// Hash returns the SHA-256 of content in "sha256:<hex>" form
+func Hash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,181 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"maajise/templates"
+)
+
+func TestLoad_Missing(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-manifest-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	m, err := Load(tmpDir)
+	if err != nil {
+		t.Errorf("Load() error = %v, want nil", err)
+	}
+	if m != nil {
+		t.Error("Load() should return nil manifest when file is missing")
+	}
+	if Exists(tmpDir) {
+		t.Error("Exists() should be false when file is missing")
+	}
+}
+
+func TestManifest_RoundTrip(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-manifest-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	vars := templates.DefaultVars("my-app")
+	vars.Author = "Jane Smith"
+
+	m := New("go", "2.0.0", vars)
+	m.Record("README.md", "# my-app\n")
+	m.Record(filepath.Join("cmd", "my-app", "main.go"), "package main\n")
+
+	if err := m.Save(tmpDir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if loaded.Template != "go" {
+		t.Errorf("Template = %q, want %q", loaded.Template, "go")
+	}
+	if loaded.Version != "2.0.0" {
+		t.Errorf("Version = %q, want %q", loaded.Version, "2.0.0")
+	}
+	if loaded.Vars.ProjectName != "my-app" || loaded.Vars.Author != "Jane Smith" {
+		t.Errorf("Vars = %+v, want project my-app by Jane Smith", loaded.Vars)
+	}
+
+	// Paths are stored with forward slashes and sorted
+	paths := loaded.Paths()
+	want := []string{"README.md", "cmd/my-app/main.go"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("Paths() = %v, want %v", paths, want)
+	}
+}
+
+func TestManifest_Modified(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-manifest-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	m := New("base", "2.0.0", templates.DefaultVars("test"))
+	m.Record(".gitignore", "node_modules/\n")
+	os.WriteFile(filepath.Join(tmpDir, ".gitignore"), []byte("node_modules/\n"), 0644)
+
+	if m.Modified(tmpDir, ".gitignore") {
+		t.Error("Modified() = true for unchanged file")
+	}
+
+	os.WriteFile(filepath.Join(tmpDir, ".gitignore"), []byte("node_modules/\n.env\n"), 0644)
+	if !m.Modified(tmpDir, ".gitignore") {
+		t.Error("Modified() = false for edited file")
+	}
+
+	if !m.Modified(tmpDir, "README.md") {
+		t.Error("Modified() = false for unrecorded file")
+	}
+}
+
+func TestHash(t *testing.T) {
+	h := Hash("")
+	if !strings.HasPrefix(h, "sha256:") {
+		t.Errorf("Hash() = %q, want sha256: prefix", h)
+	}
+	if h != "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Errorf("Hash(\"\") = %q", h)
+	}
+	if Hash("a") == Hash("b") {
+		t.Error("Hash() should differ for different content")
+	}
+}
+
+func TestSymlinkedWrites(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-manifest-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	dir := filepath.Join(tmpDir, "project")
+	outside := filepath.Join(tmpDir, "outside")
+	os.MkdirAll(dir, 0755)
+	os.MkdirAll(outside, 0755)
+	if err := os.Symlink(outside, filepath.Join(dir, ".maajise")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	os.Symlink(filepath.Join(outside, "lock"), filepath.Join(dir, FileName))
+
+	if err := SaveBaseline(dir, "x"); err == nil {
+		t.Error("SaveBaseline() should fail when .maajise is a symlink")
+	}
+	if err := New("base", "1.0.0", templates.DefaultVars("p")).Save(dir); err == nil {
+		t.Error("Save() should fail when .maajise.lock is a symlink")
+	}
+	if entries, _ := os.ReadDir(outside); len(entries) != 0 {
+		t.Errorf("wrote %d files outside the project", len(entries))
+	}
+}
+
+func TestBaselines(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-manifest-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// A copy stored under the file's own path by an older version
+	legacy := filepath.Join(tmpDir, filepath.FromSlash(BaselineDir), ".gitignore")
+	os.MkdirAll(filepath.Dir(legacy), 0755)
+	os.WriteFile(legacy, []byte("/bin/\n"), 0644)
+
+	m := New("base", "2.0.0", templates.DefaultVars("p"))
+	m.Record(".gitignore", "/bin/\n")
+	if got, ok := m.Baseline(tmpDir, ".gitignore"); !ok || got != "/bin/\n" {
+		t.Errorf("Baseline(.gitignore) = %q, %v, want the legacy copy", got, ok)
+	}
+
+	m.Record("README.md", "# p\n")
+	if err := SaveBaseline(tmpDir, "# p\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveBaseline(tmpDir, "# stale\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Save(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// Save moves the legacy copy to a blob and drops the stale one
+	entries, _ := os.ReadDir(filepath.Join(tmpDir, filepath.FromSlash(BaselineDir)))
+	if len(entries) != 2 {
+		t.Errorf("baselines = %d entries, want one blob per recorded file", len(entries))
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			t.Errorf("baseline %s is named after a file", entry.Name())
+		}
+	}
+	for _, path := range []string{".gitignore", "README.md"} {
+		if _, ok := m.Baseline(tmpDir, path); !ok {
+			t.Errorf("Baseline(%s) missing after Save()", path)
+		}
+	}
+}
@@ -0,0 +1,421 @@
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Kind is the type of a template source
+type Kind int
+
+const (
+	Dir Kind = iota
+	Tarball
+	Git
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Tarball:
+		return "tarball"
+	case Git:
+		return "git"
+	}
+	return "directory"
+}
+
+// Source is a template fetched from outside ~/.maajise/templates
+type Source struct {
+	Kind     Kind
+	Location string // directory, tarball path or URL, or git URL
+	Ref      string // git branch, tag or commit; empty for the default branch
+}
+
+// IsSource reports whether a --template value names a source rather than a
+// registered template. Template names never contain a slash or a scheme.
+func IsSource(ref string) bool {
+	return strings.ContainsAny(ref, `/\`) || strings.HasPrefix(ref, "~") ||
+		strings.HasPrefix(ref, ".") || strings.Contains(ref, "://") || isTarball(ref)
+}
+
+// Parse interprets a --template value:
+//
+//	git+https://host/org/templates.git#v1.2  git repository at a ref
+//	git+file:///srv/templates.git            local git repository
+//	https://host/org/templates.git#main      URLs ending in .git are git too
+//	./templates/service.tar.gz               tarball, local or http(s)
+//	~/work/service-template                  local directory
+func Parse(ref string) (Source, error) {
+	if ref == "" {
+		return Source{}, fmt.Errorf("empty template source")
+	}
+
+	location, gitRef, _ := strings.Cut(ref, "#")
+	switch {
+	case strings.HasPrefix(location, "git+"):
+		location = strings.TrimPrefix(location, "git+")
+		if location == "" {
+			return Source{}, fmt.Errorf("invalid template source %q: missing repository URL", ref)
+		}
+		src := Source{Kind: Git, Location: location, Ref: gitRef}
+		return src, src.checkGit()
+	case strings.HasPrefix(location, "git@") || (strings.Contains(location, "://") && strings.HasSuffix(location, ".git")):
+		src := Source{Kind: Git, Location: location, Ref: gitRef}
+		return src, src.checkGit()
+	}
+
+	if gitRef != "" {
+		return Source{}, fmt.Errorf("invalid template source %q: a #ref needs a git repository", ref)
+	}
+	if strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://") {
+		if !isTarball(ref) {
+			return Source{}, fmt.Errorf("invalid template source %q: URLs must be git repositories or .tar.gz files", ref)
+		}
+		return Source{Kind: Tarball, Location: ref}, nil
+	}
+
+	path, err := expandHome(strings.TrimPrefix(ref, "file://"))
+	if err != nil {
+		return Source{}, err
+	}
+	if isTarball(path) {
+		return Source{Kind: Tarball, Location: path}, nil
+	}
+	return Source{Kind: Dir, Location: path}, nil
+}
+
+// checkGit rejects a repository URL or ref that git would read as an
+// option, e.g. --upload-pack=... from a hostile .maajise.lock
+func (s Source) checkGit() error {
+	if strings.HasPrefix(s.Location, "-") {
+		return fmt.Errorf("invalid git repository %q", s.Location)
+	}
+	if strings.HasPrefix(s.Ref, "-") {
+		return fmt.Errorf("invalid git ref %q", s.Ref)
+	}
+	return nil
+}
+
+// String returns the source as a --template value. Local paths are made
+// absolute so the value still works from another directory.
+func (s Source) String() string {
+	switch {
+	case s.Kind == Git:
+		ref := "git+" + s.Location
+		if s.Ref != "" {
+			ref += "#" + s.Ref
+		}
+		return ref
+	case strings.Contains(s.Location, "://"):
+		return s.Location
+	}
+	if abs, err := filepath.Abs(s.Location); err == nil {
+		return abs
+	}
+	return s.Location
+}
+
+// Canonical returns ref in the form recorded in .maajise.lock: sources as by
+// Source.String, anything else unchanged
+func Canonical(ref string) string {
+	if !IsSource(ref) {
+		return ref
+	}
+	src, err := Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return src.String()
+}
+
+// DefaultCacheDir returns ~/.maajise/cache/
+func DefaultCacheDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".maajise", "cache")
+}
+
+// Fetch makes the source available locally and returns its directory.
+// Directories are used in place. Tarballs and git repositories are unpacked
+// or cloned into cacheDir; a git repository pinned to a tag or commit is
+// reused from the cache, one following a branch is fetched again.
+func Fetch(src Source, cacheDir string) (string, error) {
+	switch src.Kind {
+	case Dir:
+		info, err := os.Stat(src.Location)
+		if err != nil {
+			return "", fmt.Errorf("template directory: %w", err)
+		}
+		if !info.IsDir() {
+			return "", fmt.Errorf("template source %s is not a directory", src.Location)
+		}
+		return src.Location, nil
+	case Tarball:
+		return fetchTarball(src, cacheDir)
+	case Git:
+		return fetchGit(src, cacheDir)
+	}
+	return "", fmt.Errorf("unknown template source kind %d", src.Kind)
+}
+
+// cachePath returns the cache directory for a source
+func cachePath(cacheDir string, kind Kind, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(cacheDir, kind.String(), hex.EncodeToString(sum[:])[:16])
+}
+
+func fetchGit(src Source, cacheDir string) (string, error) {
+	if err := src.checkGit(); err != nil {
+		return "", err
+	}
+	dir := cachePath(cacheDir, Git, src.Location+"#"+src.Ref)
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		if src.Ref == "" {
+			if err := runGit(dir, "pull", "--quiet", "--ff-only"); err != nil {
+				return "", fmt.Errorf("failed to update %s: %w", src.Location, err)
+			}
+			return dir, nil
+		}
+		if !isBranch(dir, src.Ref) {
+			return dir, nil
+		}
+		if err := runGit(dir, "fetch", "--quiet", "origin"); err != nil {
+			return "", fmt.Errorf("failed to update %s: %w", src.Location, err)
+		}
+		if err := checkout(dir, src.Ref); err != nil {
+			return "", fmt.Errorf("failed to check out %s in %s: %w", src.Ref, src.Location, err)
+		}
+		return dir, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), ".fetch-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := runGit("", "clone", "--quiet", "--", src.Location, tmp); err != nil {
+		return "", fmt.Errorf("failed to clone %s: %w", src.Location, err)
+	}
+	if src.Ref != "" {
+		if err := checkout(tmp, src.Ref); err != nil {
+			return "", fmt.Errorf("failed to check out %s in %s: %w", src.Ref, src.Location, err)
+		}
+	}
+
+	return dir, install(tmp, dir)
+}
+
+// checkout detaches the clone in dir at ref, a tag, commit or branch of
+// origin. The ref is validated and resolved to a commit first, so git never
+// sees it as an option or a path.
+func checkout(dir, ref string) error {
+	if err := runGit(dir, "check-ref-format", "--allow-onelevel", ref); err != nil {
+		return fmt.Errorf("invalid git ref %q", ref)
+	}
+
+	// Branches of origin win over the local branch the clone started on
+	for _, name := range []string{"refs/remotes/origin/" + ref, ref} {
+		commit, err := gitOutput(dir, "rev-parse", "--verify", "--quiet", "--end-of-options", name+"^{commit}")
+		if err == nil {
+			return runGit(dir, "checkout", "--quiet", commit, "--")
+		}
+	}
+	return fmt.Errorf("unknown ref %q", ref)
+}
+
+// isBranch reports whether ref names a branch of origin in the clone in dir
+func isBranch(dir, ref string) bool {
+	_, err := gitOutput(dir, "rev-parse", "--verify", "--quiet", "--end-of-options", "refs/remotes/origin/"+ref)
+	return err == nil
+}
+
+func fetchTarball(src Source, cacheDir string) (string, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(src.Location, "http://") || strings.HasPrefix(src.Location, "https://") {
+		data, err = download(src.Location)
+	} else {
+		data, err = os.ReadFile(src.Location)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read tarball: %w", err)
+	}
+
+	// Keyed by content, so a changed tarball is unpacked again
+	sum := sha256.Sum256(data)
+	dir := cachePath(cacheDir, Tarball, hex.EncodeToString(sum[:]))
+	if _, err := os.Stat(dir); err == nil {
+		return dir, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), ".fetch-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := extract(data, strings.HasSuffix(src.Location, ".tar"), tmp); err != nil {
+		return "", fmt.Errorf("failed to unpack %s: %w", src.Location, err)
+	}
+	return dir, install(singleRoot(tmp), dir)
+}
+
+// extract unpacks a tar (optionally gzipped) archive into dir
+func extract(data []byte, plain bool, dir string) error {
+	var r io.Reader = bytes.NewReader(data)
+	if !plain {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.Clean(filepath.FromSlash(hdr.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("archive entry %s escapes the template directory", hdr.Name)
+		}
+		target := filepath.Join(dir, name)
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode).Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+		// Links and other entries are skipped
+	}
+}
+
+// singleRoot descends into the only directory of an unpacked archive, so
+// "service-1.0/template.yaml" tarballs work like flat ones
+func singleRoot(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+		return dir
+	}
+	return filepath.Join(dir, entries[0].Name())
+}
+
+// install moves a fetched source into its cache directory
+func install(from, to string) error {
+	if err := os.Rename(from, to); err != nil {
+		// Another maajise may have fetched the same source meanwhile
+		if _, statErr := os.Stat(to); statErr == nil {
+			return nil
+		}
+		return fmt.Errorf("failed to populate cache: %w", err)
+	}
+	return nil
+}
+
+// downloadTimeout bounds a whole tarball download, including the body
+const downloadTimeout = 2 * time.Minute
+
+// maxDownloadSize is the largest tarball download accepts
+var maxDownloadSize int64 = 64 << 20
+
+var httpClient = &http.Client{Timeout: downloadTimeout}
+
+func download(url string) ([]byte, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxDownloadSize {
+		return nil, fmt.Errorf("GET %s: tarball larger than %d MiB", url, maxDownloadSize>>20)
+	}
+	return data, nil
+}
+
+// gitOutput runs git in dir and returns its trimmed standard output
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s", msg)
+		}
+		return err
+	}
+	return nil
+}
+
+func isTarball(path string) bool {
+	return strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz") || strings.HasSuffix(path, ".tar")
+}
+
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to expand %s: %w", path, err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
@@ -0,0 +1,229 @@
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsSource(t *testing.T) {
+	for _, ref := range []string{"./tmpl", "/srv/tmpl", "~/tmpl", "svc.tar.gz", "git+https://example.com/t.git", "https://example.com/t.tgz"} {
+		if !IsSource(ref) {
+			t.Errorf("IsSource(%q) = false, want true", ref)
+		}
+	}
+	for _, name := range []string{"go", "org-go", "my_template"} {
+		if IsSource(name) {
+			t.Errorf("IsSource(%q) = true, want false", name)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want Source
+	}{
+		{"git+file:///srv/templates.git#v1.2", Source{Git, "file:///srv/templates.git", "v1.2"}},
+		{"git+https://example.com/org/templates", Source{Git, "https://example.com/org/templates", ""}},
+		{"https://example.com/org/templates.git#main", Source{Git, "https://example.com/org/templates.git", "main"}},
+		{"git@example.com:org/templates.git", Source{Git, "git@example.com:org/templates.git", ""}},
+		{"https://example.com/service.tar.gz", Source{Tarball, "https://example.com/service.tar.gz", ""}},
+		{"./service.tgz", Source{Tarball, "./service.tgz", ""}},
+		{"file:///srv/service", Source{Dir, "/srv/service", ""}},
+		{"../service", Source{Dir, "../service", ""}},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.ref)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.ref, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.ref, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "git+", "./service#v1", "https://example.com/service",
+		"git+--upload-pack=touch /tmp/pwned", "https://example.com/t.git#--upload-pack=sh"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	if got := Canonical("go"); got != "go" {
+		t.Errorf("Canonical(go) = %q, want go", got)
+	}
+	if got := Canonical("git+file:///srv/t.git#v1"); got != "git+file:///srv/t.git#v1" {
+		t.Errorf("Canonical() = %q", got)
+	}
+
+	abs, _ := filepath.Abs("service")
+	if got := Canonical("./service"); got != abs {
+		t.Errorf("Canonical(./service) = %q, want %q", got, abs)
+	}
+}
+
+func TestFetch_Dir(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-source-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	dir, err := Fetch(Source{Kind: Dir, Location: tmpDir}, filepath.Join(tmpDir, "cache"))
+	if err != nil || dir != tmpDir {
+		t.Errorf("Fetch() = %q, %v, want the directory itself", dir, err)
+	}
+
+	if _, err := Fetch(Source{Kind: Dir, Location: filepath.Join(tmpDir, "missing")}, ""); err == nil {
+		t.Error("Fetch() should fail for a missing directory")
+	}
+}
+
+func TestFetch_Tarball(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-source-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	archive := filepath.Join(tmpDir, "service.tar.gz")
+	writeTarball(t, archive, map[string]string{"service-1.0/template.yaml": "name: service\n"})
+
+	cacheDir := filepath.Join(tmpDir, "cache")
+	dir, err := Fetch(Source{Kind: Tarball, Location: archive}, cacheDir)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "template.yaml"))
+	if err != nil || string(content) != "name: service\n" {
+		t.Errorf("template.yaml = %q, %v (a single top-level directory should be unwrapped)", content, err)
+	}
+
+	again, err := Fetch(Source{Kind: Tarball, Location: archive}, cacheDir)
+	if err != nil || again != dir {
+		t.Errorf("second Fetch() = %q, %v, want cached %q", again, err, dir)
+	}
+
+	evil := filepath.Join(tmpDir, "evil.tar.gz")
+	writeTarball(t, evil, map[string]string{"../escape.txt": "x"})
+	if _, err := Fetch(Source{Kind: Tarball, Location: evil}, cacheDir); err == nil {
+		t.Error("Fetch() should reject archive entries outside the template directory")
+	}
+}
+
+func TestFetch_Git(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed, skipping test")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "maajise-source-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	repo := filepath.Join(tmpDir, "templates")
+	os.MkdirAll(repo, 0755)
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "--quiet")
+	os.WriteFile(filepath.Join(repo, "template.yaml"), []byte("name: v1\n"), 0644)
+	git("add", ".")
+	git("commit", "--quiet", "-m", "v1")
+	git("tag", "v1")
+	os.WriteFile(filepath.Join(repo, "template.yaml"), []byte("name: v2\n"), 0644)
+	git("commit", "--quiet", "-am", "v2")
+
+	cacheDir := filepath.Join(tmpDir, "cache")
+	src, _ := Parse("git+file://" + repo + "#v1")
+	dir, err := Fetch(src, cacheDir)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "template.yaml")); string(content) != "name: v1\n" {
+		t.Errorf("pinned template.yaml = %q, want the v1 tag", content)
+	}
+
+	src, _ = Parse("git+file://" + repo)
+	dir, err = Fetch(src, cacheDir)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "template.yaml")); string(content) != "name: v2\n" {
+		t.Errorf("unpinned template.yaml = %q, want the latest commit", content)
+	}
+
+	// A branch ref follows the branch; the cached clone is fetched again
+	git("branch", "stable")
+	src, _ = Parse("git+file://" + repo + "#stable")
+	if _, err := Fetch(src, cacheDir); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	git("checkout", "--quiet", "stable")
+	os.WriteFile(filepath.Join(repo, "template.yaml"), []byte("name: v3\n"), 0644)
+	git("commit", "--quiet", "-am", "v3")
+	dir, err = Fetch(src, cacheDir)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "template.yaml")); string(content) != "name: v3\n" {
+		t.Errorf("branch template.yaml = %q, want the branch's latest commit", content)
+	}
+
+	src, _ = Parse("git+file://" + repo + "#no:such:ref")
+	if _, err := Fetch(src, cacheDir); err == nil {
+		t.Error("Fetch() should fail for an invalid ref")
+	}
+}
+
+func TestDownload_SizeLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(bytes.Repeat([]byte("x"), 2048))
+	}))
+	defer server.Close()
+
+	old := maxDownloadSize
+	defer func() { maxDownloadSize = old }()
+
+	maxDownloadSize = 4096
+	if data, err := download(server.URL); err != nil || len(data) != 2048 {
+		t.Errorf("download() = %d bytes, %v, want 2048 bytes", len(data), err)
+	}
+	maxDownloadSize = 1024
+	if _, err := download(server.URL); err == nil {
+		t.Error("download() should fail for a body over maxDownloadSize")
+	}
+}
+
+// writeTarball writes a gzipped tar archive with the given files
+func writeTarball(t *testing.T, path string, files map[string]string) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	gz.Close()
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,218 @@
+package txn
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// backup is the original content of a file that existed before it was touched
+type backup struct {
+	path    string
+	content []byte
+	mode    os.FileMode
+}
+
+// Tx records the filesystem changes of a multi-step operation so they can be
+// undone if a later step fails. Paths are tracked before they are written:
+// paths that did not exist are removed on rollback, existing files are
+// restored from an in-memory backup. Tx is safe for concurrent use, but a
+// rollback should run once the steps writing to the tracked paths have
+// stopped, not from a signal handler racing them.
+type Tx struct {
+	mu      sync.Mutex
+	created []string
+	backups []backup
+	backed  map[string]bool
+	done    bool
+}
+
+// New starts a transaction
+func New() *Tx {
+	return &Tx{backed: make(map[string]bool)}
+}
+
+// Track records path before it is created or overwritten. If path does not
+// exist, its top-most missing ancestor is recorded for removal so directories
+// created along the way are cleaned up too. If path is an existing file, its
+// content is backed up. Existing directories are left alone.
+func (t *Tx) Track(path string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done {
+		return errors.New("transaction already finished")
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Lstat(abs)
+	if err == nil {
+		if info.IsDir() || t.backed[abs] || t.isCreated(abs) {
+			return nil
+		}
+		content, err := os.ReadFile(abs)
+		if err != nil {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+		t.backups = append(t.backups, backup{path: abs, content: content, mode: info.Mode().Perm()})
+		t.backed[abs] = true
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+
+	// Walk up to the outermost directory that doesn't exist yet
+	top := abs
+	for {
+		parent := filepath.Dir(top)
+		if parent == top {
+			break
+		}
+		if _, err := os.Lstat(parent); err == nil {
+			break
+		}
+		top = parent
+	}
+
+	if !t.isCreated(top) {
+		t.created = append(t.created, top)
+	}
+	return nil
+}
+
+// isCreated reports whether path is, or is inside, a path recorded for removal
+func (t *Tx) isCreated(path string) bool {
+	for _, c := range t.created {
+		if path == c || strings.HasPrefix(path, c+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Created returns the paths that rollback would remove, in the order they were tracked
+func (t *Tx) Created() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.created...)
+}
+
+// Commit keeps all changes and discards the backups. Later calls to Rollback
+// do nothing.
+func (t *Tx) Commit() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.done = true
+	t.created = nil
+	t.backups = nil
+}
+
+// Rollback removes every created path, newest first, and restores backed-up
+// files. It keeps going after a failure and returns all errors joined.
+// Calling it again, or after Commit, does nothing.
+func (t *Tx) Rollback() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done {
+		return nil
+	}
+	t.done = true
+
+	var errs []error
+	for i := len(t.created) - 1; i >= 0; i-- {
+		if err := os.RemoveAll(t.created[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for i := len(t.backups) - 1; i >= 0; i-- {
+		b := t.backups[i]
+		if err := os.MkdirAll(filepath.Dir(b.path), 0755); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := os.WriteFile(b.path, b.content, b.mode); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		// WriteFile keeps the mode of an existing file
+		if err := os.Chmod(b.path, b.mode); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	t.created = nil
+	t.backups = nil
+	return errors.Join(errs...)
+}
+
+// Snapshot is the list of paths under a directory at one point in time, for
+// finding what a subprocess created there
+type Snapshot struct {
+	dir   string
+	paths map[string]bool
+}
+
+// TakeSnapshot lists the paths under dir, skipping .git
+func TakeSnapshot(dir string) (*Snapshot, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	s := &Snapshot{dir: abs, paths: make(map[string]bool)}
+	err = filepath.WalkDir(abs, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		s.paths[path] = true
+		return nil
+	})
+	return s, err
+}
+
+// TrackNew records the paths created under the snapshot's directory since it
+// was taken, for changes made by subprocesses such as hooks that can't Track
+// before writing. Only the top-most new path of each new tree is recorded.
+func (t *Tx) TrackNew(s *Snapshot) error {
+	var created []string
+	err := filepath.WalkDir(s.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if s.paths[path] {
+			return nil
+		}
+		created = append(created, path)
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return errors.New("transaction already finished")
+	}
+	for _, path := range created {
+		if !t.isCreated(path) {
+			t.created = append(t.created, path)
+		}
+	}
+	return err
+}
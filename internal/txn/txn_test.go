@@ -0,0 +1,163 @@
+package txn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRollback_RemovesCreatedPaths(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "txn-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tx := New()
+
+	// Nested directories are recorded by their outermost missing ancestor
+	nested := filepath.Join(tmpDir, "proj", "proj")
+	if err := tx.Track(nested); err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(nested, 0755)
+
+	file := filepath.Join(nested, "README.md")
+	if err := tx.Track(file); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(file, []byte("readme"), 0644)
+
+	if got := tx.Created(); len(got) != 1 || filepath.Base(got[0]) != "proj" {
+		t.Errorf("Created() = %v, want only the outer proj dir", got)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "proj")); !os.IsNotExist(err) {
+		t.Error("Rollback() did not remove the created directory")
+	}
+}
+
+func TestRollback_RestoresBackups(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "txn-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	existing := filepath.Join(tmpDir, ".gitignore")
+	os.WriteFile(existing, []byte("mine\n"), 0600)
+
+	tx := New()
+	if err := tx.Track(existing); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(existing, []byte("generated\n"), 0644)
+
+	// Tracking again must not replace the original backup
+	if err := tx.Track(existing); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+
+	content, _ := os.ReadFile(existing)
+	if string(content) != "mine\n" {
+		t.Errorf("restored content = %q, want %q", content, "mine\n")
+	}
+	info, _ := os.Stat(existing)
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("restored mode = %v, want %v", info.Mode().Perm(), os.FileMode(0600))
+	}
+}
+
+func TestRollback_KeepsExistingDirectories(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "txn-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tx := New()
+	if err := tx.Track(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if _, err := os.Stat(tmpDir); err != nil {
+		t.Error("Rollback() removed a directory that existed before")
+	}
+}
+
+func TestCommit(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "txn-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	file := filepath.Join(tmpDir, "new.txt")
+	tx := New()
+	tx.Track(file)
+	os.WriteFile(file, []byte("x"), 0644)
+
+	tx.Commit()
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback() after Commit() error = %v", err)
+	}
+	if _, err := os.Stat(file); err != nil {
+		t.Error("Rollback() after Commit() should not remove anything")
+	}
+	if err := tx.Track(file); err == nil {
+		t.Error("Track() after Commit() should fail")
+	}
+}
+
+func TestTrackNew(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "txn-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	os.WriteFile(filepath.Join(tmpDir, "existing.txt"), []byte("keep"), 0644)
+	os.MkdirAll(filepath.Join(tmpDir, ".git", "objects"), 0755)
+
+	tx := New()
+	snap, err := TakeSnapshot(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Files written by a subprocess, which could not Track them first
+	os.MkdirAll(filepath.Join(tmpDir, "node_modules", "dep"), 0755)
+	os.WriteFile(filepath.Join(tmpDir, "node_modules", "dep", "index.js"), nil, 0644)
+	os.WriteFile(filepath.Join(tmpDir, "go.sum"), nil, 0644)
+	os.WriteFile(filepath.Join(tmpDir, ".git", "objects", "ab"), nil, 0644)
+
+	if err := tx.TrackNew(snap); err != nil {
+		t.Fatalf("TrackNew() error = %v", err)
+	}
+	if got := tx.Created(); len(got) != 2 {
+		t.Errorf("Created() = %v, want node_modules and go.sum", got)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	for _, name := range []string{"node_modules", "go.sum"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); !os.IsNotExist(err) {
+			t.Errorf("Rollback() did not remove %s", name)
+		}
+	}
+	for _, name := range []string{"existing.txt", ".git/objects/ab"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); err != nil {
+			t.Errorf("Rollback() removed %s", name)
+		}
+	}
+}
@@ -3,6 +3,9 @@ package ui
 import (
 	"fmt"
 	"os"
+	"strings"
+
+	"golang.org/x/term"
 )
 
 // ANSI colors
@@ -53,3 +56,32 @@ func Summary(lines ...string) {
 	fmt.Printf("%s╚═══════════════════════════════════════════════════════════╝%s\n", Green, Reset)
 	fmt.Println()
 }
+
+// Diff prints a unified diff with added lines in green, removed lines in red
+// and hunk headers in blue
+func Diff(diff string) {
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if line == "" {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			fmt.Print(line)
+		case strings.HasPrefix(line, "+"):
+			fmt.Printf("%s%s%s\n", Green, strings.TrimSuffix(line, "\n"), Reset)
+		case strings.HasPrefix(line, "-"):
+			fmt.Printf("%s%s%s\n", Red, strings.TrimSuffix(line, "\n"), Reset)
+		case strings.HasPrefix(line, "@@"):
+			fmt.Printf("%s%s%s\n", Blue, strings.TrimSuffix(line, "\n"), Reset)
+		default:
+			fmt.Print(line)
+		}
+	}
+}
+
+// StdinIsTerminal reports whether stdin is attached to a terminal rather than
+// a pipe, a file or a device such as /dev/null, i.e. whether prompting can
+// expect an answer
+func StdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
@@ -199,3 +199,19 @@ func TestIntegration_MultipleOutputs(t *testing.T) {
 		t.Errorf("Expected at least 2 box characters, got %d", boxCount)
 	}
 }
+
+func TestDiff(t *testing.T) {
+	output := captureStdout(func() {
+		Diff("--- a/x\n+++ b/x\n@@ -1 +1 @@\n-old\n+new\n")
+	})
+
+	if !strings.Contains(output, Red+"-old"+Reset) {
+		t.Errorf("Diff() should color removed lines red: %q", output)
+	}
+	if !strings.Contains(output, Green+"+new"+Reset) {
+		t.Errorf("Diff() should color added lines green: %q", output)
+	}
+	if !strings.Contains(output, "--- a/x\n+++ b/x\n") {
+		t.Errorf("Diff() should print file headers uncolored: %q", output)
+	}
+}
@@ -74,3 +74,49 @@ func ContainsShellMetachars(s string) bool {
 	}
 	return false
 }
+
+// ValidateBranchName checks a branch name against the rules of
+// "git check-ref-format --branch", so it can be rejected before any
+// repository is created.
+func ValidateBranchName(name string) error {
+	if name == "" {
+		return fmt.Errorf("branch name cannot be empty")
+	}
+	if name == "@" {
+		return fmt.Errorf("branch name cannot be '@'")
+	}
+	if strings.HasPrefix(name, "-") {
+		return fmt.Errorf("branch name cannot start with '-'")
+	}
+	if strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/") {
+		return fmt.Errorf("branch name cannot start or end with '/'")
+	}
+	if strings.HasSuffix(name, ".") {
+		return fmt.Errorf("branch name cannot end with '.'")
+	}
+	for _, seq := range []string{"..", "//", "@{"} {
+		if strings.Contains(name, seq) {
+			return fmt.Errorf("branch name cannot contain %q", seq)
+		}
+	}
+
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f {
+			return fmt.Errorf("branch name cannot contain control characters")
+		}
+		if strings.ContainsRune(" ~^:?*[\\", r) {
+			return fmt.Errorf("branch name cannot contain %q", r)
+		}
+	}
+
+	for _, component := range strings.Split(name, "/") {
+		if strings.HasPrefix(component, ".") {
+			return fmt.Errorf("branch name components cannot start with '.'")
+		}
+		if strings.HasSuffix(component, ".lock") {
+			return fmt.Errorf("branch name components cannot end with '.lock'")
+		}
+	}
+
+	return nil
+}
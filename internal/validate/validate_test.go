@@ -306,3 +306,23 @@ func TestContainsShellMetachars(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateBranchName(t *testing.T) {
+	valid := []string{"main", "master", "develop", "feature/login", "release-1.0", "v2_x", "user@host"}
+	for _, name := range valid {
+		if err := ValidateBranchName(name); err != nil {
+			t.Errorf("ValidateBranchName(%q) = %v, want nil", name, err)
+		}
+	}
+
+	invalid := []string{
+		"", "@", "-main", "/main", "main/", "main.", "a..b", "a//b", "a@{1}",
+		"has space", "a~1", "a^", "a:b", "a?", "a*", "a[b", "a\\b", "a\tb",
+		".hidden", "feature/.hidden", "main.lock", "feature/x.lock",
+	}
+	for _, name := range invalid {
+		if err := ValidateBranchName(name); err == nil {
+			t.Errorf("ValidateBranchName(%q) = nil, want error", name)
+		}
+	}
+}
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
 	"maajise/cmd"
 	"maajise/internal/ui"
+	"maajise/templates"
 )
 
 const VERSION = "2.0.0"
@@ -20,6 +22,12 @@ func main() {
 
 	cmdName := os.Args[1]
 
+	// Custom templates replace built-ins of the same name, so they load once
+	// every built-in has registered
+	if err := templates.LoadCustomTemplates(templates.DefaultCustomTemplatesDir()); err != nil {
+		ui.Warn(fmt.Sprintf("Could not load custom templates: %v", err))
+	}
+
 	// Handle help flags
 	if cmdName == "-h" || cmdName == "--help" || cmdName == "help" {
 		printUsage()
@@ -64,6 +72,10 @@ func main() {
 
 	// Execute the command with remaining args
 	if err := command.Run(os.Args[2:]); err != nil {
+		// An interrupted command has already said so and cleaned up
+		if errors.Is(err, cmd.ErrInterrupted) {
+			os.Exit(130)
+		}
 		ui.Error(fmt.Sprintf("Error: %v", err))
 		os.Exit(1)
 	}
@@ -85,7 +97,7 @@ Commands:
 		commands []string
 	}{
 		{"Project Setup", []string{"init", "add", "update"}},
-		{"Project Info", []string{"status", "validate", "templates"}},
+		{"Project Info", []string{"status", "validate", "templates", "template"}},
 		{"System", []string{"doctor"}},
 		{"Help", []string{"help", "version"}},
 	}
@@ -1,6 +1,10 @@
 package templates
 
-import "fmt"
+import (
+	"fmt"
+
+	"maajise/internal/managed"
+)
 
 func init() {
 	Register(&BaseTemplate{})
@@ -23,8 +27,8 @@ func (t *BaseTemplate) Dependencies() []string {
 
 func (t *BaseTemplate) Files(projectName string) map[string]string {
 	return map[string]string{
-		".gitignore": t.gitignore(),
-		".ubsignore": t.ubsignore(),
+		".gitignore": managed.Wrap(t.Name(), t.gitignore()),
+		".ubsignore": managed.Wrap(t.Name(), t.ubsignore()),
 		"README.md":  t.readme(projectName),
 	}
 }
@@ -102,6 +106,7 @@ obj/
 .vscode/
 .idea/
 .beads/
+.maajise/
 .claude/
 
 # Documentation & metadata
@@ -206,6 +211,6 @@ ubs . --fail-on-warning
 
 ## License
 
-[Add license information here]
+{{if .License}}Licensed under {{.License}}. See [LICENSE](LICENSE).{{else}}[Add license information here]{{end}}
 `, projectName, projectName)
 }
@@ -0,0 +1,125 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+
+	"maajise/internal/managed"
+)
+
+func init() {
+	Register(&CTemplate{})
+}
+
+// CTemplate is a C project template built with CMake
+type CTemplate struct{}
+
+func (t *CTemplate) Name() string {
+	return "c"
+}
+
+func (t *CTemplate) Description() string {
+	return "C17 project with CMake, CTest and clang-format (src/, include/, tests/)"
+}
+
+func (t *CTemplate) Dependencies() []string {
+	return []string{"git", "br", "cmake", "cc"}
+}
+
+func (t *CTemplate) Hooks() []Hook {
+	return nativeHooks()
+}
+
+func (t *CTemplate) Files(projectName string) map[string]string {
+	ident := nativeIdent(projectName)
+	return map[string]string{
+		".gitignore":     managed.Wrap(t.Name(), nativeGitignore()),
+		".ubsignore":     managed.Wrap(t.Name(), nativeUbsignore()),
+		".clang-format":  nativeClangFormat(),
+		"README.md":      nativeReadme(projectName, "C"),
+		"CMakeLists.txt": t.cmakeLists(projectName),
+		"include/" + projectName + "/" + ident + ".h": t.header(ident),
+		"src/" + ident + ".c":                         t.source(projectName, ident),
+		"src/main.c":                                  t.main(projectName, ident),
+		"tests/" + ident + "_test.c":                  t.test(projectName, ident),
+	}
+}
+
+func (t *CTemplate) cmakeLists(projectName string) string {
+	ident := nativeIdent(projectName)
+	return fmt.Sprintf(`cmake_minimum_required(VERSION 3.20)
+project(%[1]s VERSION 0.1.0 LANGUAGES C)
+
+set(CMAKE_C_STANDARD 17)
+set(CMAKE_C_STANDARD_REQUIRED ON)
+set(CMAKE_C_EXTENSIONS OFF)
+set(CMAKE_EXPORT_COMPILE_COMMANDS ON)
+
+if(MSVC)
+    add_compile_options(/W4)
+else()
+    add_compile_options(-Wall -Wextra -Wpedantic)
+endif()
+
+add_library(%[2]s_lib src/%[2]s.c)
+target_include_directories(%[2]s_lib PUBLIC ${PROJECT_SOURCE_DIR}/include)
+
+add_executable(%[1]s src/main.c)
+target_link_libraries(%[1]s PRIVATE %[2]s_lib)
+
+include(CTest)
+if(BUILD_TESTING)
+    add_executable(%[2]s_test tests/%[2]s_test.c)
+    target_link_libraries(%[2]s_test PRIVATE %[2]s_lib)
+    add_test(NAME %[2]s_test COMMAND %[2]s_test)
+endif()
+`, projectName, ident)
+}
+
+func (t *CTemplate) header(ident string) string {
+	guard := strings.ToUpper(ident) + "_" + strings.ToUpper(ident) + "_H"
+	return fmt.Sprintf(`#ifndef %[1]s
+#define %[1]s
+
+const char* %[2]s_greeting(void);
+
+#endif // %[1]s
+`, guard, ident)
+}
+
+func (t *CTemplate) source(projectName, ident string) string {
+	return fmt.Sprintf(`#include "%[1]s/%[2]s.h"
+
+const char* %[2]s_greeting(void) { return "Hello, C!"; }
+`, projectName, ident)
+}
+
+func (t *CTemplate) main(projectName, ident string) string {
+	return fmt.Sprintf(`#include <stdio.h>
+
+#include "%[1]s/%[2]s.h"
+
+int main(void) {
+    puts(%[2]s_greeting());
+    // TODO: Add your application code here
+    return 0;
+}
+`, projectName, ident)
+}
+
+func (t *CTemplate) test(projectName, ident string) string {
+	return fmt.Sprintf(`#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+#include "%[1]s/%[2]s.h"
+
+int main(void) {
+    if (strcmp(%[2]s_greeting(), "Hello, C!") != 0) {
+        fprintf(stderr, "%[2]s_greeting() returned an unexpected value\n");
+        return EXIT_FAILURE;
+    }
+    return EXIT_SUCCESS;
+}
+`, projectName, ident)
+}
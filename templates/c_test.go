@@ -0,0 +1,45 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCTemplate(t *testing.T) {
+	tmpl, ok := Get("c")
+	if !ok {
+		t.Fatal("c template not registered")
+	}
+
+	files := tmpl.Files("ringbuf")
+
+	expectedFiles := []string{
+		".gitignore",
+		".ubsignore",
+		".clang-format",
+		"README.md",
+		"CMakeLists.txt",
+		"include/ringbuf/ringbuf.h",
+		"src/ringbuf.c",
+		"src/main.c",
+		"tests/ringbuf_test.c",
+	}
+	if len(files) != len(expectedFiles) {
+		t.Fatalf("Files() returned %d files, want %d", len(files), len(expectedFiles))
+	}
+	for _, f := range expectedFiles {
+		if _, ok := files[f]; !ok {
+			t.Errorf("missing file: %s", f)
+		}
+	}
+
+	if !strings.Contains(files["CMakeLists.txt"], "project(ringbuf VERSION 0.1.0 LANGUAGES C)") {
+		t.Error("CMakeLists.txt should declare a C project")
+	}
+	if !strings.Contains(files["include/ringbuf/ringbuf.h"], "#ifndef RINGBUF_RINGBUF_H") {
+		t.Error("header should have an include guard")
+	}
+	if !strings.Contains(files[".ubsignore"], "build/") {
+		t.Error(".ubsignore should skip the build directory")
+	}
+}
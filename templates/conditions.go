@@ -0,0 +1,107 @@
+package templates
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Conditional is implemented by templates that leave files out depending on
+// the variables. Conditions maps a file path, or a directory ending in "/",
+// to a condition such as "{{.Answers.docker}}"; files whose condition renders
+// false are not generated.
+type Conditional interface {
+	Conditions() map[string]string
+}
+
+// ConditionsOf returns the file conditions of a template, if any
+func ConditionsOf(tmpl Template) map[string]string {
+	if c, ok := tmpl.(Conditional); ok {
+		return c.Conditions()
+	}
+	return nil
+}
+
+// FileEntry is a file of a custom template. In YAML it is either the content
+// itself or a mapping with the content, a when condition, an octal mode and
+// raw, which writes the content as is instead of rendering it:
+//
+//	Dockerfile:
+//	  when: "{{.Answers.docker}}"
+//	  content: |
+//	    FROM alpine
+//	scripts/setup:
+//	  mode: "0755"
+//	  content: ...
+//	.github/workflows/ci.yml:
+//	  raw: true
+//	  content: |
+//	    if: ${{ github.event_name == 'push' }}
+type FileEntry struct {
+	Content string `yaml:"content"`
+	When    string `yaml:"when,omitempty"`
+	Mode    string `yaml:"mode,omitempty"`
+	Raw     bool   `yaml:"raw,omitempty"`
+}
+
+// MarshalYAML writes the plain form when there is no condition, mode or raw
+func (f FileEntry) MarshalYAML() (interface{}, error) {
+	if f.When == "" && f.Mode == "" && !f.Raw {
+		return f.Content, nil
+	}
+	type plain FileEntry
+	return plain(f), nil
+}
+
+// UnmarshalYAML accepts both the plain and the mapping form
+func (f *FileEntry) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&f.Content)
+	}
+	type plain FileEntry
+	return node.Decode((*plain)(f))
+}
+
+// Conditions returns the inherited conditions merged with the template's own
+func (t *CustomTemplate) Conditions() map[string]string {
+	return t.resolveConditions(nil)
+}
+
+// resolveConditions is Conditions with cycle detection through seen
+func (t *CustomTemplate) resolveConditions(seen []string) map[string]string {
+	result := make(map[string]string)
+	if t.extends != "" {
+		if parent, err := parentTemplate(t.extends, append(seen, t.name)); err == nil {
+			var inherited map[string]string
+			if custom, ok := parent.(*CustomTemplate); ok {
+				inherited = custom.resolveConditions(append(seen, t.name))
+			} else {
+				inherited = ConditionsOf(parent)
+			}
+			for path, condition := range inherited {
+				result[path] = condition
+			}
+		}
+	}
+
+	for path, condition := range t.conditions {
+		result[path] = condition
+	}
+	return result
+}
+
+// included reports whether the file at path (before rendering) is generated.
+// Every condition on the file and on the directories containing it must hold.
+func included(path string, conditions map[string]string, vars TemplateVars) (bool, error) {
+	for key, condition := range conditions {
+		dir := strings.TrimSuffix(key, "/")
+		if path != key && !strings.HasPrefix(path, dir+"/") {
+			continue
+		}
+		ok, err := EvalCondition(condition, vars)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,163 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRender_Conditions(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-conditions-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "conditional.yaml")
+	os.WriteFile(path, []byte(`name: conditional
+files:
+  README.md: "# {{.ProjectName}}"
+  Dockerfile:
+    when: "{{.Answers.docker}}"
+    content: |
+      FROM alpine
+  tests/main_test.go: "package main"
+  tests/data/fixture.txt: "data"
+when:
+  tests/: "{{.Answers.tests}}"
+`), 0644)
+	if err := loadCustomTemplate(path); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, _ := Get("conditional")
+
+	vars := DefaultVars("app")
+	vars.Answers = map[string]any{"docker": false, "tests": true}
+	files, err := Render(tmpl, vars)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if _, ok := files["Dockerfile"]; ok {
+		t.Error("Dockerfile should be left out when docker is false")
+	}
+	for _, name := range []string{"README.md", "tests/main_test.go", "tests/data/fixture.txt"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Render() missing %s", name)
+		}
+	}
+
+	vars.Answers = map[string]any{"docker": true, "tests": false}
+	files, err = Render(tmpl, vars)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if files["Dockerfile"] != "FROM alpine\n" {
+		t.Errorf("Dockerfile = %q", files["Dockerfile"])
+	}
+	if len(files) != 2 {
+		t.Errorf("Render() = %v, want README.md and Dockerfile only", files)
+	}
+}
+
+func TestRender_RawFiles(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-conditions-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "raw.yaml")
+	os.WriteFile(path, []byte(`name: raw-files
+files:
+  README.md: "# {{.ProjectName}}"
+  .github/workflows/{{.ProjectName}}.yml:
+    raw: true
+    content: "if: ${{ github.event_name == 'push' }}\n"
+`), 0644)
+	if err := loadCustomTemplate(path); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, _ := Get("raw-files")
+
+	files, err := Render(tmpl, DefaultVars("app"))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got := files[".github/workflows/app.yml"]; got != "if: ${{ github.event_name == 'push' }}\n" {
+		t.Errorf("raw file = %q, want its content as is and its path rendered", got)
+	}
+	if issues := Lint(path); len(issues) != 0 {
+		t.Errorf("Lint() = %v, want raw content not checked", issues)
+	}
+}
+
+func TestCustomTemplate_InheritedConditions(t *testing.T) {
+	parent := &CustomTemplate{
+		name:       "cond-parent",
+		files:      map[string]string{"Dockerfile": "FROM alpine\n", "ci.yml": "ci"},
+		conditions: map[string]string{"Dockerfile": "{{.docker}}", "ci.yml": "{{.ci}}"},
+	}
+	Register(parent)
+	child := &CustomTemplate{
+		name:       "cond-child",
+		extends:    "cond-parent",
+		conditions: map[string]string{"ci.yml": "yes"},
+	}
+
+	vars := DefaultVars("app")
+	vars.Set("docker", "no")
+	vars.Set("ci", "no")
+	files, err := Render(child, vars)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if _, ok := files["Dockerfile"]; ok {
+		t.Error("the inherited condition should leave Dockerfile out")
+	}
+	if _, ok := files["ci.yml"]; !ok {
+		t.Error("the child's condition should replace the inherited one for ci.yml")
+	}
+}
+
+// conditionalTemplate is a built-in style template using Conditional
+type conditionalTemplate struct{ BaseTemplate }
+
+func (conditionalTemplate) Conditions() map[string]string {
+	return map[string]string{"LICENSE": "{{.License}}"}
+}
+
+func (conditionalTemplate) Files(projectName string) map[string]string {
+	return map[string]string{"README.md": "# " + projectName, "LICENSE": "{{.License}}"}
+}
+
+func TestRender_BuiltinConditions(t *testing.T) {
+	vars := DefaultVars("app")
+	vars.License = ""
+
+	files, err := Render(&conditionalTemplate{}, vars)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if _, ok := files["LICENSE"]; ok {
+		t.Error("LICENSE should be left out without a license")
+	}
+	if _, ok := files["README.md"]; !ok {
+		t.Error("README.md has no condition and should be rendered")
+	}
+
+	if _, err := Render(&conditionalTemplate{}, DefaultVars("app")); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+}
+
+func TestRender_ConditionError(t *testing.T) {
+	tmpl := &CustomTemplate{
+		name:       "cond-error",
+		files:      map[string]string{"Dockerfile": "FROM alpine\n"},
+		conditions: map[string]string{"Dockerfile": "{{.Answers.missing}}"},
+	}
+
+	if _, err := Render(tmpl, DefaultVars("app")); err == nil {
+		t.Error("Render() should fail when a condition refers to an unknown answer")
+	}
+}
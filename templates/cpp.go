@@ -0,0 +1,134 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+
+	"maajise/internal/managed"
+)
+
+func init() {
+	Register(&CppTemplate{})
+}
+
+// CppTemplate is a C++ project template built with CMake
+type CppTemplate struct{}
+
+func (t *CppTemplate) Name() string {
+	return "cpp"
+}
+
+func (t *CppTemplate) Description() string {
+	return "C++20 project with CMake, CTest and clang-format (src/, include/, tests/)"
+}
+
+func (t *CppTemplate) Dependencies() []string {
+	return []string{"git", "br", "cmake", "c++"}
+}
+
+func (t *CppTemplate) Hooks() []Hook {
+	return nativeHooks()
+}
+
+func (t *CppTemplate) Files(projectName string) map[string]string {
+	ident := nativeIdent(projectName)
+	return map[string]string{
+		".gitignore":     managed.Wrap(t.Name(), nativeGitignore()),
+		".ubsignore":     managed.Wrap(t.Name(), nativeUbsignore()),
+		".clang-format":  nativeClangFormat(),
+		"README.md":      nativeReadme(projectName, "C++"),
+		"CMakeLists.txt": t.cmakeLists(projectName),
+		"include/" + projectName + "/" + ident + ".hpp": t.header(ident),
+		"src/" + ident + ".cpp":                         t.source(projectName, ident),
+		"src/main.cpp":                                  t.main(projectName, ident),
+		"tests/" + ident + "_test.cpp":                  t.test(projectName, ident),
+	}
+}
+
+func (t *CppTemplate) cmakeLists(projectName string) string {
+	ident := nativeIdent(projectName)
+	return fmt.Sprintf(`cmake_minimum_required(VERSION 3.20)
+project(%[1]s VERSION 0.1.0 LANGUAGES CXX)
+
+set(CMAKE_CXX_STANDARD 20)
+set(CMAKE_CXX_STANDARD_REQUIRED ON)
+set(CMAKE_CXX_EXTENSIONS OFF)
+set(CMAKE_EXPORT_COMPILE_COMMANDS ON)
+
+if(MSVC)
+    add_compile_options(/W4)
+else()
+    add_compile_options(-Wall -Wextra -Wpedantic)
+endif()
+
+add_library(%[2]s_lib src/%[2]s.cpp)
+target_include_directories(%[2]s_lib PUBLIC ${PROJECT_SOURCE_DIR}/include)
+
+add_executable(%[1]s src/main.cpp)
+target_link_libraries(%[1]s PRIVATE %[2]s_lib)
+
+include(CTest)
+if(BUILD_TESTING)
+    add_executable(%[2]s_test tests/%[2]s_test.cpp)
+    target_link_libraries(%[2]s_test PRIVATE %[2]s_lib)
+    add_test(NAME %[2]s_test COMMAND %[2]s_test)
+endif()
+`, projectName, ident)
+}
+
+func (t *CppTemplate) header(ident string) string {
+	guard := strings.ToUpper(ident) + "_" + strings.ToUpper(ident) + "_HPP"
+	return fmt.Sprintf(`#ifndef %[1]s
+#define %[1]s
+
+#include <string>
+
+namespace %[2]s {
+
+std::string greeting();
+
+} // namespace %[2]s
+
+#endif // %[1]s
+`, guard, ident)
+}
+
+func (t *CppTemplate) source(projectName, ident string) string {
+	return fmt.Sprintf(`#include "%s/%s.hpp"
+
+namespace %s {
+
+std::string greeting() { return "Hello, C++!"; }
+
+} // namespace %s
+`, projectName, ident, ident, ident)
+}
+
+func (t *CppTemplate) main(projectName, ident string) string {
+	return fmt.Sprintf(`#include <iostream>
+
+#include "%s/%s.hpp"
+
+int main() {
+    std::cout << %s::greeting() << '\n';
+    // TODO: Add your application code here
+    return 0;
+}
+`, projectName, ident, ident)
+}
+
+func (t *CppTemplate) test(projectName, ident string) string {
+	return fmt.Sprintf(`#include <cstdlib>
+#include <iostream>
+
+#include "%s/%s.hpp"
+
+int main() {
+    if (%s::greeting() != "Hello, C++!") {
+        std::cerr << "greeting() returned an unexpected value\n";
+        return EXIT_FAILURE;
+    }
+    return EXIT_SUCCESS;
+}
+`, projectName, ident, ident)
+}
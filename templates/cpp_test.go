@@ -0,0 +1,70 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCppTemplate(t *testing.T) {
+	tmpl, ok := Get("cpp")
+	if !ok {
+		t.Fatal("cpp template not registered")
+	}
+
+	files := tmpl.Files("fast-pack")
+
+	expectedFiles := []string{
+		".gitignore",
+		".ubsignore",
+		".clang-format",
+		"README.md",
+		"CMakeLists.txt",
+		"include/fast-pack/fast_pack.hpp",
+		"src/fast_pack.cpp",
+		"src/main.cpp",
+		"tests/fast_pack_test.cpp",
+	}
+	if len(files) != len(expectedFiles) {
+		t.Fatalf("Files() returned %d files, want %d", len(files), len(expectedFiles))
+	}
+	for _, f := range expectedFiles {
+		if _, ok := files[f]; !ok {
+			t.Errorf("missing file: %s", f)
+		}
+	}
+
+	cmake := files["CMakeLists.txt"]
+	for _, want := range []string{
+		"project(fast-pack VERSION 0.1.0 LANGUAGES CXX)",
+		"set(CMAKE_EXPORT_COMPILE_COMMANDS ON)",
+		"add_test(NAME fast_pack_test COMMAND fast_pack_test)",
+	} {
+		if !strings.Contains(cmake, want) {
+			t.Errorf("CMakeLists.txt missing %q", want)
+		}
+	}
+
+	if !strings.Contains(files["include/fast-pack/fast_pack.hpp"], "namespace fast_pack {") {
+		t.Error("header should use the project name as a namespace")
+	}
+	if !strings.Contains(files["src/main.cpp"], `#include "fast-pack/fast_pack.hpp"`) {
+		t.Error("main.cpp should include the public header")
+	}
+	if !strings.Contains(files[".gitignore"], "compile_commands.json") {
+		t.Error(".gitignore should ignore compile_commands.json")
+	}
+}
+
+func TestNativeIdent(t *testing.T) {
+	tests := map[string]string{
+		"myapp":     "myapp",
+		"my-app":    "my_app",
+		"my.app v2": "my_app_v2",
+		"3d-engine": "_3d_engine",
+	}
+	for name, want := range tests {
+		if got := nativeIdent(name); got != want {
+			t.Errorf("nativeIdent(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -86,3 +86,165 @@ func TestDefaultCustomTemplatesDir(t *testing.T) {
 func TestCustomTemplate_Implementation(t *testing.T) {
 	var _ Template = &CustomTemplate{}
 }
+
+// writeTemplate writes a custom template file and loads it
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name+".yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadCustomTemplate(path); err != nil {
+		t.Fatalf("loadCustomTemplate() error = %v", err)
+	}
+}
+
+func TestCustomTemplate_Extends(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-custom-template-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	writeTemplate(t, tmpDir, "org-go", `name: org-go
+extends: go
+dependencies:
+  - make
+files:
+  Makefile: |
+    build:
+    	go build ./...
+append:
+  .gitignore: |
+    /coverage/
+delete:
+  - .ubsignore
+`)
+
+	tmpl, ok := Get("org-go")
+	if !ok {
+		t.Fatal("org-go not registered")
+	}
+	if err := Resolve(tmpl); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	files := tmpl.Files("svc")
+	goFiles := (&GoTemplate{}).Files("svc")
+
+	// Inherited unchanged
+	if files["go.mod"] != goFiles["go.mod"] {
+		t.Error("go.mod should be inherited from the go template")
+	}
+	// Added
+	if !strings.Contains(files["Makefile"], "go build") {
+		t.Error("Makefile from the custom template is missing")
+	}
+	// Deleted
+	if _, ok := files[".ubsignore"]; ok {
+		t.Error(".ubsignore should be deleted")
+	}
+	// Appended in its own managed block after the inherited one
+	if !strings.HasPrefix(files[".gitignore"], goFiles[".gitignore"]) {
+		t.Error(".gitignore should start with the inherited content")
+	}
+	if !strings.Contains(files[".gitignore"], "# >>> maajise:org-go\n/coverage/\n") {
+		t.Errorf(".gitignore append should be a managed block:\n%s", files[".gitignore"])
+	}
+
+	deps := tmpl.Dependencies()
+	if !contains(deps, "go") || !contains(deps, "make") {
+		t.Errorf("Dependencies() = %v, want go's dependencies plus make", deps)
+	}
+}
+
+func TestCustomTemplate_ExtendsCustom(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-custom-template-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	writeTemplate(t, tmpDir, "org-base", `name: org-base
+extends: base
+files:
+  CODEOWNERS: "* @org/team\n"
+`)
+	writeTemplate(t, tmpDir, "org-service", `name: org-service
+extends: org-base
+files:
+  README.md: "# {{.ProjectName}} service\n"
+`)
+
+	tmpl, _ := Get("org-service")
+	files := tmpl.Files("billing")
+
+	if files["CODEOWNERS"] != "* @org/team\n" {
+		t.Error("CODEOWNERS should be inherited through org-base")
+	}
+	if files["README.md"] != "# billing service\n" {
+		t.Errorf("README.md = %q, want the override", files["README.md"])
+	}
+	if _, ok := files[".gitignore"]; !ok {
+		t.Error(".gitignore should be inherited from base")
+	}
+}
+
+func TestCustomTemplate_ExtendsErrors(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-custom-template-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	writeTemplate(t, tmpDir, "orphan", "name: orphan\nextends: does-not-exist\n")
+	writeTemplate(t, tmpDir, "loop-a", "name: loop-a\nextends: loop-b\n")
+	writeTemplate(t, tmpDir, "loop-b", "name: loop-b\nextends: loop-a\n")
+
+	orphan, _ := Get("orphan")
+	if err := Resolve(orphan); err == nil || !strings.Contains(err.Error(), "does-not-exist") {
+		t.Errorf("Resolve() error = %v, want unknown template error", err)
+	}
+
+	loop, _ := Get("loop-a")
+	if err := Resolve(loop); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("Resolve() error = %v, want cycle error", err)
+	}
+	// Files must not recurse forever on a cycle
+	loop.Files("x")
+}
+
+func TestLoadDir(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-loaddir-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if _, err := LoadDir(tmpDir); err == nil {
+		t.Error("LoadDir() should fail without a template.yaml")
+	}
+
+	os.WriteFile(filepath.Join(tmpDir, ManifestFile), []byte(`name: go
+description: Shared service template
+extends: base
+files:
+  Makefile: "build:\n"
+`), 0644)
+
+	tmpl, err := LoadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadDir() error = %v", err)
+	}
+	if tmpl.Name() != "go" || tmpl.Description() != "Shared service template" {
+		t.Errorf("LoadDir() = %s (%s)", tmpl.Name(), tmpl.Description())
+	}
+	if _, ok := tmpl.Files("app")["Makefile"]; !ok {
+		t.Error("LoadDir() template is missing its files")
+	}
+
+	// Not registered, so it can't replace the built-in of the same name
+	if registered, _ := Get("go"); registered == Template(tmpl) {
+		t.Error("LoadDir() should not register the template")
+	}
+}
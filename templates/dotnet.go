@@ -0,0 +1,351 @@
+package templates
+
+import (
+	"crypto/md5"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"maajise/internal/managed"
+)
+
+func init() {
+	Register(&DotnetTemplate{})
+}
+
+// DotnetTemplate is a C# solution with an application and an xUnit test project
+type DotnetTemplate struct{}
+
+// dotnetFramework is the target framework of the generated projects
+const dotnetFramework = "net8.0"
+
+// Project type GUIDs Visual Studio uses in .sln files
+const (
+	slnCSharpProject = "9A19103F-16F7-4668-BE54-9A1E7A4F7556"
+	slnFolder        = "2150E333-8FDC-42A3-9474-1A3956D46DE8"
+)
+
+func (t *DotnetTemplate) Name() string {
+	return "dotnet"
+}
+
+func (t *DotnetTemplate) Description() string {
+	return "C# (.NET 8) solution with src/ and xUnit tests/ projects"
+}
+
+func (t *DotnetTemplate) Dependencies() []string {
+	return []string{"git", "br", "dotnet"}
+}
+
+func (t *DotnetTemplate) Hooks() []Hook {
+	return []Hook{
+		{Name: "Restore NuGet packages", Run: "dotnet restore", Optional: true},
+	}
+}
+
+func (t *DotnetTemplate) Files(projectName string) map[string]string {
+	name := pascalName(projectName)
+	tests := name + ".Tests"
+	return map[string]string{
+		".gitignore":                               managed.Wrap(t.Name(), t.gitignore()),
+		".ubsignore":                               managed.Wrap(t.Name(), t.ubsignore()),
+		"README.md":                                t.readme(projectName, name),
+		"Directory.Build.props":                    t.buildProps(),
+		name + ".sln":                              t.solution(name),
+		"src/" + name + "/" + name + ".csproj":     t.csproj(),
+		"src/" + name + "/Program.cs":              t.program(name),
+		"src/" + name + "/Greeter.cs":              t.greeter(name),
+		"tests/" + tests + "/" + tests + ".csproj": t.testCsproj(name),
+		"tests/" + tests + "/GreeterTests.cs":      t.greeterTests(name),
+	}
+}
+
+// pascalName turns a kebab-case project name into a PascalCase type or
+// module name, e.g. billing-api becomes BillingApi
+func pascalName(projectName string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range projectName {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if b.Len() == 0 && unicode.IsDigit(r) {
+			b.WriteRune('_')
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "App"
+	}
+	return b.String()
+}
+
+// slnGUID derives a stable project GUID from a name, so regenerating the
+// solution during update doesn't change it
+func slnGUID(name string) string {
+	sum := md5.Sum([]byte("maajise/" + name))
+	return strings.ToUpper(fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16]))
+}
+
+func (t *DotnetTemplate) gitignore() string {
+	return `# Build results
+[Dd]ebug/
+[Rr]elease/
+x64/
+x86/
+[Aa][Rr][Mm]/
+[Aa][Rr][Mm]64/
+bld/
+[Bb]in/
+[Oo]bj/
+[Ll]og/
+[Ll]ogs/
+artifacts/
+
+# Visual Studio
+.vs/
+*.suo
+*.user
+*.userosscache
+*.sln.docstates
+*.rsuser
+
+# Test results
+[Tt]est[Rr]esult*/
+*.trx
+*.coverage
+*.coveragexml
+coverage*.json
+coverage*.xml
+
+# NuGet
+*.nupkg
+*.snupkg
+**/[Pp]ackages/*
+!**/[Pp]ackages/build/
+*.nuget.props
+*.nuget.targets
+project.lock.json
+project.fragment.lock.json
+
+# Rider and VS Code
+.idea/
+*.sln.iml
+.vscode/*
+!.vscode/settings.json
+!.vscode/tasks.json
+!.vscode/launch.json
+!.vscode/extensions.json
+
+# Editors
+*.swp
+*.swo
+
+# OS
+.DS_Store
+Thumbs.db
+
+# Environment
+.env
+`
+}
+
+func (t *DotnetTemplate) ubsignore() string {
+	return `# UBS Scanner Ignore File
+bin/
+obj/
+artifacts/
+TestResults/
+packages/
+.vs/
+.git/
+.vscode/
+.idea/
+.beads/
+.maajise/
+.claude/
+*.md
+*.sln
+*.csproj
+*.props
+`
+}
+
+func (t *DotnetTemplate) readme(projectName, name string) string {
+	return fmt.Sprintf(`# %[1]s
+
+A C# project targeting .NET 8.
+
+## Project Structure
+
+| Path | Purpose |
+|------|---------|
+| %[2]s.sln | Solution with both projects |
+| src/%[2]s/ | Application |
+| tests/%[2]s.Tests/ | xUnit tests |
+| Directory.Build.props | Settings shared by every project |
+
+## Development
+
+`+"```bash"+`
+# Build the solution
+dotnet build
+
+# Run the application
+dotnet run --project src/%[2]s
+
+# Run the tests
+dotnet test
+
+# Format the code
+dotnet format
+`+"```"+`
+
+## Issue Tracking
+
+`+"```bash"+`
+br list           # View issues
+br create --title "Task"  # Create issue
+`+"```"+`
+
+## Code Quality
+
+`+"```bash"+`
+ubs .             # Scan for bugs
+`+"```"+`
+`, projectName, name)
+}
+
+func (t *DotnetTemplate) buildProps() string {
+	return `<Project>
+  <PropertyGroup>
+    <TargetFramework>` + dotnetFramework + `</TargetFramework>
+    <LangVersion>latest</LangVersion>
+    <Nullable>enable</Nullable>
+    <ImplicitUsings>enable</ImplicitUsings>
+    <Version>0.1.0</Version>
+    <Authors>{{.Author}}</Authors>{{with .License}}
+    <PackageLicenseExpression>{{.}}</PackageLicenseExpression>{{end}}
+  </PropertyGroup>
+</Project>
+`
+}
+
+func (t *DotnetTemplate) solution(name string) string {
+	app := slnGUID(name)
+	tests := slnGUID(name + ".Tests")
+	src := slnGUID("src")
+	testsDir := slnGUID("tests")
+
+	var configs strings.Builder
+	for _, guid := range []string{app, tests} {
+		for _, config := range []string{"Debug", "Release"} {
+			fmt.Fprintf(&configs, "\t\t{%[1]s}.%[2]s|Any CPU.ActiveCfg = %[2]s|Any CPU\n", guid, config)
+			fmt.Fprintf(&configs, "\t\t{%[1]s}.%[2]s|Any CPU.Build.0 = %[2]s|Any CPU\n", guid, config)
+		}
+	}
+
+	return fmt.Sprintf(`
+Microsoft Visual Studio Solution File, Format Version 12.00
+# Visual Studio Version 17
+VisualStudioVersion = 17.0.31903.59
+MinimumVisualStudioVersion = 10.0.40219.1
+Project("{%[1]s}") = "src", "src", "{%[3]s}"
+EndProject
+Project("{%[2]s}") = "%[5]s", "src\%[5]s\%[5]s.csproj", "{%[6]s}"
+EndProject
+Project("{%[1]s}") = "tests", "tests", "{%[4]s}"
+EndProject
+Project("{%[2]s}") = "%[5]s.Tests", "tests\%[5]s.Tests\%[5]s.Tests.csproj", "{%[7]s}"
+EndProject
+Global
+	GlobalSection(SolutionConfigurationPlatforms) = preSolution
+		Debug|Any CPU = Debug|Any CPU
+		Release|Any CPU = Release|Any CPU
+	EndGlobalSection
+	GlobalSection(ProjectConfigurationPlatforms) = postSolution
+%[8]s	EndGlobalSection
+	GlobalSection(SolutionProperties) = preSolution
+		HideSolutionNode = FALSE
+	EndGlobalSection
+	GlobalSection(NestedProjects) = preSolution
+		{%[6]s} = {%[3]s}
+		{%[7]s} = {%[4]s}
+	EndGlobalSection
+EndGlobal
+`, slnFolder, slnCSharpProject, src, testsDir, name, app, tests, configs.String())
+}
+
+func (t *DotnetTemplate) csproj() string {
+	return `<Project Sdk="Microsoft.NET.Sdk">
+
+  <PropertyGroup>
+    <OutputType>Exe</OutputType>
+  </PropertyGroup>
+
+</Project>
+`
+}
+
+func (t *DotnetTemplate) program(name string) string {
+	return fmt.Sprintf(`using %s;
+
+Console.WriteLine(Greeter.Greeting());
+// TODO: Add your application code here
+`, name)
+}
+
+func (t *DotnetTemplate) greeter(name string) string {
+	return fmt.Sprintf(`namespace %s;
+
+public static class Greeter
+{
+    public static string Greeting() => "Hello, .NET!";
+}
+`, name)
+}
+
+func (t *DotnetTemplate) testCsproj(name string) string {
+	return fmt.Sprintf(`<Project Sdk="Microsoft.NET.Sdk">
+
+  <PropertyGroup>
+    <IsPackable>false</IsPackable>
+    <IsTestProject>true</IsTestProject>
+  </PropertyGroup>
+
+  <ItemGroup>
+    <PackageReference Include="Microsoft.NET.Test.Sdk" Version="17.11.1" />
+    <PackageReference Include="xunit" Version="2.9.2" />
+    <PackageReference Include="xunit.runner.visualstudio" Version="2.8.2" />
+  </ItemGroup>
+
+  <ItemGroup>
+    <Using Include="Xunit" />
+  </ItemGroup>
+
+  <ItemGroup>
+    <ProjectReference Include="..\..\src\%[1]s\%[1]s.csproj" />
+  </ItemGroup>
+
+</Project>
+`, name)
+}
+
+func (t *DotnetTemplate) greeterTests(name string) string {
+	return fmt.Sprintf(`namespace %[1]s.Tests;
+
+public class GreeterTests
+{
+    [Fact]
+    public void GreetingSaysHello()
+    {
+        Assert.Equal("Hello, .NET!", Greeter.Greeting());
+    }
+}
+`, name)
+}
@@ -0,0 +1,102 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDotnetTemplate(t *testing.T) {
+	tmpl, ok := Get("dotnet")
+	if !ok {
+		t.Fatal("dotnet template not registered")
+	}
+
+	files := tmpl.Files("billing-api")
+
+	expectedFiles := []string{
+		".gitignore",
+		".ubsignore",
+		"README.md",
+		"Directory.Build.props",
+		"BillingApi.sln",
+		"src/BillingApi/BillingApi.csproj",
+		"src/BillingApi/Program.cs",
+		"src/BillingApi/Greeter.cs",
+		"tests/BillingApi.Tests/BillingApi.Tests.csproj",
+		"tests/BillingApi.Tests/GreeterTests.cs",
+	}
+	if len(files) != len(expectedFiles) {
+		t.Fatalf("Files() returned %d files, want %d", len(files), len(expectedFiles))
+	}
+	for _, f := range expectedFiles {
+		if _, ok := files[f]; !ok {
+			t.Errorf("missing file: %s", f)
+		}
+	}
+
+	sln := files["BillingApi.sln"]
+	for _, want := range []string{
+		`"BillingApi", "src\BillingApi\BillingApi.csproj"`,
+		`"BillingApi.Tests", "tests\BillingApi.Tests\BillingApi.Tests.csproj"`,
+		"{" + slnGUID("BillingApi") + "}.Debug|Any CPU.Build.0 = Debug|Any CPU",
+	} {
+		if !strings.Contains(sln, want) {
+			t.Errorf("BillingApi.sln missing %q", want)
+		}
+	}
+
+	if !strings.Contains(files["tests/BillingApi.Tests/BillingApi.Tests.csproj"], `<ProjectReference Include="..\..\src\BillingApi\BillingApi.csproj" />`) {
+		t.Error("test project should reference the application project")
+	}
+	if !strings.Contains(files["src/BillingApi/Greeter.cs"], "namespace BillingApi;") {
+		t.Error("sources should use the PascalCase project name as namespace")
+	}
+	if !strings.Contains(files[".gitignore"], "[Oo]bj/") {
+		t.Error(".gitignore should ignore obj/")
+	}
+}
+
+func TestDotnetTemplate_Render(t *testing.T) {
+	tmpl, _ := Get("dotnet")
+
+	vars := DefaultVars("billing-api")
+	vars.Author = "Jane Doe"
+	vars.License = "MIT"
+	files, err := Render(tmpl, vars)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	props := files["Directory.Build.props"]
+	if !strings.Contains(props, "<Authors>Jane Doe</Authors>") || !strings.Contains(props, "<PackageLicenseExpression>MIT</PackageLicenseExpression>") {
+		t.Errorf("Directory.Build.props should carry the author and license:\n%s", props)
+	}
+}
+
+func TestPascalName(t *testing.T) {
+	tests := map[string]string{
+		"billing-api":    "BillingApi",
+		"myapp":          "Myapp",
+		"my_cool.tool":   "MyCoolTool",
+		"already-Pascal": "AlreadyPascal",
+		"2fa-service":    "_2faService",
+		"---":            "App",
+	}
+	for name, want := range tests {
+		if got := pascalName(name); got != want {
+			t.Errorf("pascalName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestSlnGUID(t *testing.T) {
+	guid := slnGUID("BillingApi")
+	if guid != slnGUID("BillingApi") {
+		t.Error("slnGUID() should be stable")
+	}
+	if guid == slnGUID("BillingApi.Tests") {
+		t.Error("slnGUID() should differ between projects")
+	}
+	if len(guid) != 36 || strings.Count(guid, "-") != 4 || guid != strings.ToUpper(guid) {
+		t.Errorf("slnGUID() = %q, want an upper-case GUID", guid)
+	}
+}
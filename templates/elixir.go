@@ -0,0 +1,214 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+
+	"maajise/internal/managed"
+)
+
+func init() {
+	Register(&ElixirTemplate{})
+}
+
+// ElixirTemplate is a Mix project template laid out like `mix new`
+type ElixirTemplate struct{}
+
+func (t *ElixirTemplate) Name() string {
+	return "elixir"
+}
+
+func (t *ElixirTemplate) Description() string {
+	return "Elixir Mix project with ExUnit (lib/, test/)"
+}
+
+func (t *ElixirTemplate) Dependencies() []string {
+	return []string{"git", "br", "elixir", "mix"}
+}
+
+func (t *ElixirTemplate) Files(projectName string) map[string]string {
+	app := elixirApp(projectName)
+	return map[string]string{
+		".gitignore":                managed.Wrap(t.Name(), t.gitignore(app)),
+		".ubsignore":                managed.Wrap(t.Name(), t.ubsignore()),
+		".formatter.exs":            t.formatter(),
+		"README.md":                 t.readme(projectName),
+		"mix.exs":                   t.mixExs(projectName),
+		"lib/" + app + ".ex":        t.lib(projectName),
+		"test/test_helper.exs":      "ExUnit.start()\n",
+		"test/" + app + "_test.exs": t.test(projectName),
+	}
+}
+
+// elixirApp turns a project name into the OTP application name, e.g. my-app
+// becomes my_app
+func elixirApp(projectName string) string {
+	return strings.ToLower(nativeIdent(projectName))
+}
+
+func (t *ElixirTemplate) gitignore(app string) string {
+	return fmt.Sprintf(`# Mix build output and dependencies
+/_build/
+/deps/
+/cover/
+/doc/
+
+# Crash dumps and archives
+erl_crash.dump
+*.ez
+%s-*.tar
+
+# Temporary files, e.g. from tests
+/tmp/
+
+# ElixirLS
+/.elixir_ls/
+
+# IDE
+.vscode/
+.idea/
+*.swp
+*.swo
+
+# OS
+.DS_Store
+Thumbs.db
+
+# Environment
+.env
+`, app)
+}
+
+func (t *ElixirTemplate) ubsignore() string {
+	return `# UBS Scanner Ignore File
+_build/
+deps/
+cover/
+doc/
+tmp/
+.elixir_ls/
+.git/
+.vscode/
+.idea/
+.beads/
+.maajise/
+.claude/
+*.md
+mix.lock
+erl_crash.dump
+`
+}
+
+func (t *ElixirTemplate) readme(projectName string) string {
+	return fmt.Sprintf(`# %s
+
+An Elixir project.
+
+## Setup
+
+`+"```bash"+`
+# Fetch dependencies
+mix deps.get
+`+"```"+`
+
+## Development
+
+`+"```bash"+`
+# Compile
+mix compile
+
+# Run the tests
+mix test
+
+# Start an interactive shell with the project loaded
+iex -S mix
+
+# Format the code
+mix format
+`+"```"+`
+
+## Issue Tracking
+
+`+"```bash"+`
+br list           # View issues
+br create --title "Task"  # Create issue
+`+"```"+`
+
+## Code Quality
+
+`+"```bash"+`
+ubs .             # Scan for bugs
+`+"```"+`
+`, projectName)
+}
+
+func (t *ElixirTemplate) formatter() string {
+	return `# Used by "mix format"
+[
+  inputs: ["{mix,.formatter}.exs", "{config,lib,test}/**/*.{ex,exs}"]
+]
+`
+}
+
+func (t *ElixirTemplate) mixExs(projectName string) string {
+	return fmt.Sprintf(`defmodule %s.MixProject do
+  use Mix.Project
+
+  def project do
+    [
+      app: :%s,
+      version: "0.1.0",
+      elixir: "~> 1.15",
+      start_permanent: Mix.env() == :prod,
+      deps: deps()
+    ]
+  end
+
+  # Run "mix help compile.app" to learn about applications.
+  def application do
+    [
+      extra_applications: [:logger]
+    ]
+  end
+
+  # Run "mix help deps" to learn about dependencies.
+  defp deps do
+    []
+  end
+end
+`, pascalName(projectName), elixirApp(projectName))
+}
+
+func (t *ElixirTemplate) lib(projectName string) string {
+	return fmt.Sprintf(`defmodule %[1]s do
+  @moduledoc """
+  Documentation for `+"`%[1]s`"+`.
+  """
+
+  @doc """
+  Returns a greeting.
+
+  ## Examples
+
+      iex> %[1]s.greeting()
+      "Hello, Elixir!"
+
+  """
+  def greeting do
+    "Hello, Elixir!"
+  end
+end
+`, pascalName(projectName))
+}
+
+func (t *ElixirTemplate) test(projectName string) string {
+	return fmt.Sprintf(`defmodule %[1]sTest do
+  use ExUnit.Case
+  doctest %[1]s
+
+  test "greets" do
+    assert %[1]s.greeting() == "Hello, Elixir!"
+  end
+end
+`, pascalName(projectName))
+}
@@ -0,0 +1,45 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestElixirTemplate(t *testing.T) {
+	tmpl, ok := Get("elixir")
+	if !ok {
+		t.Fatal("elixir template not registered")
+	}
+
+	files := tmpl.Files("order-service")
+
+	expectedFiles := []string{
+		".gitignore",
+		".ubsignore",
+		".formatter.exs",
+		"README.md",
+		"mix.exs",
+		"lib/order_service.ex",
+		"test/test_helper.exs",
+		"test/order_service_test.exs",
+	}
+	if len(files) != len(expectedFiles) {
+		t.Fatalf("Files() returned %d files, want %d", len(files), len(expectedFiles))
+	}
+	for _, f := range expectedFiles {
+		if _, ok := files[f]; !ok {
+			t.Errorf("missing file: %s", f)
+		}
+	}
+
+	mix := files["mix.exs"]
+	if !strings.Contains(mix, "defmodule OrderService.MixProject do") || !strings.Contains(mix, "app: :order_service,") {
+		t.Error("mix.exs should name the module and the OTP application")
+	}
+	if !strings.Contains(files["test/order_service_test.exs"], "doctest OrderService") {
+		t.Error("test should run the module's doctests")
+	}
+	if !strings.Contains(files[".gitignore"], "order_service-*.tar") {
+		t.Error(".gitignore should ignore release tarballs")
+	}
+}
@@ -0,0 +1,170 @@
+package templates
+
+import "fmt"
+
+func init() {
+	RegisterFeature(&CIFeature{})
+}
+
+// CIFeature adds a GitHub Actions workflow that builds and tests the project
+type CIFeature struct{}
+
+func (f *CIFeature) Name() string {
+	return "ci"
+}
+
+func (f *CIFeature) Description() string {
+	return "GitHub Actions workflow that builds and tests on every push"
+}
+
+func (f *CIFeature) Files(base string, vars TemplateVars) map[string]string {
+	return map[string]string{
+		".github/workflows/ci.yml": ciHeader(vars.Branch) + f.steps(base),
+	}
+}
+
+func (f *CIFeature) Gitignore(base string) []string {
+	return nil
+}
+
+// ciHeader starts a workflow that runs on pushes to the main branch and on
+// pull requests. Projects recorded before the branch was default to main.
+func ciHeader(branch string) string {
+	if branch == "" {
+		branch = "main"
+	}
+	return fmt.Sprintf(`name: CI
+
+on:
+  push:
+    branches: [%s]
+  pull_request:
+
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`, yamlQuote(branch))
+}
+
+func (f *CIFeature) steps(base string) string {
+	switch base {
+	case "go":
+		return `    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+        with:
+          go-version-file: go.mod
+      - run: go build ./...
+      - run: go vet ./...
+      - run: go test ./...
+`
+	case "typescript":
+		return `    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-node@v4
+        with:
+          node-version: 20
+      - run: npm install
+      - run: npm run build
+`
+	case "python":
+		return `    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-python@v5
+        with:
+          python-version: "3.12"
+      - run: pip install -e ".[dev]"
+      # Exit code 5 means no tests were collected yet
+      - run: pytest || [ $? -eq 5 ]
+`
+	case "rust":
+		return `    steps:
+      - uses: actions/checkout@v4
+      - uses: dtolnay/rust-toolchain@stable
+      - run: cargo build
+      - run: cargo test
+`
+	case "php":
+		return `    steps:
+      - uses: actions/checkout@v4
+      - uses: shivammathur/setup-php@v2
+        with:
+          php-version: "8.3"
+      - run: composer validate
+      - run: composer install --prefer-dist
+      - run: composer lint
+`
+	case "java", "kotlin":
+		return `    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-java@v4
+        with:
+          distribution: temurin
+          java-version: "21"
+      - uses: gradle/actions/setup-gradle@v4
+        if: hashFiles('build.gradle.kts') != ''
+      - run: gradle build
+        if: hashFiles('build.gradle.kts') != ''
+      - run: mvn -B verify
+        if: hashFiles('pom.xml') != ''
+`
+	case "c", "cpp":
+		return `    steps:
+      - uses: actions/checkout@v4
+      - run: cmake -S . -B build -DCMAKE_BUILD_TYPE=Release
+      - run: cmake --build build
+      - run: ctest --test-dir build --output-on-failure
+`
+	case "dotnet":
+		return `    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-dotnet@v4
+        with:
+          dotnet-version: 8.0.x
+      - run: dotnet restore
+      - run: dotnet build --no-restore
+      - run: dotnet test --no-build
+`
+	case "ruby":
+		return `    steps:
+      - uses: actions/checkout@v4
+      - uses: ruby/setup-ruby@v1
+        with:
+          ruby-version: "3.3"
+          bundler-cache: true
+      - run: bundle exec rake test
+`
+	case "elixir":
+		return `    steps:
+      - uses: actions/checkout@v4
+      - uses: erlef/setup-beam@v1
+        with:
+          otp-version: "27"
+          elixir-version: "1.17"
+      - run: mix deps.get
+      - run: mix format --check-formatted
+      - run: mix test
+`
+	case "zig":
+		return `    steps:
+      - uses: actions/checkout@v4
+      - uses: mlugg/setup-zig@v1
+        with:
+          version: ` + zigVersion + `
+      - run: zig build test
+`
+	case "swift":
+		return `    container: swift:6.0
+    steps:
+      - uses: actions/checkout@v4
+      - run: swift build
+      - run: swift test
+`
+	default:
+		return `    steps:
+      - uses: actions/checkout@v4
+      # TODO: Add the steps that build and test the project
+      - run: echo "No build steps configured"
+`
+	}
+}
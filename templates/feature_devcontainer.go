@@ -0,0 +1,74 @@
+package templates
+
+import "fmt"
+
+func init() {
+	RegisterFeature(&DevcontainerFeature{})
+}
+
+// DevcontainerFeature adds a Dev Container definition for VS Code and Codespaces
+type DevcontainerFeature struct{}
+
+func (f *DevcontainerFeature) Name() string {
+	return "devcontainer"
+}
+
+func (f *DevcontainerFeature) Description() string {
+	return "Dev Container for VS Code and GitHub Codespaces"
+}
+
+func (f *DevcontainerFeature) Files(base string, vars TemplateVars) map[string]string {
+	image, setup := f.environment(base)
+	content := fmt.Sprintf(`{
+  "name": "%s",
+  "image": "%s"`, vars.ProjectName, image)
+	if setup != "" {
+		content += fmt.Sprintf(`,
+  "postCreateCommand": "%s"`, setup)
+	}
+	content += "\n}\n"
+
+	return map[string]string{
+		".devcontainer/devcontainer.json": content,
+	}
+}
+
+func (f *DevcontainerFeature) Gitignore(base string) []string {
+	return nil
+}
+
+// environment returns the container image and setup command for a base template
+func (f *DevcontainerFeature) environment(base string) (string, string) {
+	switch base {
+	case "go":
+		return "mcr.microsoft.com/devcontainers/go:1", "go mod download"
+	case "typescript":
+		return "mcr.microsoft.com/devcontainers/typescript-node:20", "npm install"
+	case "python":
+		return "mcr.microsoft.com/devcontainers/python:3.12", `pip install -e '.[dev]'`
+	case "rust":
+		return "mcr.microsoft.com/devcontainers/rust:1", "cargo fetch"
+	case "php":
+		return "mcr.microsoft.com/devcontainers/php:8.3", "composer install"
+	case "swift":
+		return "swift:6.0", "swift package resolve"
+	case "java", "kotlin":
+		return "mcr.microsoft.com/devcontainers/java:21", ""
+	case "ruby":
+		return "mcr.microsoft.com/devcontainers/ruby:3.3", "bundle install"
+	case "elixir":
+		return "elixir:1.17", "mix local.hex --force && mix deps.get"
+	case "dotnet":
+		return "mcr.microsoft.com/devcontainers/dotnet:8.0", "dotnet restore"
+	case "c", "cpp":
+		return "mcr.microsoft.com/devcontainers/cpp:ubuntu", "cmake -S . -B build"
+	case "zig":
+		// No Zig image is published; install the release the build files pin
+		return "mcr.microsoft.com/devcontainers/base:ubuntu", "mkdir -p ~/.local/zig" +
+			" && curl -fsSL https://ziglang.org/download/" + zigVersion + "/zig-linux-$(uname -m)-" + zigVersion + ".tar.xz" +
+			" | tar -xJ --strip-components=1 -C ~/.local/zig" +
+			" && sudo ln -sf ~/.local/zig/zig /usr/local/bin/zig"
+	default:
+		return "mcr.microsoft.com/devcontainers/base:ubuntu", ""
+	}
+}
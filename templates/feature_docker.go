@@ -0,0 +1,257 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterFeature(&DockerFeature{})
+}
+
+// DockerFeature adds a Dockerfile and .dockerignore
+type DockerFeature struct{}
+
+func (f *DockerFeature) Name() string {
+	return "docker"
+}
+
+func (f *DockerFeature) Description() string {
+	return "Dockerfile and .dockerignore"
+}
+
+func (f *DockerFeature) Files(base string, vars TemplateVars) map[string]string {
+	return map[string]string{
+		"Dockerfile":    f.dockerfile(base, vars),
+		".dockerignore": f.dockerignore(base),
+	}
+}
+
+func (f *DockerFeature) Gitignore(base string) []string {
+	return []string{"# Docker", "docker-compose.override.yml"}
+}
+
+func (f *DockerFeature) dockerfile(base string, vars TemplateVars) string {
+	projectName := vars.ProjectName
+	switch base {
+	case "go":
+		return fmt.Sprintf(`FROM golang:1.23-alpine AS build
+WORKDIR /src
+COPY go.* ./
+RUN go mod download
+COPY . .
+RUN CGO_ENABLED=0 go build -o /out/%[1]s ./cmd/%[1]s
+
+FROM gcr.io/distroless/static-debian12
+COPY --from=build /out/%[1]s /usr/local/bin/%[1]s
+ENTRYPOINT ["/usr/local/bin/%[1]s"]
+`, projectName)
+	case "typescript":
+		return `FROM node:20-alpine AS build
+WORKDIR /app
+COPY package*.json ./
+RUN npm install
+COPY . .
+RUN npm run build
+
+FROM node:20-alpine
+WORKDIR /app
+ENV NODE_ENV=production
+COPY package*.json ./
+RUN npm install --omit=dev
+COPY --from=build /app/dist ./dist
+CMD ["node", "dist/index.js"]
+`
+	case "python":
+		return `FROM python:3.12-slim
+WORKDIR /app
+ENV PYTHONDONTWRITEBYTECODE=1 PYTHONUNBUFFERED=1
+COPY . .
+RUN pip install --no-cache-dir .
+CMD ["python", "src/main.py"]
+`
+	case "rust":
+		return fmt.Sprintf(`FROM rust:1 AS build
+WORKDIR /src
+COPY . .
+RUN cargo build --release
+
+FROM debian:bookworm-slim
+COPY --from=build /src/target/release/%[1]s /usr/local/bin/%[1]s
+ENTRYPOINT ["/usr/local/bin/%[1]s"]
+`, projectName)
+	case "php":
+		return `FROM composer:2 AS deps
+WORKDIR /app
+COPY composer.* ./
+RUN composer install --no-dev --no-scripts --prefer-dist
+
+FROM php:8.3-cli
+WORKDIR /app
+COPY . .
+COPY --from=deps /app/vendor ./vendor
+EXPOSE 8000
+CMD ["php", "-S", "0.0.0.0:8000", "-t", "public"]
+`
+	case "swift":
+		return fmt.Sprintf(`FROM swift:6.0 AS build
+WORKDIR /src
+COPY . .
+RUN swift build -c release --static-swift-stdlib
+
+FROM ubuntu:24.04
+COPY --from=build /src/.build/release/%[1]s /usr/local/bin/%[1]s
+ENTRYPOINT ["/usr/local/bin/%[1]s"]
+`, projectName)
+	case "dotnet":
+		return fmt.Sprintf(`FROM mcr.microsoft.com/dotnet/sdk:8.0 AS build
+WORKDIR /src
+COPY . .
+RUN dotnet publish src/%[1]s -c Release -o /out
+
+FROM mcr.microsoft.com/dotnet/runtime:8.0
+WORKDIR /app
+COPY --from=build /out .
+ENTRYPOINT ["dotnet", "%[1]s.dll"]
+`, pascalName(projectName))
+	case "java":
+		return jvmDockerfile(vars, "App")
+	case "kotlin":
+		return jvmDockerfile(vars, "MainKt")
+	case "ruby":
+		// A gem has no entry point of its own; the command loads it
+		return fmt.Sprintf(`FROM ruby:3.3 AS build
+WORKDIR /app
+COPY . .
+RUN bundle install --jobs 4
+
+FROM ruby:3.3-slim
+WORKDIR /app
+COPY --from=build /usr/local/bundle /usr/local/bundle
+COPY --from=build /app .
+CMD ["bundle", "exec", "ruby", "-r", "./lib/%s", "-e", "puts %s.greeting"]
+`, rubyPath(projectName), strings.Join(rubyModules(projectName), "::"))
+	case "elixir":
+		return fmt.Sprintf(`FROM elixir:1.17 AS build
+ENV MIX_ENV=prod
+WORKDIR /src
+RUN mix local.hex --force && mix local.rebar --force
+COPY mix.exs mix.lock* ./
+RUN mix deps.get --only prod && mix deps.compile
+COPY . .
+RUN mix release
+
+FROM debian:bookworm-slim
+RUN apt-get update \
+    && apt-get install -y --no-install-recommends libstdc++6 openssl libncurses6 ca-certificates \
+    && rm -rf /var/lib/apt/lists/*
+ENV LANG=C.UTF-8
+WORKDIR /app
+COPY --from=build /src/_build/prod/rel/%[1]s .
+CMD ["bin/%[1]s", "start"]
+`, elixirApp(projectName))
+	case "zig":
+		return fmt.Sprintf(`FROM debian:bookworm-slim AS build
+RUN apt-get update && apt-get install -y --no-install-recommends ca-certificates curl xz-utils
+ARG ZIG_VERSION=%[2]s
+RUN curl -fsSL https://ziglang.org/download/${ZIG_VERSION}/zig-linux-$(uname -m)-${ZIG_VERSION}.tar.xz | tar -xJ -C /opt \
+    && ln -s /opt/zig-linux-$(uname -m)-${ZIG_VERSION}/zig /usr/local/bin/zig
+WORKDIR /src
+COPY . .
+RUN zig build -Doptimize=ReleaseSafe -Dtarget=$(uname -m)-linux-musl
+
+FROM gcr.io/distroless/static-debian12
+COPY --from=build /src/zig-out/bin/%[1]s /usr/local/bin/%[1]s
+ENTRYPOINT ["/usr/local/bin/%[1]s"]
+`, projectName, zigVersion)
+	case "c", "cpp":
+		return fmt.Sprintf(`FROM debian:bookworm AS build
+RUN apt-get update && apt-get install -y --no-install-recommends build-essential cmake
+WORKDIR /src
+COPY . .
+RUN cmake -S . -B build -DCMAKE_BUILD_TYPE=Release -DBUILD_TESTING=OFF && cmake --build build
+
+FROM debian:bookworm-slim
+COPY --from=build /src/build/%[1]s /usr/local/bin/%[1]s
+ENTRYPOINT ["/usr/local/bin/%[1]s"]
+`, projectName)
+	default:
+		return `FROM alpine:3.20
+WORKDIR /app
+COPY . .
+# TODO: Install dependencies, build the project and set the command to run
+CMD ["sh"]
+`
+	}
+}
+
+// jvmDockerfile builds a java or kotlin project with the build tool chosen
+// at init and runs it on a JRE. mainClass is the entry point's class in the
+// group ID package.
+func jvmDockerfile(vars TemplateVars, mainClass string) string {
+	groupID := "com.example"
+	if id, ok := vars.Answers["group_id"].(string); ok && id != "" {
+		groupID = id
+	}
+
+	if vars.Answers["build"] == "maven" {
+		// The runtime dependencies, such as the Kotlin standard library, go
+		// next to the jar on the classpath
+		return fmt.Sprintf(`FROM maven:3-eclipse-temurin-21 AS build
+WORKDIR /src
+COPY pom.xml ./
+RUN mvn -B -q dependency:go-offline
+COPY . .
+RUN mvn -B -q package -DskipTests \
+    && mvn -B -q dependency:copy-dependencies -DincludeScope=runtime -DoutputDirectory=/out/lib \
+    && cp target/%[1]s-*.jar /out/app.jar
+
+FROM eclipse-temurin:21-jre
+WORKDIR /app
+COPY --from=build /out .
+ENTRYPOINT ["java", "-cp", "app.jar:lib/*", "%[2]s.%[3]s"]
+`, vars.ProjectName, groupID, mainClass)
+	}
+
+	// The application plugin's installDist writes the jars and a start script
+	return fmt.Sprintf(`FROM gradle:8-jdk21 AS build
+WORKDIR /src
+COPY . .
+RUN gradle installDist --no-daemon
+
+FROM eclipse-temurin:21-jre
+COPY --from=build /src/build/install/%[1]s /opt/%[1]s
+ENTRYPOINT ["/opt/%[1]s/bin/%[1]s"]
+`, vars.ProjectName)
+}
+
+func (f *DockerFeature) dockerignore(base string) string {
+	lines := []string{".git/", ".beads/", ".maajise/", ".env", "Dockerfile", ".dockerignore"}
+	switch base {
+	case "go":
+		lines = append(lines, "bin/", "dist/", "vendor/")
+	case "typescript":
+		lines = append(lines, "node_modules/", "dist/", "coverage/")
+	case "python":
+		lines = append(lines, "__pycache__/", "*.pyc", ".venv/", "venv/", ".pytest_cache/", ".mypy_cache/")
+	case "rust":
+		lines = append(lines, "target/")
+	case "php":
+		lines = append(lines, "vendor/")
+	case "swift":
+		lines = append(lines, ".build/", ".swiftpm/")
+	case "java", "kotlin":
+		lines = append(lines, "build/", "target/", ".gradle/", ".kotlin/")
+	case "ruby":
+		lines = append(lines, ".bundle/", "vendor/bundle/", "pkg/", "coverage/")
+	case "elixir":
+		lines = append(lines, "_build/", "deps/", "cover/", ".elixir_ls/")
+	case "zig":
+		lines = append(lines, ".zig-cache/", "zig-cache/", "zig-out/")
+	case "dotnet":
+		lines = append(lines, "**/bin/", "**/obj/", ".vs/", "TestResults/")
+	case "c", "cpp":
+		lines = append(lines, "build/", "cmake-build-*/", ".cache/", "compile_commands.json")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,98 @@
+package templates
+
+func init() {
+	RegisterFeature(&EditorConfigFeature{})
+}
+
+// EditorConfigFeature adds an .editorconfig with the language's indentation
+type EditorConfigFeature struct{}
+
+func (f *EditorConfigFeature) Name() string {
+	return "editorconfig"
+}
+
+func (f *EditorConfigFeature) Description() string {
+	return "EditorConfig with consistent whitespace settings"
+}
+
+func (f *EditorConfigFeature) Files(base string, vars TemplateVars) map[string]string {
+	content := `root = true
+
+[*]
+charset = utf-8
+end_of_line = lf
+insert_final_newline = true
+trim_trailing_whitespace = true
+indent_style = space
+indent_size = 2
+`
+	switch base {
+	case "go":
+		content += `
+[*.go]
+indent_style = tab
+indent_size = 4
+`
+	case "python":
+		content += `
+[*.py]
+indent_size = 4
+`
+	case "rust":
+		content += `
+[*.rs]
+indent_size = 4
+`
+	case "php":
+		content += `
+[*.php]
+indent_size = 4
+`
+	case "swift":
+		content += `
+[*.swift]
+indent_size = 4
+`
+	case "java", "kotlin":
+		content += `
+[*.{java,kt,kts,xml}]
+indent_size = 4
+`
+	case "dotnet":
+		content += `
+[*.{cs,csx}]
+indent_size = 4
+
+[*.{csproj,props,targets}]
+indent_size = 2
+
+[*.sln]
+indent_style = tab
+`
+	case "zig":
+		content += `
+[*.{zig,zon}]
+indent_size = 4
+`
+	case "c", "cpp":
+		content += `
+[*.{c,h,cc,cpp,hpp}]
+indent_size = 4
+`
+	}
+	content += `
+[Makefile]
+indent_style = tab
+
+[*.md]
+trim_trailing_whitespace = false
+`
+
+	return map[string]string{
+		".editorconfig": content,
+	}
+}
+
+func (f *EditorConfigFeature) Gitignore(base string) []string {
+	return nil
+}
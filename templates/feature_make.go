@@ -0,0 +1,204 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+)
+
+func init() {
+	RegisterFeature(&MakeFeature{})
+	RegisterFeature(&TaskFeature{})
+}
+
+// task is a named project command shared by the Makefile and Taskfile features
+type task struct {
+	name     string
+	desc     string
+	commands []string
+}
+
+// projectTasks returns the build, test, run and clean commands of a base template
+func projectTasks(base, projectName string) []task {
+	switch base {
+	case "go":
+		return []task{
+			{"build", "Build the binary", []string{"go build -o bin/" + projectName + " ./cmd/" + projectName}},
+			{"test", "Run the tests", []string{"go test ./..."}},
+			{"run", "Run the application", []string{"go run ./cmd/" + projectName}},
+			{"lint", "Run static analysis", []string{"go vet ./..."}},
+			{"clean", "Remove build output", []string{"rm -rf bin dist"}},
+		}
+	case "typescript":
+		return []task{
+			{"install", "Install dependencies", []string{"npm install"}},
+			{"build", "Compile TypeScript", []string{"npm run build"}},
+			{"run", "Run the application", []string{"npm start"}},
+			{"clean", "Remove build output", []string{"npm run clean"}},
+		}
+	case "python":
+		return []task{
+			{"install", "Install the package with dev dependencies", []string{`pip install -e ".[dev]"`}},
+			{"test", "Run the tests", []string{"pytest"}},
+			{"run", "Run the application", []string{"python src/main.py"}},
+			{"lint", "Type-check the sources", []string{"mypy src"}},
+			{"clean", "Remove caches", []string{"rm -rf .pytest_cache .mypy_cache build dist"}},
+		}
+	case "rust":
+		return []task{
+			{"build", "Build a release binary", []string{"cargo build --release"}},
+			{"test", "Run the tests", []string{"cargo test"}},
+			{"run", "Run the application", []string{"cargo run"}},
+			{"lint", "Run clippy", []string{"cargo clippy"}},
+			{"clean", "Remove build output", []string{"cargo clean"}},
+		}
+	case "php":
+		return []task{
+			{"install", "Install dependencies", []string{"composer install"}},
+			{"test", "Run the tests", []string{"composer test"}},
+			{"run", "Start the development server", []string{"php -S localhost:8000 -t public"}},
+			{"lint", "Check PHP syntax", []string{"composer lint"}},
+		}
+	case "swift":
+		return []task{
+			{"build", "Build the package", []string{"swift build"}},
+			{"test", "Run the tests", []string{"swift test"}},
+			{"run", "Run the application", []string{"swift run " + projectName}},
+			{"clean", "Remove build output", []string{"rm -rf .build"}},
+		}
+	case "java", "kotlin":
+		return []task{
+			{"build", "Build the project", []string{jvmBuildCommand("./gradlew build", "mvn -B package")}},
+			{"test", "Run the tests", []string{jvmBuildCommand("./gradlew test", "mvn -B test")}},
+			{"run", "Run the application", []string{jvmBuildCommand("./gradlew run", "mvn -q exec:java")}},
+			{"clean", "Remove build output", []string{jvmBuildCommand("./gradlew clean", "mvn -B clean")}},
+		}
+	case "ruby":
+		return []task{
+			{"install", "Install dependencies", []string{"bundle install"}},
+			{"test", "Run the tests", []string{"bundle exec rake test"}},
+			{"build", "Build the gem into pkg/", []string{"bundle exec rake build"}},
+			{"clean", "Remove build output", []string{"rm -rf pkg"}},
+		}
+	case "elixir":
+		return []task{
+			{"install", "Fetch dependencies", []string{"mix deps.get"}},
+			{"build", "Compile the project", []string{"mix compile"}},
+			{"test", "Run the tests", []string{"mix test"}},
+			{"lint", "Check formatting", []string{"mix format --check-formatted"}},
+			{"clean", "Remove build output", []string{"mix clean"}},
+		}
+	case "zig":
+		return []task{
+			{"build", "Build into zig-out/", []string{"zig build"}},
+			{"test", "Run the tests", []string{"zig build test"}},
+			{"run", "Run the application", []string{"zig build run"}},
+			{"lint", "Check formatting", []string{"zig fmt --check ."}},
+			{"clean", "Remove build output", []string{"rm -rf zig-out .zig-cache"}},
+		}
+	case "dotnet":
+		return []task{
+			{"build", "Build the solution", []string{"dotnet build"}},
+			{"test", "Run the tests", []string{"dotnet test"}},
+			{"run", "Run the application", []string{"dotnet run --project src/" + pascalName(projectName)}},
+			{"lint", "Check formatting", []string{"dotnet format --verify-no-changes"}},
+			{"clean", "Remove build output", []string{"dotnet clean"}},
+		}
+	case "c", "cpp":
+		return []task{
+			{"build", "Configure and build", []string{"cmake -S . -B build", "cmake --build build"}},
+			{"test", "Run the tests", []string{"ctest --test-dir build --output-on-failure"}},
+			{"run", "Run the application", []string{"./build/" + projectName}},
+			{"format", "Format the sources", []string{"clang-format -i src/* include/" + projectName + "/* tests/*"}},
+			{"clean", "Remove build output", []string{"rm -rf build"}},
+		}
+	default:
+		return []task{
+			{"build", "Build the project", []string{`echo "TODO: add build steps"`}},
+			{"test", "Run the tests", []string{`echo "TODO: add test steps"`}},
+		}
+	}
+}
+
+// jvmBuildCommand runs the Maven command in projects with a pom.xml and the
+// Gradle one otherwise, as features don't see the template's build answer
+func jvmBuildCommand(gradle, maven string) string {
+	return "if [ -f pom.xml ]; then " + maven + "; else " + gradle + "; fi"
+}
+
+// MakeFeature adds a Makefile with the project's common commands
+type MakeFeature struct{}
+
+func (f *MakeFeature) Name() string {
+	return "make"
+}
+
+func (f *MakeFeature) Description() string {
+	return "Makefile with build, test and run targets"
+}
+
+func (f *MakeFeature) Files(base string, vars TemplateVars) map[string]string {
+	tasks := projectTasks(base, vars.ProjectName)
+	names := make([]string, len(tasks))
+	for i, t := range tasks {
+		names[i] = t.name
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, ".PHONY: %s\n", strings.Join(names, " "))
+	for _, t := range tasks {
+		fmt.Fprintf(&b, "\n# %s\n%s:\n", t.desc, t.name)
+		for _, command := range t.commands {
+			// Make requires recipe lines to start with a tab
+			fmt.Fprintf(&b, "\t%s\n", command)
+		}
+	}
+
+	return map[string]string{
+		"Makefile": b.String(),
+	}
+}
+
+func (f *MakeFeature) Gitignore(base string) []string {
+	return nil
+}
+
+// TaskFeature adds a Taskfile.yml for https://taskfile.dev
+type TaskFeature struct{}
+
+func (f *TaskFeature) Name() string {
+	return "task"
+}
+
+func (f *TaskFeature) Description() string {
+	return "Taskfile.yml with build, test and run tasks"
+}
+
+func (f *TaskFeature) Files(base string, vars TemplateVars) map[string]string {
+	var b strings.Builder
+	b.WriteString("version: '3'\n\ntasks:\n")
+	for i, t := range projectTasks(base, vars.ProjectName) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "  %s:\n    desc: %s\n    cmds:\n", t.name, t.desc)
+		for _, command := range t.commands {
+			fmt.Fprintf(&b, "      - %s\n", yamlQuote(command))
+		}
+	}
+
+	return map[string]string{
+		"Taskfile.yml": b.String(),
+	}
+}
+
+func (f *TaskFeature) Gitignore(base string) []string {
+	return []string{"# Task", ".task/"}
+}
+
+// yamlQuote single-quotes a command when YAML would not read it as a plain string
+func yamlQuote(s string) string {
+	if strings.ContainsAny(s, `:#"'{}[]&*!|>%@`+"`") {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	return s
+}
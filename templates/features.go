@@ -0,0 +1,209 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"maajise/internal/fsutil"
+	"maajise/internal/managed"
+)
+
+// Feature is a set of files layered on top of any template, such as a
+// Dockerfile or a CI workflow. Its output can be tailored to the base
+// template, e.g. a Go-specific or a Python-specific Dockerfile.
+type Feature interface {
+	Name() string
+	Description() string
+	// Files returns the feature's files for a project of the given base
+	// template ("" when unknown) and variables. They are written as is,
+	// without rendering.
+	Files(base string, vars TemplateVars) map[string]string
+	// Gitignore returns the lines the feature adds to .gitignore
+	Gitignore(base string) []string
+}
+
+// features holds the available features
+var features = make(map[string]Feature)
+
+// RegisterFeature adds a feature to the registry
+func RegisterFeature(f Feature) {
+	features[f.Name()] = f
+}
+
+// GetFeature retrieves a feature by name
+func GetFeature(name string) (Feature, bool) {
+	f, ok := features[name]
+	return f, ok
+}
+
+// AllFeatures returns the registered features sorted by name
+func AllFeatures() []Feature {
+	all := make([]Feature, 0, len(features))
+	for _, f := range features {
+		all = append(all, f)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name() < all[j].Name() })
+	return all
+}
+
+// FeatureNames returns the names of the registered features, sorted
+func FeatureNames() []string {
+	names := make([]string, 0, len(features))
+	for name := range features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseFeatures splits a comma-separated list such as "docker,ci", checking
+// each name and dropping duplicates
+func ParseFeatures(list string) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, ok := features[name]; !ok {
+			return nil, fmt.Errorf("unknown feature: %s (available: %s)", name, strings.Join(FeatureNames(), ", "))
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// Composite is a template with features layered on top. Feature files are
+// added to the template's, replacing files of the same name, and feature
+// .gitignore lines are appended to .gitignore as managed blocks.
+type Composite struct {
+	base     Template
+	features []Feature
+}
+
+// Compose layers the named features on top of base. Without features it
+// returns base itself.
+func Compose(base Template, names []string) (Template, error) {
+	if len(names) == 0 {
+		return base, nil
+	}
+
+	c := &Composite{base: base}
+	for _, name := range names {
+		f, ok := features[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown feature: %s (available: %s)", name, strings.Join(FeatureNames(), ", "))
+		}
+		c.features = append(c.features, f)
+	}
+	return c, nil
+}
+
+func (c *Composite) Name() string           { return c.base.Name() }
+func (c *Composite) Description() string    { return c.base.Description() }
+func (c *Composite) Dependencies() []string { return c.base.Dependencies() }
+
+// Base returns the template the features are layered on
+func (c *Composite) Base() Template { return c.base }
+
+// Features returns the names of the layered features, in order
+func (c *Composite) Features() []string {
+	names := make([]string, len(c.features))
+	for i, f := range c.features {
+		names[i] = f.Name()
+	}
+	return names
+}
+
+// Files returns the unrendered files of the template and its features
+func (c *Composite) Files(projectName string) map[string]string {
+	return c.layer(c.base.Files(projectName), DefaultVars(projectName))
+}
+
+// layer adds the feature files to files, the base template's output
+func (c *Composite) layer(files map[string]string, vars TemplateVars) map[string]string {
+	base := BaseOf(c.base)
+	result := make(map[string]string, len(files))
+	for path, content := range files {
+		result[path] = content
+	}
+
+	for _, f := range c.features {
+		for path, content := range f.Files(base, vars) {
+			result[path] = content
+		}
+		if lines := f.Gitignore(base); len(lines) > 0 {
+			result[".gitignore"] = appendBlock(result[".gitignore"], FeatureBlock(f.Name()), strings.Join(lines, "\n"))
+		}
+	}
+	return result
+}
+
+// FeatureBlock returns the name of the managed .gitignore block of a feature
+func FeatureBlock(feature string) string {
+	return "feature-" + feature
+}
+
+// appendBlock appends a managed block to content, separated by a blank line
+func appendBlock(content, name, lines string) string {
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	if content != "" && !strings.HasSuffix(content, "\n\n") {
+		content += "\n"
+	}
+	return content + managed.Wrap(name, lines)
+}
+
+// Questions, Variables, FileModes and Hooks are those of the base template
+
+func (c *Composite) Questions() []Question        { return QuestionsOf(c.base) }
+func (c *Composite) Variables() map[string]string { return VariablesOf(c.base) }
+func (c *Composite) Hooks() []Hook                { return HooksOf(c.base) }
+
+func (c *Composite) FileModes() map[string]os.FileMode {
+	if moder, ok := c.base.(FileModer); ok {
+		return moder.FileModes()
+	}
+	return nil
+}
+
+// BaseOf returns the name of the built-in template tmpl is or extends, so
+// features can tailor their files; "" for a template that extends none
+func BaseOf(tmpl Template) string {
+	seen := make(map[string]bool)
+	for tmpl != nil && !seen[tmpl.Name()] {
+		name := tmpl.Name()
+		if IsBuiltin(name) {
+			return name
+		}
+		seen[name] = true
+
+		custom, ok := tmpl.(*CustomTemplate)
+		if !ok || custom.extends == "" {
+			return ""
+		}
+		tmpl, _ = Get(custom.extends)
+	}
+	return ""
+}
+
+// render renders the base template and layers the feature files on top
+func (c *Composite) render(vars TemplateVars) (map[string]string, error) {
+	files, err := Render(c.base, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	result := c.layer(files, vars)
+	for path := range result {
+		if err := fsutil.CheckRelPath(path); err != nil {
+			return nil, fmt.Errorf("path %s: %w", path, err)
+		}
+	}
+	return result, nil
+}
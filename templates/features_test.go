@@ -0,0 +1,188 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+
+	"maajise/internal/managed"
+)
+
+func TestParseFeatures(t *testing.T) {
+	names, err := ParseFeatures(" docker, CI,,docker ")
+	if err != nil {
+		t.Fatalf("ParseFeatures() error = %v", err)
+	}
+	if got := strings.Join(names, ","); got != "docker,ci" {
+		t.Errorf("ParseFeatures() = %q, want %q", got, "docker,ci")
+	}
+
+	if _, err := ParseFeatures("docker,kubernetes"); err == nil || !strings.Contains(err.Error(), "kubernetes") {
+		t.Errorf("ParseFeatures() error = %v, want unknown feature kubernetes", err)
+	}
+}
+
+func TestCompose(t *testing.T) {
+	base, _ := Get("go")
+
+	if tmpl, err := Compose(base, nil); err != nil || tmpl != base {
+		t.Errorf("Compose(nil) = %v, %v, want the base template", tmpl, err)
+	}
+	if _, err := Compose(base, []string{"nope"}); err == nil {
+		t.Error("Compose() with an unknown feature should fail")
+	}
+
+	tmpl, err := Compose(base, []string{"docker", "task"})
+	if err != nil {
+		t.Fatalf("Compose() error = %v", err)
+	}
+	if tmpl.Name() != "go" {
+		t.Errorf("Name() = %q, want %q", tmpl.Name(), "go")
+	}
+	if len(HooksOf(tmpl)) == 0 {
+		t.Error("HooksOf() should forward the base template's hooks")
+	}
+
+	files, err := Render(tmpl, DefaultVars("myapp"))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, path := range []string{"go.mod", "cmd/myapp/main.go", "Dockerfile", ".dockerignore", "Taskfile.yml"} {
+		if _, ok := files[path]; !ok {
+			t.Errorf("missing file: %s", path)
+		}
+	}
+
+	// Both the template's and the features' lines end up in .gitignore
+	gitignore := files[".gitignore"]
+	for _, name := range []string{"go", "feature-docker", "feature-task"} {
+		if !strings.Contains(gitignore, managed.Begin(name)) || !strings.Contains(gitignore, managed.End(name)) {
+			t.Errorf(".gitignore missing block %q:\n%s", name, gitignore)
+		}
+	}
+	if !strings.Contains(gitignore, "\n\n# >>> maajise:feature-docker") {
+		t.Errorf(".gitignore blocks should be separated by a blank line:\n%s", gitignore)
+	}
+}
+
+func TestCompose_Verbatim(t *testing.T) {
+	base, _ := Get("base")
+	tmpl, _ := Compose(base, []string{"ci"})
+
+	files, err := Render(tmpl, DefaultVars("myapp"))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	// Feature files are not rendered, so they may contain {{ }}
+	ci := files[".github/workflows/ci.yml"]
+	if !strings.HasPrefix(ci, "name: CI\n") {
+		t.Errorf("ci.yml = %q, want a workflow", ci)
+	}
+}
+
+func TestFeatures_Tailored(t *testing.T) {
+	tests := []struct {
+		base    string
+		feature string
+		path    string
+		want    string
+	}{
+		{"go", "docker", "Dockerfile", "go build -o /out/myapp ./cmd/myapp"},
+		{"python", "docker", "Dockerfile", "FROM python:"},
+		{"rust", "docker", "Dockerfile", "target/release/myapp"},
+		{"typescript", "docker", ".dockerignore", "node_modules/"},
+		{"", "docker", "Dockerfile", "FROM alpine"},
+		{"go", "ci", ".github/workflows/ci.yml", "go test ./..."},
+		{"php", "ci", ".github/workflows/ci.yml", "composer install"},
+		{"swift", "devcontainer", ".devcontainer/devcontainer.json", `"postCreateCommand": "swift package resolve"`},
+		{"zig", "devcontainer", ".devcontainer/devcontainer.json", "https://ziglang.org/download/" + zigVersion + "/"},
+		{"go", "editorconfig", ".editorconfig", "[*.go]\nindent_style = tab"},
+		{"go", "make", "Makefile", "build:\n\tgo build -o bin/myapp ./cmd/myapp\n"},
+		{"python", "task", "Taskfile.yml", `- 'pip install -e ".[dev]"'`},
+		{"java", "make", "Makefile", "test:\n\tif [ -f pom.xml ]; then mvn -B test; else ./gradlew test; fi\n"},
+		{"kotlin", "ci", ".github/workflows/ci.yml", "mvn -B verify"},
+		{"cpp", "docker", "Dockerfile", "COPY --from=build /src/build/myapp /usr/local/bin/myapp"},
+		{"dotnet", "docker", "Dockerfile", `ENTRYPOINT ["dotnet", "Myapp.dll"]`},
+		{"dotnet", "make", "Makefile", "run:\n\tdotnet run --project src/Myapp\n"},
+		{"ruby", "ci", ".github/workflows/ci.yml", "bundle exec rake test"},
+		{"elixir", "make", "Makefile", "test:\n\tmix test\n"},
+		{"zig", "docker", ".dockerignore", "zig-out/"},
+		{"zig", "docker", "Dockerfile", "COPY --from=build /src/zig-out/bin/myapp /usr/local/bin/myapp"},
+		{"ruby", "docker", "Dockerfile", `CMD ["bundle", "exec", "ruby", "-r", "./lib/myapp", "-e", "puts Myapp.greeting"]`},
+		{"elixir", "docker", "Dockerfile", "RUN mix release"},
+		{"elixir", "docker", "Dockerfile", `CMD ["bin/myapp", "start"]`},
+		{"c", "make", "Makefile", "test:\n\tctest --test-dir build --output-on-failure\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.base+"/"+tt.feature, func(t *testing.T) {
+			f, ok := GetFeature(tt.feature)
+			if !ok {
+				t.Fatalf("feature %s not registered", tt.feature)
+			}
+			content, ok := f.Files(tt.base, DefaultVars("myapp"))[tt.path]
+			if !ok {
+				t.Fatalf("Files() missing %s", tt.path)
+			}
+			if !strings.Contains(content, tt.want) {
+				t.Errorf("%s = %q, want it to contain %q", tt.path, content, tt.want)
+			}
+		})
+	}
+}
+
+func TestDockerFeature_JVM(t *testing.T) {
+	f, _ := GetFeature("docker")
+
+	vars := DefaultVars("myapp")
+	vars.Answers = map[string]any{"build": "gradle", "group_id": "org.acme"}
+	dockerfile := f.Files("java", vars)["Dockerfile"]
+	for _, want := range []string{"RUN gradle installDist", "FROM eclipse-temurin:21-jre", `ENTRYPOINT ["/opt/myapp/bin/myapp"]`} {
+		if !strings.Contains(dockerfile, want) {
+			t.Errorf("Gradle Dockerfile = %q, want it to contain %q", dockerfile, want)
+		}
+	}
+
+	vars.Answers["build"] = "maven"
+	dockerfile = f.Files("kotlin", vars)["Dockerfile"]
+	for _, want := range []string{"mvn -B -q package", "cp target/myapp-*.jar /out/app.jar", "FROM eclipse-temurin:21-jre", `"app.jar:lib/*", "org.acme.MainKt"]`} {
+		if !strings.Contains(dockerfile, want) {
+			t.Errorf("Maven Dockerfile = %q, want it to contain %q", dockerfile, want)
+		}
+	}
+}
+
+func TestCIFeature_Branch(t *testing.T) {
+	f, _ := GetFeature("ci")
+	vars := DefaultVars("myapp")
+
+	if content := f.Files("go", vars)[".github/workflows/ci.yml"]; !strings.Contains(content, "branches: [main]") {
+		t.Errorf("ci.yml = %q, want main without a recorded branch", content)
+	}
+
+	vars.Branch = "develop"
+	if content := f.Files("go", vars)[".github/workflows/ci.yml"]; !strings.Contains(content, "branches: [develop]") {
+		t.Errorf("ci.yml = %q, want the recorded branch", content)
+	}
+}
+
+func TestBaseOf(t *testing.T) {
+	goTmpl, _ := Get("go")
+	tests := []struct {
+		name string
+		tmpl Template
+		want string
+	}{
+		{"builtin", goTmpl, "go"},
+		{"extends builtin", &CustomTemplate{name: "org-go", extends: "go"}, "go"},
+		{"standalone", &CustomTemplate{name: "standalone"}, ""},
+		{"missing parent", &CustomTemplate{name: "orphan", extends: "does-not-exist"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BaseOf(tt.tmpl); got != tt.want {
+				t.Errorf("BaseOf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package templates
 
-import "fmt"
+import (
+	"fmt"
+
+	"maajise/internal/managed"
+)
 
 func init() {
 	Register(&GoTemplate{})
@@ -21,10 +25,16 @@ func (t *GoTemplate) Dependencies() []string {
 	return []string{"git", "br", "go"}
 }
 
+func (t *GoTemplate) Hooks() []Hook {
+	return []Hook{
+		{Name: "Tidy Go modules", Run: "go mod tidy", Optional: true, Commit: []string{"go.sum"}},
+	}
+}
+
 func (t *GoTemplate) Files(projectName string) map[string]string {
 	return map[string]string{
-		".gitignore":                      t.gitignore(),
-		".ubsignore":                      t.ubsignore(),
+		".gitignore":                      managed.Wrap(t.Name(), t.gitignore()),
+		".ubsignore":                      managed.Wrap(t.Name(), t.ubsignore()),
 		"README.md":                       t.readme(projectName),
 		"go.mod":                          t.goMod(projectName),
 		"api/.gitkeep":                    "",
@@ -82,6 +92,7 @@ vendor/
 .vscode/
 .idea/
 .beads/
+.maajise/
 .claude/
 *.md
 *.sum
@@ -152,11 +163,14 @@ go vet ./...      # Go static analysis
 `, projectName, projectName, projectName, projectName, projectName, projectName)
 }
 
+// goVersion is the go directive of new modules and workspaces
+const goVersion = "1.23"
+
 func (t *GoTemplate) goMod(projectName string) string {
 	return fmt.Sprintf(`module %s
 
-go 1.23
-`, projectName)
+go %s
+`, projectName, goVersion)
 }
 
 func (t *GoTemplate) mainGo() string {
@@ -0,0 +1,157 @@
+package templates
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"maajise/internal/fsutil"
+)
+
+// DefaultHookTimeout bounds a hook without a timeout of its own
+const DefaultHookTimeout = 5 * time.Minute
+
+// Hook is a shell command init runs in the new project after writing its
+// files and before the initial commit, e.g. "npm install". Run, Dir, Commit
+// and the Env values are rendered like file contents; variables in Run should
+// go through shquote, e.g. {{shquote .ProjectName}}. A failing hook aborts
+// init unless it is Optional. Commit lists files the hook creates that belong
+// in the initial commit, such as lockfiles.
+type Hook struct {
+	Name    string            `yaml:"name,omitempty"`
+	Run     string            `yaml:"run"`
+	Dir     string            `yaml:"dir,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty"`
+	Timeout string            `yaml:"timeout,omitempty"`
+	// Optional hooks only warn when they fail. The built-in templates mark
+	// their dependency installs optional so init still works offline or
+	// without the language toolchain.
+	Optional bool     `yaml:"optional,omitempty"`
+	When     string   `yaml:"when,omitempty"`
+	Commit   []string `yaml:"commit,omitempty"`
+}
+
+// Hooker is implemented by templates with post-generate hooks
+type Hooker interface {
+	Hooks() []Hook
+}
+
+// HooksOf returns the hooks of a template in the order they run, if any
+func HooksOf(tmpl Template) []Hook {
+	if h, ok := tmpl.(Hooker); ok {
+		return h.Hooks()
+	}
+	return nil
+}
+
+// Label returns the name shown for the hook, defaulting to its command
+func (h Hook) Label() string {
+	if h.Name != "" {
+		return h.Name
+	}
+	return h.Run
+}
+
+// Duration returns how long the hook may run
+func (h Hook) Duration() time.Duration {
+	if d, err := time.ParseDuration(h.Timeout); err == nil && d > 0 {
+		return d
+	}
+	return DefaultHookTimeout
+}
+
+// Check validates the hook definition itself
+func (h Hook) Check() error {
+	if strings.TrimSpace(h.Run) == "" {
+		return fmt.Errorf("hook %q: run is required", h.Name)
+	}
+	if h.Timeout != "" {
+		if d, err := time.ParseDuration(h.Timeout); err != nil || d <= 0 {
+			return fmt.Errorf("hook %s: invalid timeout %q (use e.g. 30s or 10m)", h.Label(), h.Timeout)
+		}
+	}
+	if h.Dir != "" {
+		if err := fsutil.CheckRelPath(h.Dir); err != nil {
+			return fmt.Errorf("hook %s: dir: %w", h.Label(), err)
+		}
+	}
+	for _, path := range h.Commit {
+		if err := fsutil.CheckRelPath(path); err != nil {
+			return fmt.Errorf("hook %s: commit: %w", h.Label(), err)
+		}
+	}
+	for key := range h.Env {
+		if key == "" || strings.ContainsAny(key, "=\x00") {
+			return fmt.Errorf("hook %s: invalid environment variable name %q", h.Label(), key)
+		}
+	}
+	return nil
+}
+
+// Render returns the hook with Run, Dir, Commit and the Env values rendered
+// with vars
+func (h Hook) Render(vars TemplateVars) (Hook, error) {
+	var err error
+	if h.Run, err = RenderString("hook", h.Run, vars); err != nil {
+		return h, fmt.Errorf("hook %s: %w", h.Label(), err)
+	}
+	if h.Dir, err = RenderString("hook", h.Dir, vars); err != nil {
+		return h, fmt.Errorf("hook %s: %w", h.Label(), err)
+	}
+	if h.Dir != "" {
+		if err := fsutil.CheckRelPath(h.Dir); err != nil {
+			return h, fmt.Errorf("hook %s: dir: %w", h.Label(), err)
+		}
+	}
+
+	commit := make([]string, len(h.Commit))
+	for i, path := range h.Commit {
+		if commit[i], err = RenderString("hook", path, vars); err != nil {
+			return h, fmt.Errorf("hook %s: commit: %w", h.Label(), err)
+		}
+		if err := fsutil.CheckRelPath(commit[i]); err != nil {
+			return h, fmt.Errorf("hook %s: commit: %w", h.Label(), err)
+		}
+	}
+	h.Commit = commit
+
+	env := make(map[string]string, len(h.Env))
+	for key, value := range h.Env {
+		if env[key], err = RenderString("hook", value, vars); err != nil {
+			return h, fmt.Errorf("hook %s: env %s: %w", h.Label(), key, err)
+		}
+	}
+	h.Env = env
+	return h, nil
+}
+
+// Environ returns the hook's environment as sorted KEY=value pairs
+func (h Hook) Environ() []string {
+	env := make([]string, 0, len(h.Env))
+	for key, value := range h.Env {
+		env = append(env, key+"="+value)
+	}
+	sort.Strings(env)
+	return env
+}
+
+// Hooks returns the inherited hooks followed by the template's own
+func (t *CustomTemplate) Hooks() []Hook {
+	return t.resolveHooks(nil)
+}
+
+// resolveHooks is Hooks with cycle detection through seen
+func (t *CustomTemplate) resolveHooks(seen []string) []Hook {
+	var hooks []Hook
+	if t.extends != "" {
+		if parent, err := parentTemplate(t.extends, append(seen, t.name)); err == nil {
+			if custom, ok := parent.(*CustomTemplate); ok {
+				hooks = custom.resolveHooks(append(seen, t.name))
+			} else {
+				hooks = HooksOf(parent)
+			}
+		}
+	}
+	return append(hooks, t.hooks...)
+}
@@ -0,0 +1,133 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHook_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		hook    Hook
+		wantErr bool
+	}{
+		{"minimal", Hook{Run: "npm install"}, false},
+		{"full", Hook{Name: "deps", Run: "make", Dir: "web", Timeout: "90s", Env: map[string]string{"CI": "1"}, Commit: []string{"web/lock"}}, false},
+		{"missing run", Hook{Name: "empty"}, true},
+		{"bad timeout", Hook{Run: "make", Timeout: "soon"}, true},
+		{"negative timeout", Hook{Run: "make", Timeout: "-1m"}, true},
+		{"escaping dir", Hook{Run: "make", Dir: "../elsewhere"}, true},
+		{"absolute commit", Hook{Run: "make", Commit: []string{"/etc/passwd"}}, true},
+		{"bad env name", Hook{Run: "make", Env: map[string]string{"A=B": "c"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.hook.Check(); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHook_Duration(t *testing.T) {
+	if got := (Hook{}).Duration(); got != DefaultHookTimeout {
+		t.Errorf("Duration() = %v, want %v", got, DefaultHookTimeout)
+	}
+	if got := (Hook{Timeout: "2m"}).Duration(); got != 2*time.Minute {
+		t.Errorf("Duration() = %v, want 2m", got)
+	}
+}
+
+func TestHook_Render(t *testing.T) {
+	h := Hook{
+		Run:    "echo {{.ProjectName}}",
+		Dir:    "cmd/{{.ProjectName}}",
+		Env:    map[string]string{"APP": "{{.ProjectName}}", "B": "2"},
+		Commit: []string{"{{.ProjectName}}.lock"},
+	}
+	got, err := h.Render(DefaultVars("app"))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got.Run != "echo app" || got.Dir != "cmd/app" || got.Commit[0] != "app.lock" {
+		t.Errorf("Render() = %+v", got)
+	}
+	if env := got.Environ(); !reflect.DeepEqual(env, []string{"APP=app", "B=2"}) {
+		t.Errorf("Environ() = %v", env)
+	}
+
+	vars := DefaultVars("app")
+	vars.Set("dir", "..")
+	if _, err := (Hook{Run: "make", Dir: "{{.dir}}"}).Render(vars); err == nil {
+		t.Error("Render() should fail when dir renders outside the project")
+	}
+}
+
+func TestCustomTemplate_Hooks(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-hooks-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	parentPath := filepath.Join(tmpDir, "parent.yaml")
+	os.WriteFile(parentPath, []byte(`name: hooks-parent
+hooks:
+  - run: first
+`), 0644)
+	parent, err := LoadFile(parentPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Register(parent)
+	defer delete(registry, "hooks-parent")
+
+	childPath := filepath.Join(tmpDir, "child.yaml")
+	os.WriteFile(childPath, []byte(`name: hooks-child
+extends: hooks-parent
+hooks:
+  - name: second
+    run: second
+    optional: true
+    timeout: 1m
+`), 0644)
+	child, err := LoadFile(childPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hooks := HooksOf(child)
+	if len(hooks) != 2 || hooks[0].Run != "first" || hooks[1].Run != "second" || !hooks[1].Optional {
+		t.Errorf("HooksOf() = %+v, want parent hook then child hook", hooks)
+	}
+
+	os.WriteFile(childPath, []byte("name: bad-hooks\nhooks:\n  - name: nothing\n"), 0644)
+	if _, err := LoadFile(childPath); err == nil {
+		t.Error("LoadFile() should fail for a hook without run")
+	}
+}
+
+func TestBuiltinHooks(t *testing.T) {
+	for _, name := range []string{"go", "typescript", "rust", "php"} {
+		tmpl, ok := Get(name)
+		if !ok {
+			t.Fatalf("template %s not registered", name)
+		}
+		hooks := HooksOf(tmpl)
+		if len(hooks) == 0 {
+			t.Errorf("%s has no hooks", name)
+		}
+		for _, h := range hooks {
+			if !h.Optional {
+				t.Errorf("%s hook %s should be optional", name, h.Label())
+			}
+			if err := h.Check(); err != nil {
+				t.Errorf("%s hook: %v", name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package templates
+
+import (
+	"fmt"
+
+	"maajise/internal/managed"
+)
+
+func init() {
+	Register(&JavaTemplate{})
+}
+
+// JavaTemplate is a Java project template built with Gradle or Maven
+type JavaTemplate struct{}
+
+func (t *JavaTemplate) Name() string {
+	return "java"
+}
+
+func (t *JavaTemplate) Description() string {
+	return "Java project with Gradle (Kotlin DSL) or Maven and JUnit 5"
+}
+
+func (t *JavaTemplate) Dependencies() []string {
+	return []string{"git", "br", "java", "gradle/mvn"}
+}
+
+func (t *JavaTemplate) Questions() []Question {
+	return jvmQuestions()
+}
+
+func (t *JavaTemplate) Conditions() map[string]string {
+	return jvmConditions()
+}
+
+func (t *JavaTemplate) Hooks() []Hook {
+	return jvmHooks()
+}
+
+func (t *JavaTemplate) Files(projectName string) map[string]string {
+	return map[string]string{
+		".gitignore":          managed.Wrap(t.Name(), jvmGitignore()),
+		".ubsignore":          managed.Wrap(t.Name(), jvmUbsignore()),
+		"README.md":           t.readme(projectName),
+		"build.gradle.kts":    t.buildGradle(),
+		"settings.gradle.kts": fmt.Sprintf("rootProject.name = %q\n", projectName),
+		"pom.xml":             t.pom(projectName),
+		"src/main/java/" + jvmPackagePath + "/App.java":     t.app(),
+		"src/main/resources/.gitkeep":                       "",
+		"src/test/java/" + jvmPackagePath + "/AppTest.java": t.appTest(),
+		"src/test/resources/.gitkeep":                       "",
+	}
+}
+
+func (t *JavaTemplate) readme(projectName string) string {
+	return fmt.Sprintf(`# %s
+
+A Java project.
+
+## Project Structure
+
+| Directory | Purpose |
+|-----------|---------|
+| src/main/java/ | Application code |
+| src/main/resources/ | Configuration and other resources |
+| src/test/java/ | JUnit 5 tests |
+| src/test/resources/ | Test resources |
+
+## Development
+
+`+jvmReadmeCommands("{{.Answers.group_id}}.App")+`
+
+## Issue Tracking
+
+`+"```bash"+`
+br list           # View issues
+br create --title "Task"  # Create issue
+`+"```"+`
+
+## Code Quality
+
+`+"```bash"+`
+ubs .             # Scan for bugs
+`+"```"+`
+`, projectName)
+}
+
+func (t *JavaTemplate) buildGradle() string {
+	return `plugins {
+    application
+}
+
+group = "{{.Answers.group_id}}"
+version = "0.1.0"
+
+repositories {
+    mavenCentral()
+}
+
+dependencies {
+    testImplementation(platform("org.junit:junit-bom:5.11.3"))
+    testImplementation("org.junit.jupiter:junit-jupiter")
+    testRuntimeOnly("org.junit.platform:junit-platform-launcher")
+}
+
+java {
+    toolchain {
+        languageVersion = JavaLanguageVersion.of(21)
+    }
+}
+
+application {
+    mainClass = "{{.Answers.group_id}}.App"
+}
+
+tasks.test {
+    useJUnitPlatform()
+}
+`
+}
+
+func (t *JavaTemplate) pom(projectName string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0"
+         xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+         xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 https://maven.apache.org/xsd/maven-4.0.0.xsd">
+    <modelVersion>4.0.0</modelVersion>
+
+    <groupId>{{.Answers.group_id}}</groupId>
+    <artifactId>%s</artifactId>
+    <version>0.1.0</version>
+    <packaging>jar</packaging>
+{{with .License}}
+    <licenses>
+        <license>
+            <name>{{.}}</name>
+        </license>
+    </licenses>
+{{end}}
+    <properties>
+        <maven.compiler.release>21</maven.compiler.release>
+        <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+        <exec.mainClass>{{.Answers.group_id}}.App</exec.mainClass>
+    </properties>
+
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>org.junit</groupId>
+                <artifactId>junit-bom</artifactId>
+                <version>5.11.3</version>
+                <type>pom</type>
+                <scope>import</scope>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+
+    <dependencies>
+        <dependency>
+            <groupId>org.junit.jupiter</groupId>
+            <artifactId>junit-jupiter</artifactId>
+            <scope>test</scope>
+        </dependency>
+    </dependencies>
+
+    <build>
+        <plugins>
+            <plugin>
+                <groupId>org.apache.maven.plugins</groupId>
+                <artifactId>maven-surefire-plugin</artifactId>
+                <version>3.5.2</version>
+            </plugin>
+            <plugin>
+                <groupId>org.codehaus.mojo</groupId>
+                <artifactId>exec-maven-plugin</artifactId>
+                <version>3.5.0</version>
+            </plugin>
+        </plugins>
+    </build>
+</project>
+`, projectName)
+}
+
+func (t *JavaTemplate) app() string {
+	return `package {{.Answers.group_id}};
+
+public class App {
+    public static String greeting() {
+        return "Hello, Java!";
+    }
+
+    public static void main(String[] args) {
+        System.out.println(greeting());
+        // TODO: Add your application code here
+    }
+}
+`
+}
+
+func (t *JavaTemplate) appTest() string {
+	return `package {{.Answers.group_id}};
+
+import static org.junit.jupiter.api.Assertions.assertEquals;
+
+import org.junit.jupiter.api.Test;
+
+class AppTest {
+    @Test
+    void greeting() {
+        assertEquals("Hello, Java!", App.greeting());
+    }
+}
+`
+}
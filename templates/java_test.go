@@ -0,0 +1,117 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJavaTemplate(t *testing.T) {
+	tmpl, ok := Get("java")
+	if !ok {
+		t.Fatal("java template not registered")
+	}
+
+	deps := tmpl.Dependencies()
+	expectedDeps := []string{"git", "br", "java", "gradle/mvn"}
+	if len(deps) != len(expectedDeps) {
+		t.Fatalf("Dependencies() length = %d, want %d", len(deps), len(expectedDeps))
+	}
+	for i := range expectedDeps {
+		if deps[i] != expectedDeps[i] {
+			t.Errorf("Dependencies()[%d] = %q, want %q", i, deps[i], expectedDeps[i])
+		}
+	}
+
+	vars := DefaultVars("billing")
+	vars.Answers = map[string]any{"build": "gradle", "group_id": "com.acme.billing"}
+	files, err := Render(tmpl, vars)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	expectedFiles := []string{
+		".gitignore",
+		".ubsignore",
+		"README.md",
+		"build.gradle.kts",
+		"settings.gradle.kts",
+		"src/main/java/com/acme/billing/App.java",
+		"src/main/resources/.gitkeep",
+		"src/test/java/com/acme/billing/AppTest.java",
+		"src/test/resources/.gitkeep",
+	}
+	if len(files) != len(expectedFiles) {
+		t.Fatalf("Render() returned %d files, want %d: %v", len(files), len(expectedFiles), files)
+	}
+	for _, f := range expectedFiles {
+		if _, ok := files[f]; !ok {
+			t.Errorf("missing file: %s", f)
+		}
+	}
+
+	if !strings.HasPrefix(files["src/main/java/com/acme/billing/App.java"], "package com.acme.billing;") {
+		t.Error("App.java should declare the group ID as its package")
+	}
+	if !strings.Contains(files["build.gradle.kts"], `mainClass = "com.acme.billing.App"`) {
+		t.Error("build.gradle.kts should set the main class")
+	}
+	if !strings.Contains(files["settings.gradle.kts"], `rootProject.name = "billing"`) {
+		t.Error("settings.gradle.kts should name the project")
+	}
+	if !strings.Contains(files["README.md"], "./gradlew build") {
+		t.Error("README should document the Gradle build")
+	}
+	if !strings.Contains(files[".gitignore"], ".gradle/") {
+		t.Error(".gitignore should ignore the Gradle cache")
+	}
+}
+
+func TestJavaTemplate_Maven(t *testing.T) {
+	tmpl, _ := Get("java")
+
+	vars := DefaultVars("billing")
+	vars.Answers = map[string]any{"build": "maven", "group_id": "com.acme"}
+	files, err := Render(tmpl, vars)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	for _, name := range []string{"build.gradle.kts", "settings.gradle.kts"} {
+		if _, ok := files[name]; ok {
+			t.Errorf("%s should be left out of Maven builds", name)
+		}
+	}
+	pom, ok := files["pom.xml"]
+	if !ok {
+		t.Fatal("missing file: pom.xml")
+	}
+	if !strings.Contains(pom, "<groupId>com.acme</groupId>") || !strings.Contains(pom, "<artifactId>billing</artifactId>") {
+		t.Error("pom.xml should use the group ID and project name")
+	}
+	if _, ok := files["src/main/java/com/acme/App.java"]; !ok {
+		t.Error("sources should live under the group ID's package path")
+	}
+	if !strings.Contains(files["README.md"], "mvn verify") {
+		t.Error("README should document the Maven build")
+	}
+}
+
+func TestJavaTemplate_Hooks(t *testing.T) {
+	tmpl, _ := Get("java")
+	hooks := HooksOf(tmpl)
+	if len(hooks) != 1 || !hooks[0].Optional {
+		t.Fatalf("HooksOf() = %v, want one optional Gradle wrapper hook", hooks)
+	}
+
+	for build, want := range map[string]bool{"gradle": true, "maven": false} {
+		vars := DefaultVars("billing")
+		vars.Answers = map[string]any{"build": build, "group_id": "com.acme"}
+		got, err := EvalCondition(hooks[0].When, vars)
+		if err != nil {
+			t.Fatalf("EvalCondition() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("wrapper hook runs for %s builds = %v, want %v", build, got, want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package templates
+
+// Shared pieces of the java and kotlin templates. Both ask for the build
+// tool and the group ID; the group ID is the base package, so sources live
+// under e.g. src/main/java/com/example/.
+
+// jvmPackagePath renders the group ID as a directory path
+const jvmPackagePath = `{{replace .Answers.group_id "." "/"}}`
+
+// Build tool conditions for files that only one of Gradle and Maven needs
+const (
+	jvmIfGradle = `{{eq .Answers.build "gradle"}}`
+	jvmIfMaven  = `{{eq .Answers.build "maven"}}`
+)
+
+// jvmQuestions asks for the build tool and the group ID
+func jvmQuestions() []Question {
+	return []Question{
+		{Name: "build", Prompt: "Build tool", Type: QuestionChoice, Choices: []string{"gradle", "maven"}, Default: "gradle"},
+		{Name: "group_id", Prompt: "Group ID (base package)", Default: "com.example", Validate: `^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)*$`},
+	}
+}
+
+// jvmConditions keeps the Gradle files for Gradle builds and pom.xml for Maven
+func jvmConditions() map[string]string {
+	return map[string]string{
+		"build.gradle.kts":    jvmIfGradle,
+		"settings.gradle.kts": jvmIfGradle,
+		"pom.xml":             jvmIfMaven,
+	}
+}
+
+// jvmHooks generates the Gradle wrapper when Gradle is installed
+func jvmHooks() []Hook {
+	return []Hook{
+		{
+			Name:     "Generate Gradle wrapper",
+			Run:      "gradle wrapper",
+			Optional: true,
+			When:     jvmIfGradle,
+			Commit: []string{
+				"gradlew",
+				"gradlew.bat",
+				"gradle/wrapper/gradle-wrapper.jar",
+				"gradle/wrapper/gradle-wrapper.properties",
+			},
+		},
+	}
+}
+
+func jvmGitignore() string {
+	return `# Build output
+/build/
+/target/
+/out/
+*.class
+
+# Gradle
+.gradle/
+!gradle/wrapper/gradle-wrapper.jar
+
+# Kotlin
+.kotlin/
+
+# Logs and crash files
+*.log
+hs_err_pid*
+replay_pid*
+
+# IDE
+.vscode/
+.idea/
+*.iml
+.classpath
+.project
+.settings/
+*.swp
+*.swo
+
+# OS
+.DS_Store
+Thumbs.db
+
+# Environment
+.env
+`
+}
+
+func jvmUbsignore() string {
+	return `# UBS Scanner Ignore File
+build/
+target/
+out/
+.gradle/
+gradle/
+.kotlin/
+.git/
+.vscode/
+.idea/
+.beads/
+.maajise/
+.claude/
+*.md
+*.jar
+gradlew
+gradlew.bat
+`
+}
+
+// jvmReadmeCommands returns the README's build section for the chosen build tool
+func jvmReadmeCommands(mainClass string) string {
+	return `{{if eq .Answers.build "maven"}}` + "```bash" + `
+# Build and run the tests
+mvn verify
+
+# Run the application
+mvn -q exec:java
+
+# Run the tests only
+mvn test
+
+# Package a jar into target/
+mvn package
+` + "```" + `{{else}}` + "```bash" + `
+# Build and run the tests
+./gradlew build
+
+# Run the application
+./gradlew run
+
+# Run the tests only
+./gradlew test
+` + "```" + `
+
+Without the wrapper (generated by init when Gradle is installed), use ` + "`gradle`" + `
+instead of ` + "`./gradlew`" + `, or create it with ` + "`gradle wrapper`" + `.{{end}}
+
+The entry point is ` + "`" + mainClass + "`" + `.`
+}
@@ -0,0 +1,225 @@
+package templates
+
+import (
+	"fmt"
+
+	"maajise/internal/managed"
+)
+
+func init() {
+	Register(&KotlinTemplate{})
+}
+
+// KotlinTemplate is a Kotlin/JVM project template built with Gradle or Maven
+type KotlinTemplate struct{}
+
+// kotlinVersion is the Kotlin compiler and plugin version the template uses
+const kotlinVersion = "2.0.21"
+
+func (t *KotlinTemplate) Name() string {
+	return "kotlin"
+}
+
+func (t *KotlinTemplate) Description() string {
+	return "Kotlin/JVM project with Gradle (Kotlin DSL) or Maven and kotlin.test"
+}
+
+func (t *KotlinTemplate) Dependencies() []string {
+	return []string{"git", "br", "java", "gradle/mvn"}
+}
+
+func (t *KotlinTemplate) Questions() []Question {
+	return jvmQuestions()
+}
+
+func (t *KotlinTemplate) Conditions() map[string]string {
+	return jvmConditions()
+}
+
+func (t *KotlinTemplate) Hooks() []Hook {
+	return jvmHooks()
+}
+
+func (t *KotlinTemplate) Files(projectName string) map[string]string {
+	return map[string]string{
+		".gitignore":          managed.Wrap(t.Name(), jvmGitignore()),
+		".ubsignore":          managed.Wrap(t.Name(), jvmUbsignore()),
+		"README.md":           t.readme(projectName),
+		"build.gradle.kts":    t.buildGradle(),
+		"settings.gradle.kts": fmt.Sprintf("rootProject.name = %q\n", projectName),
+		"pom.xml":             t.pom(projectName),
+		"src/main/kotlin/" + jvmPackagePath + "/Main.kt":     t.mainKt(),
+		"src/main/resources/.gitkeep":                        "",
+		"src/test/kotlin/" + jvmPackagePath + "/MainTest.kt": t.mainTestKt(),
+		"src/test/resources/.gitkeep":                        "",
+	}
+}
+
+func (t *KotlinTemplate) readme(projectName string) string {
+	return fmt.Sprintf(`# %s
+
+A Kotlin/JVM project.
+
+## Project Structure
+
+| Directory | Purpose |
+|-----------|---------|
+| src/main/kotlin/ | Application code |
+| src/main/resources/ | Configuration and other resources |
+| src/test/kotlin/ | kotlin.test tests (JUnit 5) |
+| src/test/resources/ | Test resources |
+
+## Development
+
+`+jvmReadmeCommands("{{.Answers.group_id}}.MainKt")+`
+
+## Issue Tracking
+
+`+"```bash"+`
+br list           # View issues
+br create --title "Task"  # Create issue
+`+"```"+`
+
+## Code Quality
+
+`+"```bash"+`
+ubs .             # Scan for bugs
+`+"```"+`
+`, projectName)
+}
+
+func (t *KotlinTemplate) buildGradle() string {
+	return `plugins {
+    kotlin("jvm") version "` + kotlinVersion + `"
+    application
+}
+
+group = "{{.Answers.group_id}}"
+version = "0.1.0"
+
+repositories {
+    mavenCentral()
+}
+
+dependencies {
+    testImplementation(kotlin("test"))
+}
+
+kotlin {
+    jvmToolchain(21)
+}
+
+application {
+    mainClass = "{{.Answers.group_id}}.MainKt"
+}
+
+tasks.test {
+    useJUnitPlatform()
+}
+`
+}
+
+func (t *KotlinTemplate) pom(projectName string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0"
+         xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+         xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 https://maven.apache.org/xsd/maven-4.0.0.xsd">
+    <modelVersion>4.0.0</modelVersion>
+
+    <groupId>{{.Answers.group_id}}</groupId>
+    <artifactId>%s</artifactId>
+    <version>0.1.0</version>
+    <packaging>jar</packaging>
+{{with .License}}
+    <licenses>
+        <license>
+            <name>{{.}}</name>
+        </license>
+    </licenses>
+{{end}}
+    <properties>
+        <kotlin.version>%s</kotlin.version>
+        <kotlin.compiler.jvmTarget>21</kotlin.compiler.jvmTarget>
+        <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+        <exec.mainClass>{{.Answers.group_id}}.MainKt</exec.mainClass>
+    </properties>
+
+    <dependencies>
+        <dependency>
+            <groupId>org.jetbrains.kotlin</groupId>
+            <artifactId>kotlin-stdlib</artifactId>
+            <version>${kotlin.version}</version>
+        </dependency>
+        <dependency>
+            <groupId>org.jetbrains.kotlin</groupId>
+            <artifactId>kotlin-test-junit5</artifactId>
+            <version>${kotlin.version}</version>
+            <scope>test</scope>
+        </dependency>
+    </dependencies>
+
+    <build>
+        <sourceDirectory>src/main/kotlin</sourceDirectory>
+        <testSourceDirectory>src/test/kotlin</testSourceDirectory>
+        <plugins>
+            <plugin>
+                <groupId>org.jetbrains.kotlin</groupId>
+                <artifactId>kotlin-maven-plugin</artifactId>
+                <version>${kotlin.version}</version>
+                <executions>
+                    <execution>
+                        <id>compile</id>
+                        <goals>
+                            <goal>compile</goal>
+                        </goals>
+                    </execution>
+                    <execution>
+                        <id>test-compile</id>
+                        <goals>
+                            <goal>test-compile</goal>
+                        </goals>
+                    </execution>
+                </executions>
+            </plugin>
+            <plugin>
+                <groupId>org.apache.maven.plugins</groupId>
+                <artifactId>maven-surefire-plugin</artifactId>
+                <version>3.5.2</version>
+            </plugin>
+            <plugin>
+                <groupId>org.codehaus.mojo</groupId>
+                <artifactId>exec-maven-plugin</artifactId>
+                <version>3.5.0</version>
+            </plugin>
+        </plugins>
+    </build>
+</project>
+`, projectName, kotlinVersion)
+}
+
+func (t *KotlinTemplate) mainKt() string {
+	return `package {{.Answers.group_id}}
+
+fun greeting(): String = "Hello, Kotlin!"
+
+fun main() {
+    println(greeting())
+    // TODO: Add your application code here
+}
+`
+}
+
+func (t *KotlinTemplate) mainTestKt() string {
+	return `package {{.Answers.group_id}}
+
+import kotlin.test.Test
+import kotlin.test.assertEquals
+
+class MainTest {
+    @Test
+    fun greeting() {
+        assertEquals("Hello, Kotlin!", greeting())
+    }
+}
+`
+}
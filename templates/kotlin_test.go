@@ -0,0 +1,72 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKotlinTemplate(t *testing.T) {
+	tmpl, ok := Get("kotlin")
+	if !ok {
+		t.Fatal("kotlin template not registered")
+	}
+
+	vars := DefaultVars("ledger")
+	vars.Answers = map[string]any{"build": "gradle", "group_id": "io.acme"}
+	files, err := Render(tmpl, vars)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	expectedFiles := []string{
+		".gitignore",
+		".ubsignore",
+		"README.md",
+		"build.gradle.kts",
+		"settings.gradle.kts",
+		"src/main/kotlin/io/acme/Main.kt",
+		"src/main/resources/.gitkeep",
+		"src/test/kotlin/io/acme/MainTest.kt",
+		"src/test/resources/.gitkeep",
+	}
+	if len(files) != len(expectedFiles) {
+		t.Fatalf("Render() returned %d files, want %d: %v", len(files), len(expectedFiles), files)
+	}
+	for _, f := range expectedFiles {
+		if _, ok := files[f]; !ok {
+			t.Errorf("missing file: %s", f)
+		}
+	}
+
+	if !strings.HasPrefix(files["src/main/kotlin/io/acme/Main.kt"], "package io.acme\n") {
+		t.Error("Main.kt should declare the group ID as its package")
+	}
+	if !strings.Contains(files["build.gradle.kts"], `kotlin("jvm") version "`+kotlinVersion+`"`) {
+		t.Error("build.gradle.kts should apply the Kotlin JVM plugin")
+	}
+	if !strings.Contains(files["build.gradle.kts"], `mainClass = "io.acme.MainKt"`) {
+		t.Error("build.gradle.kts should set the main class")
+	}
+}
+
+func TestKotlinTemplate_Maven(t *testing.T) {
+	tmpl, _ := Get("kotlin")
+
+	vars := DefaultVars("ledger")
+	vars.Answers = map[string]any{"build": "maven", "group_id": "io.acme"}
+	files, err := Render(tmpl, vars)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if _, ok := files["build.gradle.kts"]; ok {
+		t.Error("build.gradle.kts should be left out of Maven builds")
+	}
+	pom := files["pom.xml"]
+	if !strings.Contains(pom, "<kotlin.version>"+kotlinVersion+"</kotlin.version>") {
+		t.Error("pom.xml should pin the Kotlin version")
+	}
+	if !strings.Contains(pom, "<sourceDirectory>src/main/kotlin</sourceDirectory>") {
+		t.Error("pom.xml should compile sources from src/main/kotlin")
+	}
+}
@@ -0,0 +1,108 @@
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LicenseFile is the file a license text is written to
+const LicenseFile = "LICENSE"
+
+//go:embed licenses/*.txt
+var licenseTexts embed.FS
+
+// licenses maps each supported SPDX identifier to its text in licenses/
+var licenses = map[string]string{
+	"Apache-2.0":       "Apache-2.0.txt",
+	"BSD-2-Clause":     "BSD-2-Clause.txt",
+	"BSD-3-Clause":     "BSD-3-Clause.txt",
+	"GPL-3.0-only":     "GPL-3.0.txt",
+	"GPL-3.0-or-later": "GPL-3.0.txt",
+	"ISC":              "ISC.txt",
+	"MIT":              "MIT.txt",
+	"MPL-2.0":          "MPL-2.0.txt",
+	"Unlicense":        "Unlicense.txt",
+}
+
+// licenseAliases maps common shorthands to SPDX identifiers
+var licenseAliases = map[string]string{
+	"apache":  "Apache-2.0",
+	"apache2": "Apache-2.0",
+	"bsd-2":   "BSD-2-Clause",
+	"bsd-3":   "BSD-3-Clause",
+	"gpl-3.0": "GPL-3.0-only",
+	"gpl-3":   "GPL-3.0-only",
+	"gpl3":    "GPL-3.0-only",
+	"mpl-2":   "MPL-2.0",
+	"mpl2":    "MPL-2.0",
+}
+
+// Licenses returns the SPDX identifiers with a bundled license text, sorted
+func Licenses() []string {
+	ids := make([]string, 0, len(licenses))
+	for id := range licenses {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// LicenseID returns the SPDX identifier for name, matched case-insensitively
+// and accepting shorthands such as "gpl-3.0" or "bsd-3"
+func LicenseID(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	for id := range licenses {
+		if strings.EqualFold(id, name) {
+			return id, true
+		}
+	}
+	id, ok := licenseAliases[strings.ToLower(name)]
+	return id, ok
+}
+
+// HasLicenseText reports whether a license text is bundled for the SPDX identifier
+func HasLicenseText(id string) bool {
+	_, ok := licenses[id]
+	return ok
+}
+
+// LicenseText returns the text of a license with the year and copyright
+// holder filled in. The holder is vars.Author, or the project's authors when
+// no author is set.
+func LicenseText(id string, vars TemplateVars) (string, error) {
+	file, ok := licenses[id]
+	if !ok {
+		return "", fmt.Errorf("no license text for %q (available: %s)", id, strings.Join(Licenses(), ", "))
+	}
+
+	text, err := licenseTexts.ReadFile("licenses/" + file)
+	if err != nil {
+		return "", err
+	}
+
+	if vars.Author == "" {
+		vars.Author = fmt.Sprintf("The %s Authors", vars.ProjectName)
+	}
+	return RenderString(LicenseFile, string(text), vars)
+}
+
+// AddLicense adds a LICENSE file for vars.License to rendered files, unless
+// the template ships its own or the license has no bundled text
+func AddLicense(files map[string]string, vars TemplateVars) error {
+	if _, ok := files[LicenseFile]; ok {
+		return nil
+	}
+	id, ok := LicenseID(vars.License)
+	if !ok {
+		return nil
+	}
+
+	text, err := LicenseText(id, vars)
+	if err != nil {
+		return err
+	}
+	files[LicenseFile] = text
+	return nil
+}
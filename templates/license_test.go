@@ -0,0 +1,139 @@
+package templates
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLicenseID(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{"MIT", "MIT", true},
+		{"apache-2.0", "Apache-2.0", true},
+		{"gpl-3.0", "GPL-3.0-only", true},
+		{"GPL-3.0-or-later", "GPL-3.0-or-later", true},
+		{"bsd-3", "BSD-3-Clause", true},
+		{" unlicense ", "Unlicense", true},
+		{"WTFPL", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := LicenseID(tt.name)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("LicenseID(%q) = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestLicenseText(t *testing.T) {
+	vars := DefaultVars("widget")
+	vars.Year = "2031"
+	vars.Author = "Jane Doe"
+
+	for _, id := range Licenses() {
+		text, err := LicenseText(id, vars)
+		if err != nil {
+			t.Errorf("LicenseText(%s) error = %v", id, err)
+			continue
+		}
+		if len(text) < 500 || strings.Contains(text, "{{") {
+			t.Errorf("LicenseText(%s) = %q, want the full rendered text", id, text)
+		}
+	}
+
+	mit, _ := LicenseText("MIT", vars)
+	if !strings.Contains(mit, "Copyright (c) 2031 Jane Doe\n") {
+		t.Errorf("MIT text should fill in year and author:\n%s", mit)
+	}
+
+	vars.Author = ""
+	bsd, _ := LicenseText("BSD-3-Clause", vars)
+	if !strings.Contains(bsd, "Copyright (c) 2031, The widget Authors\n") {
+		t.Errorf("BSD text should fall back to the project's authors:\n%s", bsd)
+	}
+
+	if _, err := LicenseText("WTFPL", vars); err == nil {
+		t.Error("LicenseText() should fail for a license without a bundled text")
+	}
+}
+
+func TestAddLicense(t *testing.T) {
+	vars := DefaultVars("widget")
+	files := map[string]string{"README.md": "# widget\n"}
+	if err := AddLicense(files, vars); err != nil {
+		t.Fatalf("AddLicense() error = %v", err)
+	}
+	if !strings.HasPrefix(files[LicenseFile], "MIT License\n") {
+		t.Errorf("LICENSE = %q, want the MIT text", files[LicenseFile])
+	}
+
+	// A template's own LICENSE wins, and unknown or no licenses add nothing
+	own := map[string]string{LicenseFile: "custom"}
+	AddLicense(own, vars)
+	if own[LicenseFile] != "custom" {
+		t.Errorf("LICENSE = %q, want the template's own", own[LicenseFile])
+	}
+	for _, license := range []string{"", "LicenseRef-Proprietary"} {
+		vars.License = license
+		files := map[string]string{}
+		AddLicense(files, vars)
+		if _, ok := files[LicenseFile]; ok {
+			t.Errorf("AddLicense() with license %q added LICENSE", license)
+		}
+	}
+}
+
+func TestRender_LicenseInManifests(t *testing.T) {
+	tests := []struct {
+		template string
+		file     string
+		want     string
+		none     string
+	}{
+		{"typescript", "package.json", `"license": "Apache-2.0"`, `"license": "UNLICENSED"`},
+		{"rust", "Cargo.toml", `license = "Apache-2.0"`, ""},
+		{"python", "pyproject.toml", `license = {text = "Apache-2.0"}`, ""},
+		{"php", "composer.json", `"license": "Apache-2.0"`, `"license": "proprietary"`},
+		{"base", "README.md", "Licensed under Apache-2.0.", "[Add license information here]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			tmpl, _ := Get(tt.template)
+			vars := DefaultVars("widget")
+			vars.License = "Apache-2.0"
+
+			files, err := Render(tmpl, vars)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if !strings.Contains(files[tt.file], tt.want) {
+				t.Errorf("%s = %q, want it to contain %q", tt.file, files[tt.file], tt.want)
+			}
+
+			vars.License = ""
+			files, err = Render(tmpl, vars)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if tt.none != "" && !strings.Contains(files[tt.file], tt.none) {
+				t.Errorf("%s = %q, want it to contain %q", tt.file, files[tt.file], tt.none)
+			}
+			if strings.Contains(files[tt.file], "license = ") {
+				t.Errorf("%s = %q, want no license without one", tt.file, files[tt.file])
+			}
+			if strings.HasSuffix(tt.file, ".json") {
+				var v map[string]any
+				if err := json.Unmarshal([]byte(files[tt.file]), &v); err != nil {
+					t.Errorf("%s is not valid JSON: %v", tt.file, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,480 @@
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"gopkg.in/yaml.v3"
+
+	"maajise/internal/fsutil"
+)
+
+// Lint issue severities. Errors make a template fail to load or render;
+// warnings are likely mistakes.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Issue is a problem found by Lint
+type Issue struct {
+	Path     string
+	Line     int // 0 when unknown
+	Severity string
+	Message  string
+}
+
+func (i Issue) String() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s: %s", i.Path, i.Line, i.Severity, i.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.Path, i.Severity, i.Message)
+}
+
+// Lint checks the custom templates at path: a YAML template, a directory
+// template, or a directory of templates such as ~/.maajise/templates
+func Lint(target string) []Issue {
+	info, err := os.Stat(target)
+	if err != nil {
+		return []Issue{{Path: target, Severity: SeverityError, Message: err.Error()}}
+	}
+	if !info.IsDir() {
+		return lintTemplate(target, "")
+	}
+	if _, err := os.Stat(filepath.Join(target, ManifestFile)); err == nil {
+		return lintTemplate(filepath.Join(target, ManifestFile), filepath.Join(target, FilesDir))
+	}
+
+	entries, err := os.ReadDir(target)
+	if err != nil {
+		return []Issue{{Path: target, Severity: SeverityError, Message: err.Error()}}
+	}
+	var issues []Issue
+	for _, entry := range entries {
+		path := filepath.Join(target, entry.Name())
+		if entry.IsDir() {
+			if _, err := os.Stat(filepath.Join(path, ManifestFile)); err == nil {
+				issues = append(issues, lintTemplate(filepath.Join(path, ManifestFile), filepath.Join(path, FilesDir))...)
+			}
+		} else if isYAMLFile(entry.Name()) {
+			issues = append(issues, lintTemplate(path, "")...)
+		}
+	}
+	return issues
+}
+
+// HasErrors reports whether any issue is an error
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// linter collects the issues of one template
+type linter struct {
+	path   string
+	doc    *yaml.Node
+	issues []Issue
+
+	variables map[string]bool // declared extra variables
+	questions map[string]bool // declared question names
+}
+
+func (l *linter) add(line int, severity, format string, args ...any) {
+	l.issues = append(l.issues, Issue{Path: l.path, Line: line, Severity: severity, Message: fmt.Sprintf(format, args...)})
+}
+
+// lineOfError extracts "line N" from a YAML error
+var lineOfError = regexp.MustCompile(`line (\d+)`)
+
+func errorLine(msg string) int {
+	if m := lineOfError.FindStringSubmatch(msg); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return n
+	}
+	return 0
+}
+
+// lintTemplate checks a YAML template, or the manifest of a directory
+// template together with its files/ tree when tree is set
+func lintTemplate(manifestPath, tree string) []Issue {
+	l := &linter{path: manifestPath}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		l.add(0, SeverityError, "%v", err)
+		return l.issues
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		l.add(errorLine(err.Error()), SeverityError, "invalid YAML: %s", strings.TrimPrefix(err.Error(), "yaml: "))
+		return l.issues
+	}
+	l.doc = &doc
+
+	// Unknown keys are ignored when loading, so they are usually typos
+	var ctf CustomTemplateFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&ctf); err != nil && err != io.EOF {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			l.add(errorLine(err.Error()), SeverityError, "%v", err)
+			return l.issues
+		}
+		for _, msg := range typeErr.Errors {
+			if strings.Contains(msg, "not found in type") {
+				l.add(errorLine(msg), SeverityWarning, "unknown key: %s", unknownKey(msg))
+			} else {
+				l.add(errorLine(msg), SeverityError, "%s", strings.TrimPrefix(msg, fmt.Sprintf("line %d: ", errorLine(msg))))
+			}
+		}
+		if HasErrors(l.issues) {
+			return l.issues
+		}
+	}
+
+	if ctf.Name == "" {
+		l.add(1, SeverityError, "missing name")
+	} else if IsBuiltin(ctf.Name) {
+		l.add(l.keyLine("name"), SeverityWarning, "name %q replaces the built-in template of the same name", ctf.Name)
+	}
+	if ctf.Extends != "" {
+		if _, ok := Get(ctf.Extends); !ok {
+			l.add(l.keyLine("extends"), SeverityError, "extends unknown template %q", ctf.Extends)
+		}
+	}
+
+	l.declare(ctf)
+
+	for i, q := range ctf.Questions {
+		line := l.itemLine("questions", i)
+		if err := q.Check(); err != nil {
+			l.add(line, SeverityError, "%v", err)
+		}
+		if q.When != "" {
+			l.checkText(line, "question "+q.Name+" when", q.When)
+		}
+	}
+	for i, h := range ctf.Hooks {
+		line := l.itemLine("hooks", i)
+		if err := h.Check(); err != nil {
+			l.add(line, SeverityError, "%v", err)
+		}
+		l.checkText(line, "hook "+h.Label(), h.Run)
+		for _, match := range unquotedVarPattern.FindAllStringSubmatch(h.Run, -1) {
+			l.add(line, SeverityWarning, "hook %s inserts {{%s}} unquoted into a shell command; use {{shquote %s}}", h.Label(), match[1], match[1])
+		}
+		l.checkText(line, "hook "+h.Label()+" dir", h.Dir)
+		for _, key := range sortedKeys(h.Env) {
+			l.checkText(line, "hook "+h.Label()+" env "+key, h.Env[key])
+		}
+		if h.When != "" {
+			l.checkText(line, "hook "+h.Label()+" when", h.When)
+		}
+	}
+	for key, value := range ctf.Variables {
+		if err := (&TemplateVars{}).Set(key, value); err != nil {
+			l.add(l.entryLine("variables", key), SeverityError, "%v", err)
+		}
+	}
+
+	for _, name := range sortedKeys(ctf.Files) {
+		line := l.entryLine("files", name)
+		l.checkPath(line, name)
+		l.checkText(line, name, name)
+		if !ctf.Files[name].Raw {
+			l.checkText(line, name, ctf.Files[name].Content)
+		}
+		if when := ctf.Files[name].When; when != "" {
+			l.checkText(line, name+" when", when)
+		}
+		if mode := ctf.Files[name].Mode; mode != "" {
+			if _, err := ParseMode(mode); err != nil {
+				l.add(line, SeverityError, "%s: %v", name, err)
+			}
+		}
+	}
+	for _, name := range sortedKeys(ctf.Append) {
+		line := l.entryLine("append", name)
+		l.checkPath(line, name)
+		l.checkText(line, name, ctf.Append[name])
+	}
+	for i, name := range ctf.Delete {
+		l.checkPath(l.itemLine("delete", i), name)
+	}
+	for _, name := range sortedKeys(ctf.When) {
+		line := l.entryLine("when", name)
+		l.checkPath(line, name)
+		l.checkText(line, name+" when", ctf.When[name])
+	}
+
+	if tree != "" {
+		l.lintTree(tree, ctf.Files)
+	}
+	return l.issues
+}
+
+// unquotedVarPattern matches a variable inserted as is, e.g. {{.ProjectName}},
+// which a hook's shell would split or even run as commands
+var unquotedVarPattern = regexp.MustCompile(`\{\{-?\s*(\.[A-Za-z_][A-Za-z0-9_.]*)\s*-?\}\}`)
+
+// unknownField extracts the key from a "field x not found" error
+var unknownField = regexp.MustCompile(`field (\S+) not found`)
+
+func unknownKey(msg string) string {
+	if m := unknownField.FindStringSubmatch(msg); m != nil {
+		return m[1]
+	}
+	return msg
+}
+
+// declare records the variables and questions the template declares,
+// inherited ones included
+func (l *linter) declare(ctf CustomTemplateFile) {
+	l.variables = make(map[string]bool)
+	l.questions = make(map[string]bool)
+
+	for key := range ctf.Variables {
+		l.variables[key] = true
+	}
+	for _, q := range ctf.Questions {
+		l.questions[q.Name] = true
+	}
+
+	if ctf.Extends == "" {
+		return
+	}
+	parent, err := parentTemplate(ctf.Extends, []string{ctf.Name})
+	if err != nil {
+		return
+	}
+	for key := range VariablesOf(parent) {
+		l.variables[key] = true
+	}
+	for _, q := range QuestionsOf(parent) {
+		l.questions[q.Name] = true
+	}
+}
+
+// lintTree checks the files/ tree of a directory template
+func (l *linter) lintTree(root string, yamlFiles map[string]FileEntry) {
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return
+	}
+
+	seen := make(map[string]string)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, _ := filepath.Rel(root, p)
+		name := filepath.ToSlash(rel)
+
+		tl := &linter{path: p, variables: l.variables, questions: l.questions}
+		if !d.Type().IsRegular() {
+			tl.add(0, SeverityWarning, "not a regular file, skipped")
+			l.issues = append(l.issues, tl.issues...)
+			return nil
+		}
+
+		target := strings.TrimSuffix(name, TemplateSuffix)
+		if other, ok := seen[target]; ok {
+			tl.add(0, SeverityError, "%s is also defined by %s", target, other)
+		} else if _, ok := yamlFiles[target]; ok {
+			tl.add(0, SeverityError, "%s is also defined under files: in %s", target, ManifestFile)
+		}
+		seen[target] = name
+
+		tl.checkText(0, name, name)
+		if strings.HasSuffix(name, TemplateSuffix) {
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			tl.checkText(0, name, string(data))
+		}
+		l.issues = append(l.issues, tl.issues...)
+		return nil
+	})
+	if err != nil {
+		l.add(0, SeverityError, "%v", err)
+	}
+}
+
+// checkPath rejects file keys that would write outside the project
+func (l *linter) checkPath(line int, name string) {
+	if err := fsutil.CheckRelPath(name); err != nil {
+		l.add(line, SeverityError, "%v", err)
+	}
+}
+
+// checkText parses text as a template and checks the variables it uses
+func (l *linter) checkText(line int, name, text string) {
+	if !strings.Contains(text, "{{") {
+		return
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(funcs).Parse(text)
+	if err != nil {
+		l.add(line, SeverityError, "invalid template syntax: %s", strings.TrimPrefix(err.Error(), "template: "))
+		return
+	}
+
+	for _, ref := range references(tmpl.Tree.Root) {
+		switch {
+		case ref[0] == "Answers":
+			if len(ref) > 1 && !l.questions[ref[1]] {
+				l.add(line, SeverityError, "%s uses {{.Answers.%s}}, but there is no question %q", name, ref[1], ref[1])
+			}
+		case dataFields[ref[0]]:
+		case !l.variables[ref[0]]:
+			l.add(line, SeverityWarning, "%s uses {{.%s}}, which is not declared under variables: (it must come from --var or ~/.maajiserc)", name, ref[0])
+		}
+	}
+}
+
+// dataFields are the names TemplateVars.Data always provides
+var dataFields = map[string]bool{
+	"ProjectName": true,
+	"Author":      true,
+	"Email":       true,
+	"Year":        true,
+	"License":     true,
+	"GitHub":      true,
+	"Branch":      true,
+	"Answers":     true,
+}
+
+// references returns the field chains a template reads from its data, such
+// as ["ProjectName"] or ["Answers", "docker"]. Fields inside range and with
+// blocks are relative to another value and are not included.
+func references(root parse.Node) [][]string {
+	var refs [][]string
+	var walk func(node parse.Node, atRoot bool)
+	walkPipe := func(pipe *parse.PipeNode, atRoot bool) {
+		if pipe == nil {
+			return
+		}
+		for _, cmd := range pipe.Cmds {
+			for _, arg := range cmd.Args {
+				walk(arg, atRoot)
+			}
+		}
+	}
+	walk = func(node parse.Node, atRoot bool) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child, atRoot)
+			}
+		case *parse.ActionNode:
+			walkPipe(n.Pipe, atRoot)
+		case *parse.PipeNode:
+			walkPipe(n, atRoot)
+		case *parse.FieldNode:
+			if atRoot {
+				refs = append(refs, n.Ident)
+			}
+		case *parse.VariableNode:
+			// $ is always the root data
+			if len(n.Ident) > 1 && n.Ident[0] == "$" {
+				refs = append(refs, n.Ident[1:])
+			}
+		case *parse.IfNode:
+			walkPipe(n.Pipe, atRoot)
+			walk(n.List, atRoot)
+			walk(n.ElseList, atRoot)
+		case *parse.RangeNode:
+			walkPipe(n.Pipe, atRoot)
+			walk(n.List, false)
+			walk(n.ElseList, atRoot)
+		case *parse.WithNode:
+			walkPipe(n.Pipe, atRoot)
+			walk(n.List, false)
+			walk(n.ElseList, atRoot)
+		case *parse.TemplateNode:
+			walkPipe(n.Pipe, atRoot)
+		}
+	}
+	walk(root, true)
+	return refs
+}
+
+// keyLine returns the line of a top-level key
+func (l *linter) keyLine(key string) int {
+	if node := l.section(key); node != nil {
+		return node.Line
+	}
+	return 0
+}
+
+// entryLine returns the line of key inside a top-level mapping
+func (l *linter) entryLine(section, key string) int {
+	node := l.section(section)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return l.keyLine(section)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i].Line
+		}
+	}
+	return node.Line
+}
+
+// itemLine returns the line of the i-th item of a top-level sequence
+func (l *linter) itemLine(section string, i int) int {
+	node := l.section(section)
+	if node == nil || node.Kind != yaml.SequenceNode || i >= len(node.Content) {
+		return l.keyLine(section)
+	}
+	return node.Content[i].Line
+}
+
+// section returns the value node of a top-level key
+func (l *linter) section(key string) *yaml.Node {
+	if l.doc == nil || len(l.doc.Content) == 0 {
+		return nil
+	}
+	root := l.doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			return root.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,227 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// findIssue returns the first issue whose message contains substr
+func findIssue(issues []Issue, substr string) (Issue, bool) {
+	for _, issue := range issues {
+		if strings.Contains(issue.Message, substr) {
+			return issue, true
+		}
+	}
+	return Issue{}, false
+}
+
+func TestLint(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-lint-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tests := []struct {
+		name     string
+		yaml     string
+		message  string
+		line     int
+		severity string
+	}{
+		{
+			name:     "yaml syntax",
+			yaml:     "name: broken\nfiles:\n  a.txt: \"unterminated\n",
+			message:  "invalid YAML",
+			line:     3,
+			severity: SeverityError,
+		},
+		{
+			name:     "missing name",
+			yaml:     "description: Nameless\n",
+			message:  "missing name",
+			line:     1,
+			severity: SeverityError,
+		},
+		{
+			name:     "unknown key",
+			yaml:     "name: typo\nfile:\n  a.txt: a\n",
+			message:  "unknown key: file",
+			line:     2,
+			severity: SeverityWarning,
+		},
+		{
+			name:     "template syntax",
+			yaml:     "name: syntax\nfiles:\n  README.md: a\n  main.go: \"{{.ProjectName\"\n",
+			message:  "invalid template syntax",
+			line:     4,
+			severity: SeverityError,
+		},
+		{
+			name:     "absolute path",
+			yaml:     "name: abs\nfiles:\n  /etc/passwd: root\n",
+			message:  "is absolute",
+			line:     3,
+			severity: SeverityError,
+		},
+		{
+			name:     "parent path",
+			yaml:     "name: parent\nappend:\n  ../.bashrc: alias x=y\n",
+			message:  "escapes the project directory",
+			line:     3,
+			severity: SeverityError,
+		},
+		{
+			name:     "undeclared variable",
+			yaml:     "name: undeclared\nfiles:\n  .env: \"PORT={{.port}}\"\n",
+			message:  "{{.port}}",
+			line:     3,
+			severity: SeverityWarning,
+		},
+		{
+			name:     "unknown answer",
+			yaml:     "name: answers\nfiles:\n  Dockerfile:\n    content: FROM scratch\n    when: \"{{.Answers.docker}}\"\n",
+			message:  "no question \"docker\"",
+			line:     3,
+			severity: SeverityError,
+		},
+		{
+			name:     "hook without run",
+			yaml:     "name: hooks\nhooks:\n  - run: make\n  - name: empty\n",
+			message:  "run is required",
+			line:     4,
+			severity: SeverityError,
+		},
+		{
+			name:     "unquoted hook variable",
+			yaml:     "name: unquoted\nhooks:\n  - run: \"git tag {{shquote .ProjectName}}\"\n  - run: \"echo {{.Author}}\"\n",
+			message:  "use {{shquote .Author}}",
+			line:     4,
+			severity: SeverityWarning,
+		},
+		{
+			name:     "builtin name",
+			yaml:     "name: go\nextends: go\n",
+			message:  "replaces the built-in",
+			line:     1,
+			severity: SeverityWarning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, strings.ReplaceAll(tt.name, " ", "-")+".yaml")
+			os.WriteFile(path, []byte(tt.yaml), 0644)
+
+			issues := Lint(path)
+			issue, ok := findIssue(issues, tt.message)
+			if !ok {
+				t.Fatalf("Lint() = %v, want an issue containing %q", issues, tt.message)
+			}
+			if issue.Line != tt.line {
+				t.Errorf("Line = %d, want %d", issue.Line, tt.line)
+			}
+			if issue.Severity != tt.severity {
+				t.Errorf("Severity = %q, want %q", issue.Severity, tt.severity)
+			}
+			if issue.Path != path {
+				t.Errorf("Path = %q, want %q", issue.Path, path)
+			}
+		})
+	}
+}
+
+func TestLint_Clean(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-lint-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "clean.yaml")
+	os.WriteFile(path, []byte(`name: clean
+variables:
+  port: "8080"
+questions:
+  - name: docker
+    type: bool
+files:
+  cmd/{{.ProjectName}}/main.go: "package main // {{.Author}} {{$.port}}\n"
+  Dockerfile:
+    content: "EXPOSE {{.port}}\n"
+    when: "{{.Answers.docker}}"
+  LIST.md: "{{range .Answers}}{{.Name}}{{end}}"
+`), 0644)
+
+	if issues := Lint(path); len(issues) != 0 {
+		t.Errorf("Lint() = %v, want no issues", issues)
+	}
+}
+
+func TestLint_DirectoryTemplate(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-lint-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	dir := filepath.Join(tmpDir, "tree")
+	os.MkdirAll(dir, 0755)
+	writeTree(t, dir, "name: tree\nfiles:\n  README.md: hi\n", map[string]string{
+		"main.go.tmpl": "package {{.ProjectName\n",
+		"raw.txt":      "{{not checked}}",
+		"README.md":    "duplicate",
+	}, nil)
+
+	// Linting the parent directory finds the directory template
+	issues := Lint(tmpDir)
+	syntax, ok := findIssue(issues, "invalid template syntax")
+	if !ok {
+		t.Fatalf("Lint() = %v, want a syntax issue", issues)
+	}
+	if want := filepath.Join(dir, FilesDir, "main.go.tmpl"); syntax.Path != want {
+		t.Errorf("Path = %q, want %q", syntax.Path, want)
+	}
+	if _, ok := findIssue(issues, "also defined under files:"); !ok {
+		t.Errorf("Lint() = %v, want a duplicate file issue", issues)
+	}
+	if !HasErrors(issues) {
+		t.Error("HasErrors() = false, want true")
+	}
+	if len(issues) != 2 {
+		t.Errorf("Lint() = %v, want 2 issues", issues)
+	}
+}
+
+func TestLoadCustomTemplates_Errors(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-lint-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	os.WriteFile(filepath.Join(tmpDir, "good.yaml"), []byte("name: lint-good\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "bad.yaml"), []byte("name: [\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "nameless.yml"), []byte("description: x\n"), 0644)
+
+	before := len(LoadErrors())
+	if err := LoadCustomTemplates(tmpDir); err != nil {
+		t.Fatalf("LoadCustomTemplates() error = %v", err)
+	}
+	defer func() { loadErrors = loadErrors[:before] }()
+
+	if _, ok := Get("lint-good"); !ok {
+		t.Error("lint-good not registered")
+	}
+	errs := LoadErrors()[before:]
+	if len(errs) != 2 {
+		t.Fatalf("LoadErrors() = %v, want 2 errors", errs)
+	}
+	for _, e := range errs {
+		if filepath.Dir(e.Path) != tmpDir || e.Err == nil {
+			t.Errorf("LoadError = %v, want an error for a file in %s", e, tmpDir)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Shared pieces of the c and cpp templates. Both build with CMake, keep
+// public headers under include/<project>/ and export compile_commands.json
+// into the build directory, where clangd and other tools find it.
+
+// nativeIdent turns a project name into a C identifier for namespaces,
+// header guards and file names, e.g. my-tool becomes my_tool
+func nativeIdent(projectName string) string {
+	var b strings.Builder
+	for i, r := range projectName {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteRune('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
+
+// nativeHooks configures the build directory so compile_commands.json exists
+// right away; it is optional so init still works without CMake
+func nativeHooks() []Hook {
+	return []Hook{
+		{Name: "Configure CMake", Run: "cmake -S . -B build -DCMAKE_BUILD_TYPE=Debug", Optional: true},
+	}
+}
+
+func nativeGitignore() string {
+	return `# Build output
+/build/
+/cmake-build-*/
+/out/
+/builddir/
+
+# CMake
+CMakeCache.txt
+CMakeFiles/
+CMakeUserPresets.json
+cmake_install.cmake
+CTestTestfile.cmake
+Testing/
+
+# Compilation database and clangd cache
+compile_commands.json
+.cache/
+
+# Objects and binaries
+*.o
+*.obj
+*.a
+*.lib
+*.so
+*.so.*
+*.dylib
+*.dll
+*.exe
+*.pdb
+
+# IDE
+.vscode/
+.idea/
+*.swp
+*.swo
+
+# OS
+.DS_Store
+Thumbs.db
+
+# Environment
+.env
+`
+}
+
+func nativeUbsignore() string {
+	return `# UBS Scanner Ignore File
+build/
+cmake-build-*/
+out/
+builddir/
+CMakeFiles/
+Testing/
+.cache/
+third_party/
+external/
+vendor/
+.git/
+.vscode/
+.idea/
+.beads/
+.maajise/
+.claude/
+*.md
+*.cmake
+compile_commands.json
+`
+}
+
+// nativeClangFormat is an LLVM-based style with 4-space indents
+func nativeClangFormat() string {
+	return `---
+BasedOnStyle: LLVM
+IndentWidth: 4
+ColumnLimit: 100
+PointerAlignment: Left
+AllowShortFunctionsOnASingleLine: Empty
+IncludeBlocks: Regroup
+`
+}
+
+// nativeReadme returns the README of a CMake project written in language
+func nativeReadme(projectName, language string) string {
+	ident := nativeIdent(projectName)
+	return fmt.Sprintf(`# %[1]s
+
+A %[2]s project built with CMake.
+
+## Project Structure
+
+| Directory | Purpose |
+|-----------|---------|
+| include/%[1]s/ | Public headers |
+| src/ | Library and application sources |
+| tests/ | Tests run by CTest |
+
+## Development
+
+`+"```bash"+`
+# Configure a debug build (writes build/compile_commands.json)
+cmake -S . -B build -DCMAKE_BUILD_TYPE=Debug
+
+# Build
+cmake --build build
+
+# Run the application
+./build/%[1]s
+
+# Run the tests
+ctest --test-dir build --output-on-failure
+
+# Format the sources
+clang-format -i src/* include/%[1]s/* tests/*
+`+"```"+`
+
+The library target is `+"`%[3]s_lib`"+`; the application and the tests link against it.
+Editors using clangd pick up `+"`build/compile_commands.json`"+` automatically.
+
+## Issue Tracking
+
+`+"```bash"+`
+br list           # View issues
+br create --title "Task"  # Create issue
+`+"```"+`
+
+## Code Quality
+
+`+"```bash"+`
+ubs .             # Scan for bugs
+`+"```"+`
+`, projectName, language, ident)
+}
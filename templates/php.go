@@ -1,6 +1,10 @@
 package templates
 
-import "fmt"
+import (
+	"fmt"
+
+	"maajise/internal/managed"
+)
 
 func init() {
 	Register(&PHPTemplate{})
@@ -21,10 +25,16 @@ func (t *PHPTemplate) Dependencies() []string {
 	return []string{"git", "br", "php", "composer"}
 }
 
+func (t *PHPTemplate) Hooks() []Hook {
+	return []Hook{
+		{Name: "Install Composer dependencies", Run: "composer install", Optional: true, Commit: []string{"composer.lock"}},
+	}
+}
+
 func (t *PHPTemplate) Files(projectName string) map[string]string {
 	return map[string]string{
-		".gitignore":                 t.gitignore(),
-		".ubsignore":                 t.ubsignore(),
+		".gitignore":                 managed.Wrap(t.Name(), t.gitignore()),
+		".ubsignore":                 managed.Wrap(t.Name(), t.ubsignore()),
 		"README.md":                  t.readme(projectName),
 		"composer.json":              t.composerJSON(projectName),
 		"bin/.gitkeep":               "",
@@ -88,6 +98,9 @@ public/assets/
 
 # Tests (scan separately if needed)
 tests/
+
+# Maajise generation baselines
+.maajise/
 `
 }
 
@@ -201,6 +214,7 @@ func (t *PHPTemplate) composerJSON(projectName string) string {
     "name": "project/%s",
     "description": "",
     "type": "project",
+    "license": "{{if .License}}{{.License}}{{else}}proprietary{{end}}",
     "require": {
         "php": ">=8.1"
     },
@@ -1,6 +1,10 @@
 package templates
 
-import "fmt"
+import (
+	"fmt"
+
+	"maajise/internal/managed"
+)
 
 func init() {
 	Register(&PythonTemplate{})
@@ -23,8 +27,8 @@ func (t *PythonTemplate) Dependencies() []string {
 
 func (t *PythonTemplate) Files(projectName string) map[string]string {
 	return map[string]string{
-		".gitignore":       t.gitignore(),
-		".ubsignore":       t.ubsignore(),
+		".gitignore":       managed.Wrap(t.Name(), t.gitignore()),
+		".ubsignore":       managed.Wrap(t.Name(), t.ubsignore()),
 		"README.md":        t.readme(projectName),
 		"pyproject.toml":   t.pyproject(projectName),
 		"requirements.txt": t.requirements(),
@@ -89,6 +93,7 @@ build/
 .vscode/
 .idea/
 .beads/
+.maajise/
 .claude/
 .pytest_cache/
 htmlcov/
@@ -159,7 +164,8 @@ version = "0.1.0"
 description = ""
 readme = "README.md"
 requires-python = ">=3.9"
-dependencies = []
+{{with .License}}license = {text = "{{.}}"}
+{{end}}dependencies = []
 
 [project.optional-dependencies]
 dev = [
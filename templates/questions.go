@@ -0,0 +1,166 @@
+package templates
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Question types
+const (
+	QuestionString = "string"
+	QuestionBool   = "bool"
+	QuestionChoice = "choice"
+)
+
+// Question is an input a template asks for in addition to TemplateVars. The
+// answer is available to rendering as {{.Answers.<name>}}; bool answers are
+// real booleans, so {{if .Answers.docker}} works.
+type Question struct {
+	Name     string   `yaml:"name"`
+	Prompt   string   `yaml:"prompt"`
+	Type     string   `yaml:"type"`
+	Default  string   `yaml:"default"`
+	Choices  []string `yaml:"choices"`
+	Validate string   `yaml:"validate"`
+	When     string   `yaml:"when"`
+}
+
+// Questioner is implemented by templates that ask questions
+type Questioner interface {
+	Questions() []Question
+}
+
+// QuestionsOf returns the questions a template asks, if any
+func QuestionsOf(tmpl Template) []Question {
+	if q, ok := tmpl.(Questioner); ok {
+		return q.Questions()
+	}
+	return nil
+}
+
+// Label is the text shown when prompting
+func (q Question) Label() string {
+	if q.Prompt != "" {
+		return q.Prompt
+	}
+	return q.Name
+}
+
+// kind returns the question type, defaulting to string
+func (q Question) kind() string {
+	if q.Type == "" {
+		return QuestionString
+	}
+	return q.Type
+}
+
+// IsBool reports whether the question is a yes/no question
+func (q Question) IsBool() bool {
+	return q.kind() == QuestionBool
+}
+
+// Check validates the question definition itself
+func (q Question) Check() error {
+	if !varKeyPattern.MatchString(q.Name) {
+		return fmt.Errorf("question name %q must use letters, digits and underscores", q.Name)
+	}
+
+	switch q.kind() {
+	case QuestionString, QuestionBool:
+	case QuestionChoice:
+		if len(q.Choices) == 0 {
+			return fmt.Errorf("question %s: choice questions need choices", q.Name)
+		}
+	default:
+		return fmt.Errorf("question %s: unknown type %q (use string, bool or choice)", q.Name, q.Type)
+	}
+
+	if q.Validate != "" {
+		if _, err := regexp.Compile(q.Validate); err != nil {
+			return fmt.Errorf("question %s: invalid validate pattern: %w", q.Name, err)
+		}
+	}
+
+	if q.Default != "" {
+		if _, err := q.Parse(q.Default); err != nil {
+			return fmt.Errorf("question %s: invalid default: %w", q.Name, err)
+		}
+	}
+	return nil
+}
+
+// Parse converts an answer to its typed value, validating it
+func (q Question) Parse(answer string) (any, error) {
+	answer = strings.TrimSpace(answer)
+
+	switch q.kind() {
+	case QuestionBool:
+		switch strings.ToLower(answer) {
+		case "y", "yes", "true", "1":
+			return true, nil
+		case "n", "no", "false", "0":
+			return false, nil
+		}
+		return nil, fmt.Errorf("%q is not a yes/no answer", answer)
+
+	case QuestionChoice:
+		for _, choice := range q.Choices {
+			if answer == choice {
+				return choice, nil
+			}
+		}
+		return nil, fmt.Errorf("%q is not one of %s", answer, strings.Join(q.Choices, ", "))
+	}
+
+	if q.Validate != "" {
+		re, err := regexp.Compile(q.Validate)
+		if err != nil {
+			return nil, err
+		}
+		if !re.MatchString(answer) {
+			return nil, fmt.Errorf("%q does not match %s", answer, q.Validate)
+		}
+	}
+	return answer, nil
+}
+
+// DefaultValue returns the typed default answer. Bool questions default to
+// false; other questions without a default have none and must be answered.
+func (q Question) DefaultValue() (any, bool) {
+	if q.Default == "" {
+		if q.IsBool() {
+			return false, true
+		}
+		return nil, false
+	}
+	value, err := q.Parse(q.Default)
+	return value, err == nil
+}
+
+// Applies evaluates the question's when condition against the answers so far
+func (q Question) Applies(vars TemplateVars) (bool, error) {
+	if q.When == "" {
+		return true, nil
+	}
+	ok, err := EvalCondition(q.When, vars)
+	if err != nil {
+		return false, fmt.Errorf("question %s: %w", q.Name, err)
+	}
+	return ok, nil
+}
+
+// EvalCondition renders a condition such as "{{ .Answers.docker }}" and
+// reports whether the result is true. Empty, "false", "no" and "0" are false.
+func EvalCondition(condition string, vars TemplateVars) (bool, error) {
+	result, err := RenderString("when", condition, vars)
+	if err != nil {
+		return false, fmt.Errorf("condition %q: %w", condition, err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(result)) {
+	case "", "false", "no", "0", "<no value>":
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,179 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestQuestion_Parse(t *testing.T) {
+	tests := []struct {
+		question Question
+		answer   string
+		want     any
+		wantErr  bool
+	}{
+		{Question{Name: "docker", Type: QuestionBool}, "yes", true, false},
+		{Question{Name: "docker", Type: QuestionBool}, "0", false, false},
+		{Question{Name: "docker", Type: QuestionBool}, "maybe", nil, true},
+		{Question{Name: "db", Type: QuestionChoice, Choices: []string{"postgres", "sqlite"}}, "sqlite", "sqlite", false},
+		{Question{Name: "db", Type: QuestionChoice, Choices: []string{"postgres", "sqlite"}}, "mysql", nil, true},
+		{Question{Name: "port", Validate: `^[0-9]+$`}, " 8080 ", "8080", false},
+		{Question{Name: "port", Validate: `^[0-9]+$`}, "http", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.question.Parse(tt.answer)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s.Parse(%q) error = %v, wantErr %v", tt.question.Name, tt.answer, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("%s.Parse(%q) = %v, want %v", tt.question.Name, tt.answer, got, tt.want)
+		}
+	}
+}
+
+func TestQuestion_Check(t *testing.T) {
+	valid := []Question{
+		{Name: "service"},
+		{Name: "docker", Type: QuestionBool, Default: "yes"},
+		{Name: "db", Type: QuestionChoice, Choices: []string{"postgres"}, Default: "postgres"},
+	}
+	for _, q := range valid {
+		if err := q.Check(); err != nil {
+			t.Errorf("Check(%s) error = %v", q.Name, err)
+		}
+	}
+
+	invalid := []Question{
+		{Name: "not-valid"},
+		{Name: "x", Type: "number"},
+		{Name: "db", Type: QuestionChoice},
+		{Name: "port", Validate: "("},
+		{Name: "db", Type: QuestionChoice, Choices: []string{"postgres"}, Default: "mysql"},
+	}
+	for _, q := range invalid {
+		if err := q.Check(); err == nil {
+			t.Errorf("Check(%+v) should fail", q)
+		}
+	}
+}
+
+func TestQuestion_DefaultValue(t *testing.T) {
+	if v, ok := (Question{Name: "docker", Type: QuestionBool}).DefaultValue(); !ok || v != false {
+		t.Errorf("bool DefaultValue() = %v, %v, want false, true", v, ok)
+	}
+	if _, ok := (Question{Name: "service"}).DefaultValue(); ok {
+		t.Error("a string question without a default should have no default value")
+	}
+	if v, ok := (Question{Name: "service", Default: "api"}).DefaultValue(); !ok || v != "api" {
+		t.Errorf("DefaultValue() = %v, %v, want api, true", v, ok)
+	}
+}
+
+func TestEvalCondition(t *testing.T) {
+	vars := DefaultVars("app")
+	vars.Answers = map[string]any{"docker": true, "db": "sqlite", "ci": false}
+
+	tests := []struct {
+		condition string
+		want      bool
+	}{
+		{"{{.Answers.docker}}", true},
+		{"{{.Answers.ci}}", false},
+		{`{{eq .Answers.db "sqlite"}}`, true},
+		{`{{eq .Answers.db "postgres"}}`, false},
+		{"{{if .Answers.docker}}yes{{end}}", true},
+	}
+	for _, tt := range tests {
+		got, err := EvalCondition(tt.condition, vars)
+		if err != nil {
+			t.Errorf("EvalCondition(%q) error = %v", tt.condition, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("EvalCondition(%q) = %v, want %v", tt.condition, got, tt.want)
+		}
+	}
+
+	if _, err := EvalCondition("{{.Answers.missing}}", vars); err == nil {
+		t.Error("EvalCondition() should fail on an unknown answer")
+	}
+}
+
+func TestCustomTemplate_Questions(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-questions-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	os.WriteFile(filepath.Join(tmpDir, "q-parent.yaml"), []byte(`name: q-parent
+questions:
+  - name: docker
+    type: bool
+  - name: service
+    default: api
+files:
+  README.md: "# {{.ProjectName}}"
+`), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "q-child.yaml"), []byte(`name: q-child
+extends: q-parent
+questions:
+  - name: service
+    prompt: Service name
+    default: worker
+  - name: db
+    type: choice
+    choices: [postgres, sqlite]
+    default: sqlite
+    when: "{{.Answers.docker}}"
+`), 0644)
+	if err := LoadCustomTemplates(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, ok := Get("q-child")
+	if !ok {
+		t.Fatal("q-child not loaded")
+	}
+
+	questions := QuestionsOf(tmpl)
+	var names []string
+	for _, q := range questions {
+		names = append(names, q.Name)
+	}
+	if len(names) != 3 || names[0] != "docker" || names[1] != "service" || names[2] != "db" {
+		t.Fatalf("Questions() = %v, want [docker service db]", names)
+	}
+	if questions[1].Default != "worker" {
+		t.Errorf("service default = %q, want the child's %q", questions[1].Default, "worker")
+	}
+
+	if QuestionsOf(&BaseTemplate{}) != nil {
+		t.Error("built-in templates should not ask questions")
+	}
+}
+
+func TestLoadCustomTemplate_InvalidQuestion(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-questions-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "bad.yaml")
+	os.WriteFile(path, []byte(`name: bad-questions
+questions:
+  - name: db
+    type: choice
+`), 0644)
+
+	if err := loadCustomTemplate(path); err == nil {
+		t.Error("loadCustomTemplate() should reject a choice question without choices")
+	}
+	if _, ok := Get("bad-questions"); ok {
+		t.Error("a template with an invalid question should not be registered")
+	}
+}
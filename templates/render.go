@@ -0,0 +1,199 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"maajise/internal/fsutil"
+)
+
+// funcs are the functions available in templates, e.g. a package path as
+// {{replace .Answers.group_id "." "/"}} or a variable in a hook's run command
+// as {{shquote .Author}}
+var funcs = template.FuncMap{
+	"replace": strings.ReplaceAll,
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"shquote": shellQuote,
+}
+
+// shellQuote quotes s as a single sh word in single quotes, so a quote in
+// O'Brien can't end it early and $(...) or ; in a value is never run
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// varKeyPattern matches keys usable as {{.key}} in a template
+var varKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// builtinVars maps the normalized spelling of each TemplateVars field to the
+// field itself, so "project_name", "projectName" and "ProjectName" all work
+var builtinVars = map[string]func(v *TemplateVars) *string{
+	"projectname": func(v *TemplateVars) *string { return &v.ProjectName },
+	"author":      func(v *TemplateVars) *string { return &v.Author },
+	"email":       func(v *TemplateVars) *string { return &v.Email },
+	"year":        func(v *TemplateVars) *string { return &v.Year },
+	"license":     func(v *TemplateVars) *string { return &v.License },
+	"github":      func(v *TemplateVars) *string { return &v.GitHub },
+	"branch":      func(v *TemplateVars) *string { return &v.Branch },
+}
+
+// Set assigns a variable by key. Keys naming a TemplateVars field set that
+// field; any other key becomes an extra variable available as {{.key}}.
+func (v *TemplateVars) Set(key, value string) error {
+	normalized := strings.ToLower(strings.ReplaceAll(key, "_", ""))
+	if field, ok := builtinVars[normalized]; ok {
+		*field(v) = value
+		return nil
+	}
+
+	if !varKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid variable name %q (use letters, digits and underscores)", key)
+	}
+	if v.Extra == nil {
+		v.Extra = make(map[string]string)
+	}
+	v.Extra[key] = value
+	return nil
+}
+
+// Has reports whether key is set: a non-empty field, or a present extra variable
+func (v TemplateVars) Has(key string) bool {
+	normalized := strings.ToLower(strings.ReplaceAll(key, "_", ""))
+	if field, ok := builtinVars[normalized]; ok {
+		return *field(&v) != ""
+	}
+	_, ok := v.Extra[key]
+	return ok
+}
+
+// ParseVar splits a "key=value" assignment as given to --var
+func ParseVar(assignment string) (string, string, error) {
+	key, value, ok := strings.Cut(assignment, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return "", "", fmt.Errorf("invalid variable %q (expected key=value)", assignment)
+	}
+	return key, value, nil
+}
+
+// Data returns the variables as template data: the TemplateVars fields under
+// their Go names, every extra variable, and the question answers as .Answers
+func (v TemplateVars) Data() map[string]any {
+	data := map[string]any{
+		"ProjectName": v.ProjectName,
+		"Author":      v.Author,
+		"Email":       v.Email,
+		"Year":        v.Year,
+		"License":     v.License,
+		"GitHub":      v.GitHub,
+		"Branch":      v.Branch,
+	}
+	answers := make(map[string]any, len(v.Answers))
+	for key, value := range v.Answers {
+		answers[key] = value
+	}
+	data["Answers"] = answers
+
+	for key, value := range v.Extra {
+		if _, ok := data[key]; !ok {
+			data[key] = value
+		}
+	}
+	return data
+}
+
+// RenderString renders content as a text/template with vars. Unknown
+// variables are an error rather than "<no value>". A literal "{{" is written
+// as {{"{{"}}; files of custom templates can skip rendering altogether with
+// raw: true (YAML) or by not ending in .tmpl (directory templates).
+func RenderString(name, content string, vars TemplateVars) (string, error) {
+	if !strings.Contains(content, "{{") {
+		return content, nil
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(funcs).Parse(content)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars.Data()); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Render returns the files of tmpl with both paths and contents rendered
+// with vars, e.g. "cmd/{{.ProjectName}}/main.go". Files whose condition is
+// false are left out, and verbatim files (raw YAML entries and directory
+// template files without .tmpl) keep their content as is.
+func Render(tmpl Template, vars TemplateVars) (map[string]string, error) {
+	if c, ok := tmpl.(*Composite); ok {
+		return c.render(vars)
+	}
+
+	var files map[string]string
+	var verbatim map[string]bool
+	if custom, ok := tmpl.(*CustomTemplate); ok {
+		raw, err := custom.resolveFiles(vars.ProjectName, nil)
+		if err != nil {
+			return nil, err
+		}
+		files = raw
+		verbatim = custom.resolveVerbatim(nil)
+	} else {
+		files = tmpl.Files(vars.ProjectName)
+	}
+
+	// Sorted so errors and collisions are reported deterministically
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	conditions := ConditionsOf(tmpl)
+	result := make(map[string]string, len(files))
+	source := make(map[string]string, len(files))
+	for _, name := range names {
+		ok, err := included(name, conditions, vars)
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %w", name, err)
+		}
+		if !ok {
+			continue
+		}
+
+		path, err := RenderString(name, name, vars)
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %w", name, err)
+		}
+		if strings.TrimSpace(path) == "" {
+			return nil, fmt.Errorf("path %s renders to an empty name", name)
+		}
+		if err := fsutil.CheckRelPath(path); err != nil {
+			return nil, fmt.Errorf("path %s: %w", name, err)
+		}
+		if other, ok := source[path]; ok {
+			return nil, fmt.Errorf("paths %s and %s both render to %s", other, name, path)
+		}
+
+		content := files[name]
+		if !verbatim[name] {
+			content, err = RenderString(name, content, vars)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result[path] = content
+		source[path] = name
+	}
+
+	return result, nil
+}
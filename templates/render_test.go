@@ -0,0 +1,154 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTemplateVars_Set(t *testing.T) {
+	vars := DefaultVars("app")
+
+	for key, value := range map[string]string{
+		"author":       "Jane",
+		"GitHub":       "jane",
+		"project_name": "renamed",
+		"team":         "platform",
+	} {
+		if err := vars.Set(key, value); err != nil {
+			t.Fatalf("Set(%q) error = %v", key, err)
+		}
+	}
+
+	if vars.Author != "Jane" || vars.GitHub != "jane" || vars.ProjectName != "renamed" {
+		t.Errorf("Set() did not update the fields: %+v", vars)
+	}
+	if vars.Extra["team"] != "platform" {
+		t.Errorf("Extra[team] = %q, want %q", vars.Extra["team"], "platform")
+	}
+
+	if err := vars.Set("not-valid", "x"); err == nil {
+		t.Error("Set() should reject keys that can't be used in a template")
+	}
+}
+
+func TestParseVar(t *testing.T) {
+	key, value, err := ParseVar("team=platform=core")
+	if err != nil || key != "team" || value != "platform=core" {
+		t.Errorf("ParseVar() = %q, %q, %v", key, value, err)
+	}
+
+	for _, bad := range []string{"team", "=value", ""} {
+		if _, _, err := ParseVar(bad); err == nil {
+			t.Errorf("ParseVar(%q) should fail", bad)
+		}
+	}
+}
+
+func TestRenderString(t *testing.T) {
+	vars := DefaultVars("app")
+	vars.Author = "Jane"
+	vars.Set("team", "platform")
+
+	got, err := RenderString("README.md", "# {{.ProjectName}} by {{.Author}} ({{.team}})", vars)
+	if err != nil {
+		t.Fatalf("RenderString() error = %v", err)
+	}
+	if got != "# app by Jane (platform)" {
+		t.Errorf("RenderString() = %q", got)
+	}
+
+	if _, err := RenderString("README.md", "{{.Autor}}", vars); err == nil {
+		t.Error("RenderString() should fail on an unknown variable")
+	}
+	if _, err := RenderString("README.md", "{{.Author", vars); err == nil {
+		t.Error("RenderString() should fail on a syntax error")
+	}
+}
+
+func TestRenderString_Shquote(t *testing.T) {
+	vars := DefaultVars("app; rm -rf ~")
+	vars.Author = "O'Brien $(id)"
+
+	got, err := RenderString("hook", "echo {{shquote .ProjectName}} {{shquote .Author}}", vars)
+	if err != nil {
+		t.Fatalf("RenderString() error = %v", err)
+	}
+	if want := `echo 'app; rm -rf ~' 'O'\''Brien $(id)'`; got != want {
+		t.Errorf("RenderString() = %q, want %q", got, want)
+	}
+}
+
+func TestRender_CustomTemplatePaths(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-render-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "render-paths.yaml")
+	os.WriteFile(path, []byte(`name: render-paths
+files:
+  "cmd/{{.ProjectName}}/main.go": |
+    // Copyright {{.Year}} {{.Author}}
+    package main
+`), 0644)
+	if err := loadCustomTemplate(path); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, _ := Get("render-paths")
+	vars := DefaultVars("tool")
+	vars.Author = "Jane"
+	vars.Year = "2030"
+
+	files, err := Render(tmpl, vars)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	content, ok := files["cmd/tool/main.go"]
+	if !ok {
+		t.Fatalf("Render() paths = %v, want cmd/tool/main.go", files)
+	}
+	if !strings.Contains(content, "Copyright 2030 Jane") {
+		t.Errorf("content not rendered: %q", content)
+	}
+}
+
+func TestRender_PathCollision(t *testing.T) {
+	tmpl := &CustomTemplate{
+		name: "collide",
+		files: map[string]string{
+			"{{.ProjectName}}.md": "a",
+			"app.md":              "b",
+		},
+	}
+
+	if _, err := Render(tmpl, DefaultVars("app")); err == nil {
+		t.Error("Render() should fail when two paths render to the same name")
+	}
+}
+
+func TestRender_UnsafePaths(t *testing.T) {
+	for _, name := range []string{"../outside.txt", "/etc/x", "{{.ProjectName}}/../../x", "{{.Dir}}/x"} {
+		tmpl := &CustomTemplate{name: "unsafe", files: map[string]string{name: "x"}}
+		vars := DefaultVars("app")
+		vars.Set("Dir", "..")
+		if _, err := Render(tmpl, vars); err == nil {
+			t.Errorf("Render() should fail for path %q", name)
+		}
+	}
+}
+
+func TestRender_BuiltinTemplate(t *testing.T) {
+	tmpl, _ := Get("go")
+	files, err := Render(tmpl, DefaultVars("svc"))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if _, ok := files["cmd/svc/main.go"]; !ok {
+		t.Error("Render() should keep the built-in template's files")
+	}
+}
@@ -0,0 +1,279 @@
+package templates
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"maajise/internal/managed"
+)
+
+func init() {
+	Register(&RubyTemplate{})
+}
+
+// RubyTemplate is a Ruby gem laid out like `bundle gem` with Minitest
+type RubyTemplate struct{}
+
+func (t *RubyTemplate) Name() string {
+	return "ruby"
+}
+
+func (t *RubyTemplate) Description() string {
+	return "Ruby gem with Bundler, Rake and Minitest (lib/, test/)"
+}
+
+func (t *RubyTemplate) Dependencies() []string {
+	return []string{"git", "br", "ruby", "bundle"}
+}
+
+func (t *RubyTemplate) Hooks() []Hook {
+	return []Hook{
+		{Name: "Install gems", Run: "bundle install", Optional: true, Commit: []string{"Gemfile.lock"}},
+	}
+}
+
+func (t *RubyTemplate) Files(projectName string) map[string]string {
+	lib := "lib/" + rubyPath(projectName)
+	return map[string]string{
+		".gitignore":             managed.Wrap(t.Name(), t.gitignore()),
+		".ubsignore":             managed.Wrap(t.Name(), t.ubsignore()),
+		"README.md":              t.readme(projectName),
+		"Gemfile":                t.gemfile(),
+		projectName + ".gemspec": t.gemspec(projectName),
+		"Rakefile":               t.rakefile(),
+		lib + ".rb":              t.lib(projectName),
+		lib + "/version.rb":      t.version(projectName),
+		"test/test_helper.rb":    t.testHelper(projectName),
+		"test/test_" + strings.ReplaceAll(projectName, "-", "_") + ".rb": t.test(projectName),
+	}
+}
+
+// rubyPath returns the require path of a gem; as with `bundle gem`, dashes
+// nest, so my-gem lives in lib/my/gem.rb
+func rubyPath(gemName string) string {
+	return strings.ReplaceAll(gemName, "-", "/")
+}
+
+// rubyModules returns the nested module names of a gem, e.g. My and Gem for
+// my-gem and MyGem for my_gem
+func rubyModules(gemName string) []string {
+	parts := strings.Split(gemName, "-")
+	modules := make([]string, len(parts))
+	for i, part := range parts {
+		modules[i] = pascalName(part)
+	}
+	return modules
+}
+
+// rubyNest wraps body in the gem's nested module declarations
+func rubyNest(gemName, body string) string {
+	modules := rubyModules(gemName)
+	var b strings.Builder
+	for i, module := range modules {
+		fmt.Fprintf(&b, "%smodule %s\n", strings.Repeat("  ", i), module)
+	}
+	indent := strings.Repeat("  ", len(modules))
+	for _, line := range strings.Split(strings.TrimRight(body, "\n"), "\n") {
+		if line == "" {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString(indent + line + "\n")
+	}
+	for i := len(modules) - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, "%send\n", strings.Repeat("  ", i))
+	}
+	return b.String()
+}
+
+func (t *RubyTemplate) gitignore() string {
+	return `# Bundler
+/.bundle/
+/vendor/bundle/
+
+# Build output
+/pkg/
+/tmp/
+*.gem
+
+# Docs and coverage
+/.yardoc/
+/_yardoc/
+/doc/
+/coverage/
+
+# IDE
+.vscode/
+.idea/
+*.swp
+*.swo
+
+# OS
+.DS_Store
+Thumbs.db
+
+# Environment
+.env
+`
+}
+
+func (t *RubyTemplate) ubsignore() string {
+	return `# UBS Scanner Ignore File
+.bundle/
+vendor/
+pkg/
+tmp/
+coverage/
+doc/
+.git/
+.vscode/
+.idea/
+.beads/
+.maajise/
+.claude/
+*.md
+*.gem
+Gemfile.lock
+`
+}
+
+func (t *RubyTemplate) readme(projectName string) string {
+	return fmt.Sprintf(`# %[1]s
+
+A Ruby gem.
+
+## Setup
+
+`+"```bash"+`
+# Install dependencies
+bundle install
+`+"```"+`
+
+## Development
+
+`+"```bash"+`
+# Run the tests
+bundle exec rake test
+
+# Open a console with the gem loaded
+bundle exec irb -r ./lib/%[2]s
+
+# Build the gem into pkg/
+bundle exec rake build
+
+# Install the gem locally
+bundle exec rake install
+`+"```"+`
+
+## Issue Tracking
+
+`+"```bash"+`
+br list           # View issues
+br create --title "Task"  # Create issue
+`+"```"+`
+
+## Code Quality
+
+`+"```bash"+`
+ubs .             # Scan for bugs
+`+"```"+`
+`, projectName, rubyPath(projectName))
+}
+
+func (t *RubyTemplate) gemfile() string {
+	return `# frozen_string_literal: true
+
+source "https://rubygems.org"
+
+# Runtime dependencies are declared in the gemspec
+gemspec
+
+gem "minitest", "~> 5.25"
+gem "rake", "~> 13.2"
+`
+}
+
+func (t *RubyTemplate) gemspec(projectName string) string {
+	return fmt.Sprintf(`# frozen_string_literal: true
+
+require_relative "lib/%[2]s/version"
+
+Gem::Specification.new do |spec|
+  spec.name = "%[1]s"
+  spec.version = %[3]s::VERSION
+  spec.authors = ["{{.Author}}"]
+  spec.email = ["{{.Email}}"]
+
+  spec.summary = "TODO: Write a short summary"{{with .GitHub}}
+  spec.homepage = "https://github.com/{{.}}/%[1]s"{{end}}{{with .License}}
+  spec.license = "{{.}}"{{end}}
+  spec.required_ruby_version = ">= 3.1.0"
+
+  spec.files = Dir["lib/**/*.rb"]
+  spec.require_paths = ["lib"]
+end
+`, projectName, rubyPath(projectName), strings.Join(rubyModules(projectName), "::"))
+}
+
+func (t *RubyTemplate) rakefile() string {
+	return `# frozen_string_literal: true
+
+require "bundler/gem_tasks"
+require "rake/testtask"
+
+Rake::TestTask.new(:test) do |t|
+  t.libs << "test"
+  t.libs << "lib"
+  t.test_files = FileList["test/**/test_*.rb"]
+end
+
+task default: :test
+`
+}
+
+func (t *RubyTemplate) lib(projectName string) string {
+	return fmt.Sprintf(`# frozen_string_literal: true
+
+require_relative "%s/version"
+
+`, path.Base(rubyPath(projectName))) + rubyNest(projectName, `class Error < StandardError; end
+
+def self.greeting
+  "Hello, Ruby!"
+end
+`)
+}
+
+func (t *RubyTemplate) version(projectName string) string {
+	return "# frozen_string_literal: true\n\n" + rubyNest(projectName, `VERSION = "0.1.0"
+`)
+}
+
+func (t *RubyTemplate) testHelper(projectName string) string {
+	return fmt.Sprintf(`# frozen_string_literal: true
+
+$LOAD_PATH.unshift File.expand_path("../lib", __dir__)
+require "%s"
+
+require "minitest/autorun"
+`, rubyPath(projectName))
+}
+
+func (t *RubyTemplate) test(projectName string) string {
+	module := strings.Join(rubyModules(projectName), "::")
+	return fmt.Sprintf(`# frozen_string_literal: true
+
+require "test_helper"
+
+class Test%[1]s < Minitest::Test
+  def test_that_it_has_a_version_number
+    refute_nil ::%[2]s::VERSION
+  end
+
+  def test_greeting
+    assert_equal "Hello, Ruby!", ::%[2]s.greeting
+  end
+end
+`, strings.Join(rubyModules(projectName), ""), module)
+}
@@ -0,0 +1,74 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRubyTemplate(t *testing.T) {
+	tmpl, ok := Get("ruby")
+	if !ok {
+		t.Fatal("ruby template not registered")
+	}
+
+	files := tmpl.Files("cache_store")
+
+	expectedFiles := []string{
+		".gitignore",
+		".ubsignore",
+		"README.md",
+		"Gemfile",
+		"cache_store.gemspec",
+		"Rakefile",
+		"lib/cache_store.rb",
+		"lib/cache_store/version.rb",
+		"test/test_helper.rb",
+		"test/test_cache_store.rb",
+	}
+	if len(files) != len(expectedFiles) {
+		t.Fatalf("Files() returned %d files, want %d", len(files), len(expectedFiles))
+	}
+	for _, f := range expectedFiles {
+		if _, ok := files[f]; !ok {
+			t.Errorf("missing file: %s", f)
+		}
+	}
+
+	if !strings.Contains(files["lib/cache_store/version.rb"], "module CacheStore\n  VERSION = \"0.1.0\"\nend\n") {
+		t.Errorf("version.rb = %q", files["lib/cache_store/version.rb"])
+	}
+	if !strings.Contains(files["cache_store.gemspec"], "spec.version = CacheStore::VERSION") {
+		t.Error("gemspec should read the version from the gem")
+	}
+	if !strings.Contains(files["README.md"], "bundle exec rake test") {
+		t.Error("README should document how to run the tests")
+	}
+}
+
+func TestRubyTemplate_DashedName(t *testing.T) {
+	tmpl, _ := Get("ruby")
+
+	vars := DefaultVars("rack-cache")
+	vars.License = "MIT"
+	files, err := Render(tmpl, vars)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	lib, ok := files["lib/rack/cache.rb"]
+	if !ok {
+		t.Fatal("dashes should nest the require path as with bundle gem")
+	}
+	if !strings.Contains(lib, "require_relative \"cache/version\"") {
+		t.Error("lib file should require its version file")
+	}
+	if !strings.Contains(lib, "module Rack\n  module Cache\n") {
+		t.Error("dashes should nest the modules")
+	}
+	if !strings.Contains(files["test/test_rack_cache.rb"], "::Rack::Cache.greeting") {
+		t.Error("test should use the nested module")
+	}
+	if !strings.Contains(files["rack-cache.gemspec"], `spec.license = "MIT"`) {
+		t.Error("gemspec should declare the license")
+	}
+}
@@ -1,6 +1,10 @@
 package templates
 
-import "fmt"
+import (
+	"fmt"
+
+	"maajise/internal/managed"
+)
 
 func init() {
 	Register(&RustTemplate{})
@@ -21,10 +25,16 @@ func (t *RustTemplate) Dependencies() []string {
 	return []string{"git", "br", "cargo", "rustc"}
 }
 
+func (t *RustTemplate) Hooks() []Hook {
+	return []Hook{
+		{Name: "Generate Cargo.lock", Run: "cargo generate-lockfile", Optional: true, Commit: []string{"Cargo.lock"}},
+	}
+}
+
 func (t *RustTemplate) Files(projectName string) map[string]string {
 	return map[string]string{
-		".gitignore":  t.gitignore(),
-		".ubsignore":  t.ubsignore(),
+		".gitignore":  managed.Wrap(t.Name(), t.gitignore()),
+		".ubsignore":  managed.Wrap(t.Name(), t.ubsignore()),
 		"README.md":   t.readme(projectName),
 		"Cargo.toml":  t.cargoToml(projectName),
 		"src/main.rs": t.mainRs(),
@@ -58,6 +68,7 @@ target/
 .vscode/
 .idea/
 .beads/
+.maajise/
 .claude/
 *.md
 *.toml
@@ -119,7 +130,8 @@ func (t *RustTemplate) cargoToml(projectName string) string {
 name = "%s"
 version = "0.1.0"
 edition = "2021"
-
+{{with .License}}license = "{{.}}"
+{{end}}
 [dependencies]
 `, projectName)
 }
@@ -3,6 +3,8 @@ package templates
 import (
 	"fmt"
 	"strings"
+
+	"maajise/internal/managed"
 )
 
 func init() {
@@ -26,8 +28,8 @@ func (t *SwiftTemplate) Dependencies() []string {
 
 func (t *SwiftTemplate) Files(projectName string) map[string]string {
 	return map[string]string{
-		".gitignore": t.gitignore(),
-		".ubsignore": t.ubsignore(),
+		".gitignore": managed.Wrap(t.Name(), t.gitignore()),
+		".ubsignore": managed.Wrap(t.Name(), t.ubsignore()),
 		"README.md": t.readme(projectName),
 		"Package.swift": t.packageSwift(projectName),
 		"Sources/" + projectName + "/main.swift": t.mainSwift(),
@@ -57,6 +59,7 @@ Packages/
 xcuserdata/
 .git/
 .beads/
+.maajise/
 *.md
 `
 }
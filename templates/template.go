@@ -1,14 +1,15 @@
 package templates
 
 import (
-	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
-	"text/template"
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"maajise/internal/managed"
 )
 
 // Template defines the interface for project templates
@@ -21,12 +22,19 @@ type Template interface {
 
 // TemplateVars holds variables for template substitution
 type TemplateVars struct {
-	ProjectName string
-	Author      string
-	Email       string
-	Year        string
-	License     string
-	GitHub      string
+	ProjectName string `yaml:"project_name"`
+	Author      string `yaml:"author,omitempty"`
+	Email       string `yaml:"email,omitempty"`
+	Year        string `yaml:"year,omitempty"`
+	License     string `yaml:"license,omitempty"`
+	GitHub      string `yaml:"github,omitempty"`
+	Branch      string `yaml:"branch,omitempty"` // main Git branch, e.g. for CI triggers
+
+	// Extra holds user-defined variables, rendered as {{.key}}
+	Extra map[string]string `yaml:"extra,omitempty"`
+
+	// Answers holds the answers to the template's questions, rendered as {{.Answers.name}}
+	Answers map[string]any `yaml:"answers,omitempty"`
 }
 
 // DefaultVars returns TemplateVars with sensible defaults
@@ -38,44 +46,41 @@ func DefaultVars(projectName string) TemplateVars {
 	}
 }
 
-// ProcessTemplate applies variable substitution to content
+// ProcessTemplate applies variable substitution to content, like RenderString
 func ProcessTemplate(content string, vars TemplateVars) (string, error) {
-	tmpl, err := template.New("content").Parse(content)
-	if err != nil {
-		// If template parsing fails, return original content
-		// This handles files that aren't meant to have variables
-		return content, nil
-	}
-
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, vars); err != nil {
-		return content, nil
-	}
-
-	return buf.String(), nil
+	return RenderString("content", content, vars)
 }
 
-// FilesWithVars returns template files with variable substitution applied
+// FilesWithVars returns template files with variable substitution applied.
+// Unlike Render it never fails: if rendering does, the unrendered files are
+// returned.
 func FilesWithVars(tmpl Template, vars TemplateVars) map[string]string {
-	files := tmpl.Files(vars.ProjectName)
-	result := make(map[string]string, len(files))
-
-	for name, content := range files {
-		processed, _ := ProcessTemplate(content, vars)
-		result[name] = processed
+	files, err := Render(tmpl, vars)
+	if err != nil {
+		return tmpl.Files(vars.ProjectName)
 	}
-
-	return result
+	return files
 }
 
 // Registry holds all available templates
 var registry = make(map[string]Template)
 
+// builtins holds the names of the templates compiled into maajise
+var builtins = make(map[string]bool)
+
 // Register adds a template to the registry
 func Register(tmpl Template) {
+	if _, custom := tmpl.(*CustomTemplate); !custom {
+		builtins[tmpl.Name()] = true
+	}
 	registry[tmpl.Name()] = tmpl
 }
 
+// IsBuiltin reports whether name is a built-in template
+func IsBuiltin(name string) bool {
+	return builtins[name]
+}
+
 // Get retrieves a template by name
 func Get(name string) (Template, bool) {
 	tmpl, ok := registry[name]
@@ -104,33 +109,233 @@ func List() []string {
 type CustomTemplate struct {
 	name        string
 	description string
+	extends     string
+	questions   []Question
 	deps        []string
+	variables   map[string]string
 	files       map[string]string
+	conditions  map[string]string
+	verbatim    map[string]bool
+	modes       map[string]os.FileMode
+	appends     map[string]string
+	deletes     []string
+	hooks       []Hook
 }
 
 func (t *CustomTemplate) Name() string        { return t.name }
 func (t *CustomTemplate) Description() string { return t.description }
-func (t *CustomTemplate) Dependencies() []string { return t.deps }
+
+// Extends returns the name of the template this one inherits from, if any
+func (t *CustomTemplate) Extends() string { return t.extends }
+
+// Questions returns the inherited questions followed by the template's own.
+// A question with the same name as an inherited one replaces it.
+func (t *CustomTemplate) Questions() []Question {
+	return t.resolveQuestions(nil)
+}
+
+// resolveQuestions is Questions with cycle detection through seen
+func (t *CustomTemplate) resolveQuestions(seen []string) []Question {
+	var questions []Question
+	if t.extends != "" {
+		if parent, err := parentTemplate(t.extends, append(seen, t.name)); err == nil {
+			if custom, ok := parent.(*CustomTemplate); ok {
+				questions = custom.resolveQuestions(append(seen, t.name))
+			} else {
+				questions = QuestionsOf(parent)
+			}
+		}
+	}
+	return mergeQuestions(questions, t.questions)
+}
+
+// mergeQuestions appends own to inherited, replacing inherited questions of the same name
+func mergeQuestions(inherited, own []Question) []Question {
+	result := make([]Question, 0, len(inherited)+len(own))
+	for _, q := range inherited {
+		replaced := false
+		for _, o := range own {
+			if o.Name == q.Name {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			result = append(result, q)
+		}
+	}
+	return append(result, own...)
+}
+
+func (t *CustomTemplate) Dependencies() []string {
+	deps, _ := t.resolveDeps(nil)
+	return deps
+}
+
+// Files returns the template's files on top of the files it inherits,
+// rendered with the default variables for projectName. If the inheritance
+// chain is broken or rendering fails, the files are returned as far as they
+// could be resolved; use Resolve and Render to report the problem.
 func (t *CustomTemplate) Files(projectName string) map[string]string {
-	// Replace {{.ProjectName}} in file contents
-	result := make(map[string]string, len(t.files))
+	if files, err := Render(t, DefaultVars(projectName)); err == nil {
+		return files
+	}
+	files, _ := t.resolveFiles(projectName, nil)
+	return files
+}
+
+// resolveFiles builds the unrendered file set: inherited files, minus deleted
+// ones, replaced by the template's own files, then with appends added at the
+// end. seen holds the templates already visited, to detect cycles.
+func (t *CustomTemplate) resolveFiles(projectName string, seen []string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	var err error
+	if t.extends != "" {
+		var inherited map[string]string
+		inherited, err = inheritedFiles(t.extends, projectName, append(seen, t.name))
+		for name, content := range inherited {
+			result[name] = content
+		}
+	}
+
+	for _, name := range t.deletes {
+		delete(result, name)
+	}
+
 	for name, content := range t.files {
-		// Simple replacement for project name
-		processed := strings.ReplaceAll(content, "{{.ProjectName}}", projectName)
+		processed := content
+		if managedFiles[name] && !managed.Has(processed) {
+			processed = managed.Wrap(t.name, processed)
+		}
 		result[name] = processed
 	}
-	return result
+
+	for name, content := range t.appends {
+		processed := content
+		if managedFiles[name] {
+			// A block of its own, so the inherited block stays intact
+			processed = managed.Wrap(t.name, processed)
+		}
+		result[name] = appendContent(result[name], processed)
+	}
+
+	return result, err
+}
+
+// resolveDeps merges the inherited dependencies with the template's own
+func (t *CustomTemplate) resolveDeps(seen []string) ([]string, error) {
+	var deps []string
+	var err error
+	if t.extends != "" {
+		var parent Template
+		parent, err = parentTemplate(t.extends, append(seen, t.name))
+		if err == nil {
+			if custom, ok := parent.(*CustomTemplate); ok {
+				deps, err = custom.resolveDeps(append(seen, t.name))
+			} else {
+				deps = parent.Dependencies()
+			}
+		}
+	}
+
+	for _, dep := range t.deps {
+		if !contains(deps, dep) {
+			deps = append(deps, dep)
+		}
+	}
+	return deps, err
+}
+
+// inheritedFiles returns the files of the named parent template
+func inheritedFiles(name, projectName string, seen []string) (map[string]string, error) {
+	parent, err := parentTemplate(name, seen)
+	if err != nil {
+		return nil, err
+	}
+	if custom, ok := parent.(*CustomTemplate); ok {
+		return custom.resolveFiles(projectName, seen)
+	}
+	return parent.Files(projectName), nil
+}
+
+// parentTemplate looks up the template named by an extends key. seen is the
+// chain of templates that led here, ending with the one that extends name.
+func parentTemplate(name string, seen []string) (Template, error) {
+	if contains(seen, name) {
+		return nil, fmt.Errorf("inheritance cycle: %s", strings.Join(append(seen, name), " -> "))
+	}
+	parent, ok := Get(name)
+	if !ok {
+		return nil, fmt.Errorf("%s extends unknown template %q", seen[len(seen)-1], name)
+	}
+	return parent, nil
+}
+
+// appendContent adds extra to the end of content, starting it on a new line
+func appendContent(content, extra string) string {
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return content + extra
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
 }
 
-// CustomTemplateFile represents the YAML structure for custom templates
+// Resolve checks that a template's inheritance chain can be resolved: every
+// extended template exists and there are no cycles. Built-in templates always
+// resolve.
+func Resolve(tmpl Template) error {
+	custom, ok := tmpl.(*CustomTemplate)
+	if !ok {
+		return nil
+	}
+	if _, err := custom.resolveFiles(custom.name, nil); err != nil {
+		return err
+	}
+	_, err := custom.resolveDeps(nil)
+	return err
+}
+
+// managedFiles are wrapped in maajise-managed blocks so that update and add
+// only rewrite the generated lines and keep lines the user added around them
+var managedFiles = map[string]bool{
+	".gitignore": true,
+	".ubsignore": true,
+}
+
+// CustomTemplateFile represents the YAML structure for custom templates.
+// With Extends set, the template starts from another template's files:
+// Files add or replace files, Append adds to the end of inherited files and
+// Delete drops inherited files. Dependencies are merged. When maps files, or
+// directories ending in "/", to conditions that must hold for them to be
+// generated, in addition to the when of a file itself. Hooks run after the
+// inherited ones.
 type CustomTemplateFile struct {
-	Name         string            `yaml:"name"`
-	Description  string            `yaml:"description"`
-	Dependencies []string          `yaml:"dependencies"`
-	Files        map[string]string `yaml:"files"`
+	Name         string               `yaml:"name"`
+	Description  string               `yaml:"description,omitempty"`
+	Extends      string               `yaml:"extends,omitempty"`
+	Questions    []Question           `yaml:"questions,omitempty"`
+	Dependencies []string             `yaml:"dependencies,omitempty"`
+	Variables    map[string]string    `yaml:"variables,omitempty"`
+	Files        map[string]FileEntry `yaml:"files,omitempty"`
+	Append       map[string]string    `yaml:"append,omitempty"`
+	Delete       []string             `yaml:"delete,omitempty"`
+	When         map[string]string    `yaml:"when,omitempty"`
+	Hooks        []Hook               `yaml:"hooks,omitempty"`
 }
 
-// LoadCustomTemplates loads templates from a directory
+// LoadCustomTemplates loads templates from a directory: YAML files, and
+// directory templates (subdirectories with a template.yaml). A custom template
+// replaces a registered template of the same name, so it must be called once
+// the built-ins are registered, i.e. not from a package init.
 func LoadCustomTemplates(dir string) error {
 	if dir == "" {
 		return nil
@@ -145,49 +350,163 @@ func LoadCustomTemplates(dir string) error {
 	}
 
 	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
 		if entry.IsDir() {
+			if _, err := os.Stat(filepath.Join(path, ManifestFile)); err != nil {
+				continue
+			}
+			tmpl, err := loadTemplateDir(path)
+			if err != nil {
+				// Reported by LoadErrors; the other templates still load
+				loadErrors = append(loadErrors, LoadError{Path: path, Err: err})
+				continue
+			}
+			Register(tmpl)
 			continue
 		}
 
-		name := entry.Name()
-		if !strings.HasSuffix(name, ".yaml") && !strings.HasSuffix(name, ".yml") {
+		if !isYAMLFile(entry.Name()) {
 			continue
 		}
-
-		path := filepath.Join(dir, name)
 		if err := loadCustomTemplate(path); err != nil {
-			// Log warning but continue
-			// Can't import ui here, just skip
+			loadErrors = append(loadErrors, LoadError{Path: path, Err: err})
 		}
 	}
 
 	return nil
 }
 
+// LoadError is a custom template that failed to load
+type LoadError struct {
+	Path string
+	Err  error
+}
+
+func (e LoadError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// loadErrors collects the errors of LoadCustomTemplates
+var loadErrors []LoadError
+
+// LoadErrors returns the custom templates that failed to load, so commands
+// can report them; 'maajise template lint' explains them in detail
+func LoadErrors() []LoadError {
+	return loadErrors
+}
+
+func isYAMLFile(name string) bool {
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
+
 func loadCustomTemplate(path string) error {
-	data, err := os.ReadFile(path)
+	tmpl, err := LoadFile(path)
 	if err != nil {
 		return err
 	}
 
+	Register(tmpl)
+	return nil
+}
+
+// LoadFile loads the YAML template at path without registering it
+func LoadFile(path string) (*CustomTemplate, error) {
+	tmpl, err := parseCustomTemplate(path)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl == nil {
+		return nil, fmt.Errorf("%s: template has no name", path)
+	}
+	return tmpl, nil
+}
+
+// ManifestFile is the template manifest at the root of a template directory
+const ManifestFile = "template.yaml"
+
+// LoadDir loads the directory template in dir, such as a fetched template
+// source. The template is returned, not registered, so it can't shadow a
+// registered template of the same name.
+func LoadDir(dir string) (*CustomTemplate, error) {
+	if _, err := os.Stat(filepath.Join(dir, ManifestFile)); err != nil {
+		return nil, fmt.Errorf("no %s in %s", ManifestFile, dir)
+	}
+	return loadTemplateDir(dir)
+}
+
+// parseCustomTemplate reads a YAML template. It returns nil for a template
+// without a name.
+func parseCustomTemplate(path string) (*CustomTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
 	var ctf CustomTemplateFile
 	if err := yaml.Unmarshal(data, &ctf); err != nil {
-		return err
+		return nil, err
 	}
 
 	if ctf.Name == "" {
-		return nil // Skip templates without a name
+		return nil, nil
+	}
+
+	for _, q := range ctf.Questions {
+		if err := q.Check(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for key, value := range ctf.Variables {
+		if err := (&TemplateVars{}).Set(key, value); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for _, h := range ctf.Hooks {
+		if err := h.Check(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	files := make(map[string]string, len(ctf.Files))
+	conditions := make(map[string]string, len(ctf.When))
+	for path, condition := range ctf.When {
+		conditions[path] = condition
+	}
+	modes := make(map[string]os.FileMode)
+	verbatim := make(map[string]bool)
+	for path, entry := range ctf.Files {
+		files[path] = entry.Content
+		if entry.When != "" {
+			conditions[path] = entry.When
+		}
+		if entry.Raw {
+			verbatim[path] = true
+		}
+		if entry.Mode != "" {
+			mode, err := ParseMode(entry.Mode)
+			if err != nil {
+				return nil, fmt.Errorf("%s: file %s: %w", ctf.Name, path, err)
+			}
+			modes[path] = mode
+		}
 	}
 
 	tmpl := &CustomTemplate{
 		name:        ctf.Name,
 		description: ctf.Description,
+		extends:     ctf.Extends,
+		questions:   ctf.Questions,
 		deps:        ctf.Dependencies,
-		files:       ctf.Files,
+		variables:   ctf.Variables,
+		files:       files,
+		conditions:  conditions,
+		verbatim:    verbatim,
+		modes:       modes,
+		appends:     ctf.Append,
+		deletes:     ctf.Delete,
+		hooks:       ctf.Hooks,
 	}
-
-	Register(tmpl)
-	return nil
+	return tmpl, nil
 }
 
 // DefaultCustomTemplatesDir returns ~/.maajise/templates/
@@ -198,8 +517,3 @@ func DefaultCustomTemplatesDir() string {
 	}
 	return filepath.Join(home, ".maajise", "templates")
 }
-
-func init() {
-	// Load custom templates from default directory
-	LoadCustomTemplates(DefaultCustomTemplatesDir())
-}
@@ -0,0 +1,241 @@
+package templates
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A directory template is a directory holding a template.yaml manifest, in the
+// same format as a YAML template, and a files/ tree. Files in the tree are
+// copied verbatim, byte for byte and with their mode; files ending in .tmpl
+// are rendered like YAML file contents and written without the suffix.
+const (
+	FilesDir       = "files"
+	TemplateSuffix = ".tmpl"
+)
+
+// FileModer is implemented by templates with files that need a mode other
+// than 0644, such as executable scripts
+type FileModer interface {
+	FileModes() map[string]os.FileMode
+}
+
+// Variabler is implemented by templates that provide default values for
+// template variables
+type Variabler interface {
+	Variables() map[string]string
+}
+
+// VariablesOf returns the default variables of a template, if any
+func VariablesOf(tmpl Template) map[string]string {
+	if v, ok := tmpl.(Variabler); ok {
+		return v.Variables()
+	}
+	return nil
+}
+
+// Modes returns the mode of each file of tmpl that needs one, keyed by the
+// rendered path. Files without an entry are written with 0644.
+func Modes(tmpl Template, vars TemplateVars) map[string]os.FileMode {
+	moder, ok := tmpl.(FileModer)
+	if !ok {
+		return nil
+	}
+
+	modes := make(map[string]os.FileMode)
+	for name, mode := range moder.FileModes() {
+		if path, err := RenderString(name, name, vars); err == nil {
+			modes[path] = mode
+		}
+	}
+	return modes
+}
+
+// FileMode returns the mode to write a generated file with: the template's
+// mode for it, else 0755 for a script starting with a shebang, else 0644
+func FileMode(modes map[string]os.FileMode, path, content string) os.FileMode {
+	if mode, ok := modes[path]; ok && mode != 0 {
+		return mode
+	}
+	if strings.HasPrefix(content, "#!") {
+		return 0755
+	}
+	return 0644
+}
+
+// ParseMode parses an octal file mode such as "0755", "755" or "0o755"
+func ParseMode(s string) (os.FileMode, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "0o"), "0O")
+	mode, err := strconv.ParseUint(digits, 8, 32)
+	if err != nil || digits == "" {
+		return 0, fmt.Errorf("invalid mode %q (use octal, e.g. \"0755\")", s)
+	}
+	if mode == 0 || mode > 0777 {
+		return 0, fmt.Errorf("invalid mode %q (only permission bits, 0001-0777)", s)
+	}
+	return os.FileMode(mode), nil
+}
+
+// loadTemplateDir loads the directory template in dir
+func loadTemplateDir(dir string) (*CustomTemplate, error) {
+	tmpl, err := LoadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tmpl.readTree(filepath.Join(dir, FilesDir)); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// readTree adds the files under root to the template
+func (t *CustomTemplate) readTree(root string) error {
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Directories are created as needed; symlinks and devices are skipped
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		verbatim := !strings.HasSuffix(name, TemplateSuffix)
+		name = strings.TrimSuffix(name, TemplateSuffix)
+
+		if _, ok := t.files[name]; ok {
+			return fmt.Errorf("%s is defined twice in template %s (in %s and %s/, or with and without %s)", name, t.name, ManifestFile, FilesDir, TemplateSuffix)
+		}
+		t.files[name] = string(data)
+		t.verbatim[name] = verbatim
+		t.modes[name] = info.Mode().Perm()
+		return nil
+	})
+}
+
+// Variables returns the inherited default variables merged with the template's own
+func (t *CustomTemplate) Variables() map[string]string {
+	return t.resolveVariables(nil)
+}
+
+// resolveVariables is Variables with cycle detection through seen
+func (t *CustomTemplate) resolveVariables(seen []string) map[string]string {
+	result := make(map[string]string)
+	if t.extends != "" {
+		if parent, err := parentTemplate(t.extends, append(seen, t.name)); err == nil {
+			var inherited map[string]string
+			if custom, ok := parent.(*CustomTemplate); ok {
+				inherited = custom.resolveVariables(append(seen, t.name))
+			} else {
+				inherited = VariablesOf(parent)
+			}
+			for key, value := range inherited {
+				result[key] = value
+			}
+		}
+	}
+
+	for key, value := range t.variables {
+		result[key] = value
+	}
+	return result
+}
+
+// FileModes returns the modes of the template's files, inherited ones
+// included. A file the template replaces takes the mode of its replacement.
+func (t *CustomTemplate) FileModes() map[string]os.FileMode {
+	return t.resolveModes(nil)
+}
+
+// resolveModes is FileModes with cycle detection through seen
+func (t *CustomTemplate) resolveModes(seen []string) map[string]os.FileMode {
+	result := make(map[string]os.FileMode)
+	if t.extends != "" {
+		if parent, err := parentTemplate(t.extends, append(seen, t.name)); err == nil {
+			var inherited map[string]os.FileMode
+			if custom, ok := parent.(*CustomTemplate); ok {
+				inherited = custom.resolveModes(append(seen, t.name))
+			} else if moder, ok := parent.(FileModer); ok {
+				inherited = moder.FileModes()
+			}
+			for name, mode := range inherited {
+				result[name] = mode
+			}
+		}
+	}
+
+	for name := range t.files {
+		if mode, ok := t.modes[name]; ok {
+			result[name] = mode
+		} else {
+			delete(result, name)
+		}
+	}
+	return result
+}
+
+// resolveVerbatim returns the files, inherited ones included, whose content
+// is copied without rendering
+func (t *CustomTemplate) resolveVerbatim(seen []string) map[string]bool {
+	result := make(map[string]bool)
+	if t.extends != "" {
+		if parent, err := parentTemplate(t.extends, append(seen, t.name)); err == nil {
+			if custom, ok := parent.(*CustomTemplate); ok {
+				result = custom.resolveVerbatim(append(seen, t.name))
+			}
+		}
+	}
+
+	for name := range t.files {
+		if t.verbatim[name] {
+			result[name] = true
+		} else {
+			delete(result, name)
+		}
+	}
+	return result
+}
+
+// SetDefaultVariables sets the template's default variables that vars doesn't
+// set yet, e.g. one added to the template after the project was generated
+func SetDefaultVariables(vars *TemplateVars, tmpl Template) error {
+	defaults := VariablesOf(tmpl)
+	keys := make([]string, 0, len(defaults))
+	for key := range defaults {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if vars.Has(key) {
+			continue
+		}
+		if err := vars.Set(key, defaults[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTree creates a directory template in dir
+func writeTree(t *testing.T, dir, manifest string, files map[string]string, modes map[string]os.FileMode) {
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, FilesDir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		mode := modes[name]
+		if mode == 0 {
+			mode = 0644
+		}
+		if err := os.WriteFile(path, []byte(content), mode); err != nil {
+			t.Fatal(err)
+		}
+		os.Chmod(path, mode)
+	}
+}
+
+func TestLoadTemplateDir(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-tree-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	binary := "\x89PNG\r\n\x1a\n\x00{{not a template}}\xff"
+	writeTree(t, tmpDir, `name: tree
+description: Directory template
+variables:
+  port: "8080"
+files:
+  .env: "PORT={{.port}}\n"
+`, map[string]string{
+		"README.md.tmpl":               "# {{.ProjectName}}\n",
+		"docs/raw.md":                  "Use {{.ProjectName}} literally\n",
+		"scripts/build.sh":             "#!/bin/sh\necho build\n",
+		"assets/logo.png":              binary,
+		"cmd/{{.ProjectName}}/main.go": "package main\n",
+	}, map[string]os.FileMode{"scripts/build.sh": 0755})
+
+	tmpl, err := LoadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadDir() error = %v", err)
+	}
+
+	vars := DefaultVars("app")
+	if err := SetDefaultVariables(&vars, tmpl); err != nil {
+		t.Fatal(err)
+	}
+	files, err := Render(tmpl, vars)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	tests := map[string]string{
+		"README.md":        "# app\n",
+		".env":             "PORT=8080\n",
+		"docs/raw.md":      "Use {{.ProjectName}} literally\n",
+		"assets/logo.png":  binary,
+		"cmd/app/main.go":  "package main\n",
+		"scripts/build.sh": "#!/bin/sh\necho build\n",
+	}
+	for name, want := range tests {
+		if got, ok := files[name]; !ok || got != want {
+			t.Errorf("files[%s] = %q, want %q", name, got, want)
+		}
+	}
+	if _, ok := files["README.md.tmpl"]; ok {
+		t.Error("the .tmpl suffix should be dropped")
+	}
+
+	modes := Modes(tmpl, vars)
+	if modes["scripts/build.sh"] != 0755 {
+		t.Errorf("mode of scripts/build.sh = %v, want 0755", modes["scripts/build.sh"])
+	}
+	if modes["cmd/app/main.go"] != 0644 {
+		t.Errorf("mode of cmd/app/main.go = %v, want 0644 (keyed by rendered path)", modes["cmd/app/main.go"])
+	}
+}
+
+func TestLoadTemplateDir_Duplicate(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-tree-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	writeTree(t, tmpDir, "name: dup\n", map[string]string{
+		"README.md":      "plain",
+		"README.md.tmpl": "rendered",
+	}, nil)
+
+	if _, err := LoadDir(tmpDir); err == nil {
+		t.Error("LoadDir() should fail when a file is defined twice")
+	}
+}
+
+func TestLoadCustomTemplates_Directory(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-tree-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	dir := filepath.Join(tmpDir, "tree-registered")
+	os.MkdirAll(dir, 0755)
+	writeTree(t, dir, "name: tree-registered\nextends: base\n", map[string]string{"Makefile": "build:\n"}, nil)
+	os.MkdirAll(filepath.Join(tmpDir, "not-a-template"), 0755)
+
+	if err := LoadCustomTemplates(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, ok := Get("tree-registered")
+	if !ok {
+		t.Fatal("directory template not registered")
+	}
+	files := tmpl.Files("app")
+	if _, ok := files["Makefile"]; !ok {
+		t.Error("missing Makefile from files/")
+	}
+	if _, ok := files["README.md"]; !ok {
+		t.Error("missing README.md inherited from base")
+	}
+}
+
+func TestSetDefaultVariables(t *testing.T) {
+	tmpl := &CustomTemplate{name: "defaults", variables: map[string]string{"port": "8080", "team": "core", "license": "Apache-2.0"}}
+
+	vars := DefaultVars("app")
+	vars.Set("team", "platform")
+	if err := SetDefaultVariables(&vars, tmpl); err != nil {
+		t.Fatal(err)
+	}
+
+	if vars.Extra["port"] != "8080" {
+		t.Errorf("port = %q, want the template default", vars.Extra["port"])
+	}
+	if vars.Extra["team"] != "platform" {
+		t.Errorf("team = %q, an existing value should win", vars.Extra["team"])
+	}
+	if vars.License != "MIT" {
+		t.Errorf("License = %q, a set field should win", vars.License)
+	}
+}
+
+func TestFileMode(t *testing.T) {
+	modes := map[string]os.FileMode{"bin/tool": 0700, "docs/run.sh": 0644}
+
+	tests := []struct {
+		path    string
+		content string
+		want    os.FileMode
+	}{
+		{"bin/tool", "binary", 0700},
+		{"docs/run.sh", "#!/bin/sh\n", 0644}, // an explicit mode wins over the shebang
+		{"scripts/build.sh", "#!/usr/bin/env bash\nset -e\n", 0755},
+		{"README.md", "# Title\n", 0644},
+		{"notes.txt", " #!not a shebang\n", 0644},
+	}
+
+	for _, tt := range tests {
+		if got := FileMode(modes, tt.path, tt.content); got != tt.want {
+			t.Errorf("FileMode(%q) = %04o, want %04o", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    os.FileMode
+		wantErr bool
+	}{
+		{"0755", 0755, false},
+		{"755", 0755, false},
+		{"0o600", 0600, false},
+		{"0", 0, true},
+		{"0888", 0, true},
+		{"01755", 0, true},
+		{"rwx", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMode(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMode(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMode(%q) = %04o, want %04o", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLoadFile_Modes(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "maajise-tree-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "modes.yaml")
+	os.WriteFile(path, []byte(`name: modes
+files:
+  scripts/{{.ProjectName}}-setup:
+    mode: 0755
+    content: "echo setup\n"
+  secrets.env:
+    mode: "0600"
+    content: "TOKEN=\n"
+  README.md: "# {{.ProjectName}}\n"
+`), 0644)
+
+	tmpl, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	modes := Modes(tmpl, DefaultVars("app"))
+	if modes["scripts/app-setup"] != 0755 {
+		t.Errorf("mode of scripts/app-setup = %04o, want 0755", modes["scripts/app-setup"])
+	}
+	if modes["secrets.env"] != 0600 {
+		t.Errorf("mode of secrets.env = %04o, want 0600", modes["secrets.env"])
+	}
+	if _, ok := modes["README.md"]; ok {
+		t.Errorf("README.md has mode %04o, want none", modes["README.md"])
+	}
+
+	os.WriteFile(path, []byte("name: bad-mode\nfiles:\n  run.sh:\n    mode: \"0999\"\n    content: x\n"), 0644)
+	if _, err := LoadFile(path); err == nil {
+		t.Error("LoadFile() should fail for an invalid mode")
+	}
+}
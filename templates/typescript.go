@@ -1,6 +1,10 @@
 package templates
 
-import "fmt"
+import (
+	"fmt"
+
+	"maajise/internal/managed"
+)
 
 func init() {
 	Register(&TypeScriptTemplate{})
@@ -21,10 +25,16 @@ func (t *TypeScriptTemplate) Dependencies() []string {
 	return []string{"git", "br", "node", "npm"}
 }
 
+func (t *TypeScriptTemplate) Hooks() []Hook {
+	return []Hook{
+		{Name: "Install npm dependencies", Run: "npm install", Optional: true, Commit: []string{"package-lock.json"}},
+	}
+}
+
 func (t *TypeScriptTemplate) Files(projectName string) map[string]string {
 	return map[string]string{
-		".gitignore":               t.gitignore(),
-		".ubsignore":               t.ubsignore(),
+		".gitignore":               managed.Wrap(t.Name(), t.gitignore()),
+		".ubsignore":               managed.Wrap(t.Name(), t.ubsignore()),
 		"README.md":                t.readme(projectName),
 		"package.json":             t.packageJSON(projectName),
 		"tsconfig.json":            t.tsconfig(),
@@ -94,6 +104,7 @@ coverage/
 .vscode/
 .idea/
 .beads/
+.maajise/
 .claude/
 *.md
 *.json
@@ -204,7 +215,7 @@ func (t *TypeScriptTemplate) packageJSON(projectName string) string {
   },
   "keywords": [],
   "author": "",
-  "license": "ISC",
+  "license": "{{if .License}}{{.License}}{{else}}UNLICENSED{{end}}",
   "devDependencies": {
     "typescript": "^5.0.0"
   }
@@ -45,6 +45,17 @@ func TestProcessTemplate_NoVars(t *testing.T) {
 	}
 }
 
+func TestProcessTemplate_Errors(t *testing.T) {
+	vars := TemplateVars{ProjectName: "test"}
+
+	if _, err := ProcessTemplate("{{.Missing}}", vars); err == nil {
+		t.Error("ProcessTemplate() should fail for an unknown variable")
+	}
+	if _, err := ProcessTemplate("{{.ProjectName", vars); err == nil {
+		t.Error("ProcessTemplate() should fail for a syntax error")
+	}
+}
+
 func TestDefaultVars(t *testing.T) {
 	vars := DefaultVars("my-project")
 
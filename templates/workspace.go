@@ -0,0 +1,308 @@
+package templates
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"maajise/internal/managed"
+)
+
+func init() {
+	Register(&WorkspaceTemplate{})
+}
+
+// WorkspaceName is the template of a workspace root. Its sub-projects are
+// added with `maajise add project`.
+const WorkspaceName = "workspace"
+
+// AppsDir holds the sub-projects of a workspace
+const AppsDir = "apps"
+
+// WorkspaceOwned lists the files a workspace root generates for all of its
+// sub-projects, so they are left out of each sub-project
+var WorkspaceOwned = []string{".gitignore", ".ubsignore", LicenseFile}
+
+// WorkspaceFiles lists the files of a workspace root that change as
+// projects are added
+var WorkspaceFiles = []string{".gitignore", ".ubsignore", "go.work", "package.json", "pnpm-workspace.yaml", "Cargo.toml"}
+
+// WorkspaceTemplate is a monorepo root: one Git repository and one Beads
+// database shared by the projects under apps/
+type WorkspaceTemplate struct{}
+
+// Project is a sub-project of a workspace, as recorded in the root manifest
+type Project struct {
+	Name     string `yaml:"name"`
+	Template string `yaml:"template"`
+
+	// What the root last rendered for the project, reused until the
+	// project's own manifest or the root's ignore rules change: the built-in
+	// template it is based on, its scoped ignore rules keyed by root file,
+	// and the digest of the manifest and rules they were rendered from
+	Base    string            `yaml:"base,omitempty"`
+	Ignores map[string]string `yaml:"ignores,omitempty"`
+	Digest  string            `yaml:"digest,omitempty"`
+}
+
+// Dir returns the sub-project directory relative to the workspace root
+func (p Project) Dir() string {
+	return path.Join(AppsDir, p.Name)
+}
+
+// ProjectBlock returns the name of the managed block holding a sub-project's
+// ignore rules in the workspace's .gitignore and .ubsignore
+func ProjectBlock(name string) string {
+	return "project-" + name
+}
+
+func (t *WorkspaceTemplate) Name() string {
+	return WorkspaceName
+}
+
+func (t *WorkspaceTemplate) Description() string {
+	return "Monorepo workspace; add sub-projects under apps/ with 'maajise add project'"
+}
+
+func (t *WorkspaceTemplate) Dependencies() []string {
+	return []string{"git", "br"}
+}
+
+func (t *WorkspaceTemplate) Files(projectName string) map[string]string {
+	return map[string]string{
+		".gitignore":          managed.Wrap(t.Name(), t.gitignore()),
+		".ubsignore":          managed.Wrap(t.Name(), t.ubsignore()),
+		"README.md":           t.readme(projectName),
+		AppsDir + "/.gitkeep": "",
+	}
+}
+
+func (t *WorkspaceTemplate) gitignore() string {
+	return `# Workspace dependencies and build output
+/node_modules/
+/target/
+
+# IDE
+.vscode/
+.idea/
+*.swp
+*.swo
+
+# OS
+.DS_Store
+Thumbs.db
+
+# Environment
+.env
+.env.local
+`
+}
+
+func (t *WorkspaceTemplate) ubsignore() string {
+	return `# UBS Scanner Ignore File
+node_modules/
+target/
+.git/
+.vscode/
+.idea/
+.beads/
+.maajise/
+.claude/
+*.md
+`
+}
+
+func (t *WorkspaceTemplate) readme(projectName string) string {
+	return fmt.Sprintf(`# %s
+
+A monorepo workspace. Each project lives in its own directory under apps/ and
+shares this repository, its Beads issue database and the root .gitignore.
+
+## Project Structure
+
+| Path | Purpose |
+|------|---------|
+| apps/<name>/ | One project per directory |
+| go.work | Go workspace, once a Go project is added |
+| package.json, pnpm-workspace.yaml | npm and pnpm workspaces, once a TypeScript project is added |
+| Cargo.toml | Cargo workspace, once a Rust project is added |
+
+## Adding Projects
+
+`+"```bash"+`
+# Add a Go service in apps/api
+maajise add project api --template=go
+
+# Add a TypeScript app in apps/web
+maajise add project web --template=typescript
+`+"```"+`
+
+## Issue Tracking
+
+`+"```bash"+`
+br list           # View issues
+br create --title "Task"  # Create issue
+`+"```"+`
+
+## Code Quality
+
+`+"```bash"+`
+ubs .             # Scan for bugs
+`+"```"+`
+`, projectName)
+}
+
+// WorkspaceManifests returns the language workspace files for the given
+// sub-projects, keyed by path: go.work for Go projects, npm and pnpm
+// workspaces for TypeScript projects and a Cargo workspace for Rust
+// projects. bases maps each project name to the built-in template its
+// template is based on, and goVersion is the highest go directive of the Go
+// projects, if known.
+func WorkspaceManifests(projectName string, projects []Project, bases map[string]string, goVersion string) map[string]string {
+	members := make(map[string][]string)
+	for _, p := range projects {
+		base := bases[p.Name]
+		members[base] = append(members[base], p.Dir())
+	}
+	for _, dirs := range members {
+		sort.Strings(dirs)
+	}
+
+	files := make(map[string]string)
+	if dirs := members["go"]; len(dirs) > 0 {
+		files["go.work"] = goWork(dirs, goVersion)
+	}
+	if dirs := members["typescript"]; len(dirs) > 0 {
+		files["package.json"] = npmWorkspace(projectName, dirs)
+		files["pnpm-workspace.yaml"] = pnpmWorkspace(dirs)
+	}
+	if dirs := members["rust"]; len(dirs) > 0 {
+		files["Cargo.toml"] = cargoWorkspace(dirs)
+	}
+	return files
+}
+
+// goWork returns a go.work using dirs; a workspace can't declare a lower go
+// version than any of its modules
+func goWork(dirs []string, version string) string {
+	if version == "" {
+		version = goVersion
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "go %s\n\nuse (\n", version)
+	for _, dir := range dirs {
+		fmt.Fprintf(&b, "\t./%s\n", dir)
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
+func npmWorkspace(projectName string, dirs []string) string {
+	quoted := make([]string, len(dirs))
+	for i, dir := range dirs {
+		quoted[i] = fmt.Sprintf("    %q", dir)
+	}
+	return fmt.Sprintf(`{
+  "name": %q,
+  "private": true,
+  "workspaces": [
+%s
+  ]
+}
+`, projectName, strings.Join(quoted, ",\n"))
+}
+
+func pnpmWorkspace(dirs []string) string {
+	var b strings.Builder
+	b.WriteString("packages:\n")
+	for _, dir := range dirs {
+		fmt.Fprintf(&b, "  - %q\n", dir)
+	}
+	return b.String()
+}
+
+func cargoWorkspace(dirs []string) string {
+	var b strings.Builder
+	b.WriteString("[workspace]\nresolver = \"2\"\nmembers = [\n")
+	for _, dir := range dirs {
+		fmt.Fprintf(&b, "    %q,\n", dir)
+	}
+	b.WriteString("]\n")
+	return b.String()
+}
+
+// ScopeIgnore rewrites the rules of a sub-project's .gitignore or .ubsignore
+// so they only match inside dir when used at the workspace root. Rules that
+// root already applies everywhere are dropped, along with managed block
+// markers and comment sections left without rules.
+func ScopeIgnore(content, dir, root string) string {
+	shared := make(map[string]bool)
+	for _, line := range strings.Split(root, "\n") {
+		shared[strings.TrimSpace(line)] = true
+	}
+
+	var sections [][]string
+	var section []string
+	hasRule := false
+	flush := func() {
+		if hasRule {
+			sections = append(sections, section)
+		}
+		section, hasRule = nil, false
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, managed.BeginPrefix), strings.HasPrefix(line, managed.EndPrefix):
+			continue
+		case line == "":
+			flush()
+			continue
+		case strings.HasPrefix(line, "#"):
+			section = append(section, line)
+			continue
+		}
+
+		rule := scopeRule(line, dir)
+		if rule == "" || (!anchored(line) && shared[line]) {
+			continue
+		}
+		section = append(section, rule)
+		hasRule = true
+	}
+	flush()
+
+	blocks := make([]string, len(sections))
+	for i, s := range sections {
+		blocks[i] = strings.Join(s, "\n") + "\n"
+	}
+	return strings.Join(blocks, "\n")
+}
+
+// scopeRule prefixes a single ignore rule with dir. Following gitignore,
+// a rule with a slash anywhere but at its end is relative to the file's
+// directory; any other rule matches at every depth below it.
+func scopeRule(rule, dir string) string {
+	negate := strings.HasPrefix(rule, "!")
+	pattern := strings.TrimPrefix(rule, "!")
+	if pattern == "" {
+		return ""
+	}
+
+	if anchored(pattern) {
+		pattern = dir + "/" + strings.TrimPrefix(pattern, "/")
+	} else {
+		pattern = dir + "/**/" + pattern
+	}
+	if negate {
+		return "!" + pattern
+	}
+	return pattern
+}
+
+func anchored(pattern string) bool {
+	pattern = strings.TrimPrefix(pattern, "!")
+	return strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+}
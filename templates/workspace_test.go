@@ -0,0 +1,101 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+
+	"maajise/internal/managed"
+)
+
+func TestWorkspaceTemplate(t *testing.T) {
+	tmpl, ok := Get(WorkspaceName)
+	if !ok {
+		t.Fatal("workspace template not registered")
+	}
+
+	files := tmpl.Files("platform")
+
+	expectedFiles := []string{".gitignore", ".ubsignore", "README.md", "apps/.gitkeep"}
+	if len(files) != len(expectedFiles) {
+		t.Fatalf("Files() returned %d files, want %d", len(files), len(expectedFiles))
+	}
+	for _, f := range expectedFiles {
+		if _, ok := files[f]; !ok {
+			t.Errorf("missing file: %s", f)
+		}
+	}
+
+	if !strings.Contains(files["README.md"], "maajise add project api --template=go") {
+		t.Error("README.md should explain how to add projects")
+	}
+	if !strings.Contains(files[".gitignore"], "/node_modules/") {
+		t.Error(".gitignore should ignore the root node_modules of npm workspaces")
+	}
+}
+
+func TestWorkspaceManifests(t *testing.T) {
+	projects := []Project{
+		{Name: "web", Template: "typescript"},
+		{Name: "api", Template: "go"},
+		{Name: "worker", Template: "./templates/service"},
+		{Name: "tool", Template: "rust"},
+		{Name: "docs", Template: "base"},
+	}
+	bases := map[string]string{"web": "typescript", "api": "go", "worker": "go", "tool": "rust", "docs": "base"}
+
+	files := WorkspaceManifests("platform", projects, bases, "")
+
+	if len(files) != 4 {
+		t.Errorf("WorkspaceManifests() returned %d files, want 4", len(files))
+	}
+	if want := "go 1.23\n\nuse (\n\t./apps/api\n\t./apps/worker\n)\n"; files["go.work"] != want {
+		t.Errorf("go.work = %q, want %q", files["go.work"], want)
+	}
+	if !strings.Contains(files["package.json"], `"name": "platform",`) ||
+		!strings.Contains(files["package.json"], "\"workspaces\": [\n    \"apps/web\"\n  ]") {
+		t.Errorf("package.json = %q, want apps/web as a workspace", files["package.json"])
+	}
+	if files["pnpm-workspace.yaml"] != "packages:\n  - \"apps/web\"\n" {
+		t.Errorf("pnpm-workspace.yaml = %q", files["pnpm-workspace.yaml"])
+	}
+	if !strings.Contains(files["Cargo.toml"], "members = [\n    \"apps/tool\",\n]") {
+		t.Errorf("Cargo.toml = %q, want apps/tool as a member", files["Cargo.toml"])
+	}
+
+	if files := WorkspaceManifests("platform", projects, bases, "1.24.2"); !strings.HasPrefix(files["go.work"], "go 1.24.2\n") {
+		t.Errorf("go.work = %q, want the members' go version", files["go.work"])
+	}
+	if files := WorkspaceManifests("platform", nil, nil, ""); len(files) != 0 {
+		t.Errorf("WorkspaceManifests() without projects = %v, want none", files)
+	}
+}
+
+func TestScopeIgnore(t *testing.T) {
+	content := managed.Wrap("typescript", `# Dependencies
+node_modules/
+
+# Build outputs
+/dist/
+*.js
+!*.config.js
+**/generated/*
+
+# IDE
+.idea/
+`)
+	root := managed.Wrap(WorkspaceName, ".idea/\n")
+
+	got := ScopeIgnore(content, "apps/web", root)
+	want := `# Dependencies
+apps/web/**/node_modules/
+
+# Build outputs
+apps/web/dist/
+apps/web/**/*.js
+!apps/web/**/*.config.js
+apps/web/**/generated/*
+`
+	if got != want {
+		t.Errorf("ScopeIgnore() = %q, want %q", got, want)
+	}
+}
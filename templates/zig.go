@@ -0,0 +1,185 @@
+package templates
+
+import (
+	"fmt"
+
+	"maajise/internal/managed"
+)
+
+func init() {
+	Register(&ZigTemplate{})
+}
+
+// ZigTemplate is a Zig project template laid out like `zig init`
+type ZigTemplate struct{}
+
+// zigVersion is the Zig release the build files are written for
+const zigVersion = "0.13.0"
+
+func (t *ZigTemplate) Name() string {
+	return "zig"
+}
+
+func (t *ZigTemplate) Description() string {
+	return "Zig project with build.zig and build.zig.zon"
+}
+
+func (t *ZigTemplate) Dependencies() []string {
+	return []string{"git", "br", "zig"}
+}
+
+func (t *ZigTemplate) Files(projectName string) map[string]string {
+	return map[string]string{
+		".gitignore":    managed.Wrap(t.Name(), t.gitignore()),
+		".ubsignore":    managed.Wrap(t.Name(), t.ubsignore()),
+		"README.md":     t.readme(projectName),
+		"build.zig":     t.buildZig(projectName),
+		"build.zig.zon": t.buildZon(projectName),
+		"src/main.zig":  t.mainZig(),
+	}
+}
+
+func (t *ZigTemplate) gitignore() string {
+	return `# Zig build cache and output
+.zig-cache/
+zig-cache/
+zig-out/
+
+# IDE
+.vscode/
+.idea/
+*.swp
+*.swo
+
+# OS
+.DS_Store
+Thumbs.db
+
+# Environment
+.env
+`
+}
+
+func (t *ZigTemplate) ubsignore() string {
+	return `# UBS Scanner Ignore File
+.zig-cache/
+zig-cache/
+zig-out/
+.git/
+.vscode/
+.idea/
+.beads/
+.maajise/
+.claude/
+*.md
+*.zon
+`
+}
+
+func (t *ZigTemplate) readme(projectName string) string {
+	return fmt.Sprintf(`# %[1]s
+
+A Zig project, written for Zig %[2]s.
+
+## Development
+
+`+"```bash"+`
+# Build into zig-out/bin/
+zig build
+
+# Run the application
+zig build run
+
+# Run the tests
+zig build test
+
+# Build an optimized binary
+zig build -Doptimize=ReleaseSafe
+
+# Format the code
+zig fmt .
+`+"```"+`
+
+## Issue Tracking
+
+`+"```bash"+`
+br list           # View issues
+br create --title "Task"  # Create issue
+`+"```"+`
+
+## Code Quality
+
+`+"```bash"+`
+ubs .             # Scan for bugs
+`+"```"+`
+`, projectName, zigVersion)
+}
+
+func (t *ZigTemplate) buildZig(projectName string) string {
+	return fmt.Sprintf(`const std = @import("std");
+
+pub fn build(b: *std.Build) void {
+    const target = b.standardTargetOptions(.{});
+    const optimize = b.standardOptimizeOption(.{});
+
+    const exe = b.addExecutable(.{
+        .name = "%s",
+        .root_source_file = b.path("src/main.zig"),
+        .target = target,
+        .optimize = optimize,
+    });
+    b.installArtifact(exe);
+
+    const run_cmd = b.addRunArtifact(exe);
+    run_cmd.step.dependOn(b.getInstallStep());
+    if (b.args) |args| {
+        run_cmd.addArgs(args);
+    }
+    const run_step = b.step("run", "Run the application");
+    run_step.dependOn(&run_cmd.step);
+
+    const exe_tests = b.addTest(.{
+        .root_source_file = b.path("src/main.zig"),
+        .target = target,
+        .optimize = optimize,
+    });
+    const run_exe_tests = b.addRunArtifact(exe_tests);
+    const test_step = b.step("test", "Run the tests");
+    test_step.dependOn(&run_exe_tests.step);
+}
+`, projectName)
+}
+
+func (t *ZigTemplate) buildZon(projectName string) string {
+	return fmt.Sprintf(`.{
+    .name = "%s",
+    .version = "0.1.0",
+    .minimum_zig_version = "%s",
+    .dependencies = .{},
+    .paths = .{
+        "build.zig",
+        "build.zig.zon",
+        "src",
+    },
+}
+`, projectName, zigVersion)
+}
+
+func (t *ZigTemplate) mainZig() string {
+	return `const std = @import("std");
+
+pub fn greeting() []const u8 {
+    return "Hello, Zig!";
+}
+
+pub fn main() !void {
+    const stdout = std.io.getStdOut().writer();
+    try stdout.print("{s}\n", .{greeting()});
+    // TODO: Add your application code here
+}
+
+test "greeting" {
+    try std.testing.expectEqualStrings("Hello, Zig!", greeting());
+}
+`
+}
@@ -0,0 +1,44 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestZigTemplate(t *testing.T) {
+	tmpl, ok := Get("zig")
+	if !ok {
+		t.Fatal("zig template not registered")
+	}
+
+	files := tmpl.Files("zcat")
+
+	expectedFiles := []string{".gitignore", ".ubsignore", "README.md", "build.zig", "build.zig.zon", "src/main.zig"}
+	if len(files) != len(expectedFiles) {
+		t.Fatalf("Files() returned %d files, want %d", len(files), len(expectedFiles))
+	}
+	for _, f := range expectedFiles {
+		if _, ok := files[f]; !ok {
+			t.Errorf("missing file: %s", f)
+		}
+	}
+
+	if !strings.Contains(files["build.zig"], `.name = "zcat",`) {
+		t.Error("build.zig should name the executable after the project")
+	}
+	if !strings.Contains(files["build.zig.zon"], `.minimum_zig_version = "`+zigVersion+`",`) {
+		t.Error("build.zig.zon should pin the minimum Zig version")
+	}
+	if !strings.Contains(files[".gitignore"], ".zig-cache/") {
+		t.Error(".gitignore should ignore the build cache")
+	}
+
+	// Rendering must leave Zig's anonymous struct literals alone
+	rendered, err := Render(tmpl, DefaultVars("zcat"))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if rendered["src/main.zig"] != files["src/main.zig"] {
+		t.Error("Render() should not change src/main.zig")
+	}
+}